@@ -16,6 +16,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/kubenext/kubeon/internal/proxy"
 	"github.com/vmware/octant/internal/config"
 	"github.com/vmware/octant/internal/log"
 	"github.com/vmware/octant/internal/mime"
@@ -147,9 +148,15 @@ func (a *API) Handler(ctx context.Context) (*mux.Router, error) {
 	router := mux.NewRouter()
 	router.Use(rebindHandler(ctx, acceptedHosts()))
 
+	router.PathPrefix(proxy.PathPrefix + "/{resource}/{namespace}/{name}/{port}").
+		Handler(serviceProxyHandler(ctx, a.dashConfig, acceptedHosts()))
+
 	s := router.PathPrefix(a.prefix).Subrouter()
 
-	s.HandleFunc("/logs/namespace/{namespace}/pod/{pod}/container/{container}", containerLogsHandler(ctx, a.dashConfig.ClusterClient()))
+	s.HandleFunc("/logs/download", logsDownloadHandler(ctx, a.dashConfig))
+	s.HandleFunc("/containers/files", containerFilesListHandler(ctx, a.dashConfig)).Methods(http.MethodGet)
+	s.HandleFunc("/containers/files/download", containerFilesDownloadHandler(ctx, a.dashConfig)).Methods(http.MethodGet)
+	s.Handle("/containers/files/upload", originHandler(ctx, acceptedHosts())(containerFilesUploadHandler(ctx, a.dashConfig))).Methods(http.MethodPost)
 
 	manager := NewWebsocketClientManager(ctx, a.actionDispatcher)
 	go manager.Run(ctx)
@@ -0,0 +1,259 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubenext/kubeon/internal/containerfs"
+	"github.com/vmware/octant/internal/cluster"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/pkg/store"
+)
+
+const (
+	// maxUploadMemory is the amount of an upload kept in memory. The rest
+	// is stored in temporary files.
+	maxUploadMemory = 32 << 20
+)
+
+// ContainerFilesConfig is configuration for the container file handlers.
+type ContainerFilesConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// containerFilesRequest is the container and path of a container file request.
+// They are read from the namespace, podName, containerName and path query parameters.
+type containerFilesRequest struct {
+	container containerfs.Container
+	path      string
+}
+
+func containerFilesRequestFromQuery(r *http.Request) (containerFilesRequest, error) {
+	query := r.URL.Query()
+
+	request := containerFilesRequest{
+		container: containerfs.Container{
+			Namespace: query.Get("namespace"),
+			PodName:   query.Get("podName"),
+			Name:      query.Get("containerName"),
+		},
+		path: query.Get("path"),
+	}
+
+	switch {
+	case request.container.Namespace == "":
+		return request, errors.New("namespace is required")
+	case request.container.PodName == "":
+		return request, errors.New("podName is required")
+	case request.container.Name == "":
+		return request, errors.New("containerName is required")
+	case request.path == "":
+		request.path = "/"
+	}
+
+	return request, nil
+}
+
+// containerFilesHandler handles a container file request. It checks pods/exec
+// access and creates the container file system before calling fn.
+func containerFilesHandler(ctx context.Context, config ContainerFilesConfig, fn func(w http.ResponseWriter, r *http.Request, fs *containerfs.FS, p string) error) http.HandlerFunc {
+	logger := log.From(ctx)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		request, err := containerFilesRequestFromQuery(r)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, err.Error(), logger)
+			return
+		}
+
+		c := request.container
+		if err := checkExecAccess(r.Context(), config.ObjectStore(), c.Namespace, c.PodName); err != nil {
+			RespondWithError(w, http.StatusForbidden, err.Error(), logger)
+			return
+		}
+
+		clusterClient := config.ClusterClient()
+		kubeClient, err := clusterClient.KubernetesClient()
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+			return
+		}
+
+		fs, err := containerfs.New(kubeClient, clusterClient.RestConfig(), c)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+			return
+		}
+
+		if err := fn(w, r, fs, request.path); err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+			return
+		}
+	}
+}
+
+type containerFilesResponse struct {
+	Path    string              `json:"path"`
+	Entries []containerfs.Entry `json:"entries"`
+}
+
+// containerFilesListHandler lists a directory in a container.
+func containerFilesListHandler(ctx context.Context, config ContainerFilesConfig) http.HandlerFunc {
+	logger := log.From(ctx)
+
+	return containerFilesHandler(ctx, config, func(w http.ResponseWriter, r *http.Request, fs *containerfs.FS, p string) error {
+		entries, err := fs.List(p)
+		if err != nil {
+			return errors.Wrapf(err, "list %s", p)
+		}
+
+		serveAsJSON(w, containerFilesResponse{Path: path.Clean(p), Entries: entries}, logger)
+		return nil
+	})
+}
+
+// containerFilesDownloadHandler downloads a file from a container. Directories
+// are downloaded as tar archives. Symbolic links are followed.
+func containerFilesDownloadHandler(ctx context.Context, config ContainerFilesConfig) http.HandlerFunc {
+	logger := log.From(ctx)
+
+	return containerFilesHandler(ctx, config, func(w http.ResponseWriter, r *http.Request, fs *containerfs.FS, p string) error {
+		entry, err := fs.Resolve(p)
+		if err != nil {
+			return errors.Wrapf(err, "stat %s", p)
+		}
+
+		fileName := entry.Name
+		if fileName == "/" {
+			fileName = "root"
+		}
+
+		// the type is checked before the response starts so errors can
+		// still be returned
+		var download func(p string, w io.Writer) error
+		var contentType string
+		switch entry.Type {
+		case containerfs.EntryTypeFile:
+			download = fs.Download
+			contentType = "application/octet-stream"
+		case containerfs.EntryTypeDirectory:
+			download = fs.Archive
+			contentType = "application/x-tar"
+			fileName += ".tar"
+		default:
+			return errors.Errorf("%s can't be downloaded because it is not a file or directory", entry.Path)
+		}
+
+		dw := &downloadWriter{
+			w:           w,
+			contentType: contentType,
+			fileName:    fileName,
+		}
+
+		if err := download(entry.Path, dw); err != nil {
+			if !dw.started {
+				return errors.Wrapf(err, "download %s", entry.Path)
+			}
+
+			// the response has started, so errors can only be logged
+			logger.WithErr(err).Errorf("download %s", entry.Path)
+			return nil
+		}
+
+		// empty files don't write any output
+		dw.start()
+		return nil
+	})
+}
+
+// downloadWriter writes the download headers with the first chunk of output.
+// Until then, the response hasn't started and an error can still be returned
+// if the exec stream fails.
+type downloadWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	fileName    string
+	started     bool
+}
+
+var _ io.Writer = (*downloadWriter)(nil)
+
+func (dw *downloadWriter) start() {
+	if dw.started {
+		return
+	}
+	dw.started = true
+
+	dw.w.Header().Set("Content-Type", dw.contentType)
+	dw.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", dw.fileName))
+	dw.w.WriteHeader(http.StatusOK)
+}
+
+// Write starts the response and writes p to it.
+func (dw *downloadWriter) Write(p []byte) (int, error) {
+	dw.start()
+	return dw.w.Write(p)
+}
+
+type containerFilesUploadResponse struct {
+	Path     string   `json:"path"`
+	Uploaded []string `json:"uploaded"`
+}
+
+// containerFilesUploadHandler uploads the files in a multipart form's file
+// fields to a directory in a container.
+func containerFilesUploadHandler(ctx context.Context, config ContainerFilesConfig) http.HandlerFunc {
+	logger := log.From(ctx)
+
+	return containerFilesHandler(ctx, config, func(w http.ResponseWriter, r *http.Request, fs *containerfs.FS, p string) error {
+		if err := r.ParseMultipartForm(maxUploadMemory); err != nil {
+			return errors.Wrap(err, "parse upload")
+		}
+		defer func() {
+			if err := r.MultipartForm.RemoveAll(); err != nil {
+				logger.WithErr(err).Errorf("remove uploaded files")
+			}
+		}()
+
+		headers := r.MultipartForm.File["file"]
+		if len(headers) == 0 {
+			return errors.New("upload does not contain any files")
+		}
+
+		var files []containerfs.File
+		var names []string
+		for _, header := range headers {
+			f, err := header.Open()
+			if err != nil {
+				return errors.Wrapf(err, "open %s", header.Filename)
+			}
+			defer f.Close()
+
+			name := path.Base(header.Filename)
+			files = append(files, containerfs.File{
+				Name: name,
+				Size: header.Size,
+				Body: f,
+			})
+			names = append(names, name)
+		}
+
+		if err := fs.Upload(p, files); err != nil {
+			return errors.Wrapf(err, "upload to %s", p)
+		}
+
+		serveAsJSON(w, containerFilesUploadResponse{Path: path.Clean(p), Uploaded: names}, logger)
+		return nil
+	})
+}
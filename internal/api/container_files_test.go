@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadWriter(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	dw := &downloadWriter{
+		w:           recorder,
+		contentType: "application/x-tar",
+		fileName:    "etc.tar",
+	}
+
+	// headers aren't written until there is output
+	assert.Empty(t, recorder.Header())
+	assert.False(t, recorder.Flushed)
+
+	_, err := dw.Write([]byte("data"))
+	require.NoError(t, err)
+	_, err = dw.Write([]byte(" more"))
+	require.NoError(t, err)
+
+	assert.True(t, dw.started)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/x-tar", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="etc.tar"`, recorder.Header().Get("Content-Disposition"))
+	assert.Equal(t, "data more", recorder.Body.String())
+}
+
+func TestDownloadWriter_start(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	dw := &downloadWriter{
+		w:           recorder,
+		contentType: "application/octet-stream",
+		fileName:    "empty",
+	}
+
+	// an empty file is started without output
+	dw.start()
+	dw.start()
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, `attachment; filename="empty"`, recorder.Header().Get("Content-Disposition"))
+	assert.Empty(t, recorder.Body.String())
+}
@@ -0,0 +1,340 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/modules/overview/container"
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/store"
+)
+
+var (
+	logQuerySliceFields = map[string]bool{"include": true, "exclude": true, "containers": true}
+	logQueryBoolFields  = map[string]bool{"timestamps": true, "previous": true, "ignoreCase": true}
+)
+
+// logsDownloadHandler downloads the logs of a container or of all pods matching
+// a selector or workload. It accepts the same fields as a log stream request as
+// query parameters; `format=gzip` compresses the download. Logs are never followed.
+func logsDownloadHandler(ctx context.Context, config LogStreamManagerConfig) http.HandlerFunc {
+	logger := log.From(ctx)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		request, err := LogStreamRequestFromPayload(payloadFromQuery(r.URL.Query()))
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, err.Error(), logger)
+			return
+		}
+		request.Options.Follow = false
+
+		kubeClient, err := config.ClusterClient().KubernetesClient()
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+			return
+		}
+
+		lw := &logDownloadWriter{
+			w:        w,
+			fileName: logDownloadFileName(request),
+			gzip:     r.URL.Query().Get("format") == "gzip",
+		}
+
+		err = writeLogs(r.Context(), request, kubeClient, config.ObjectStore(), lw)
+		if err != nil && !lw.started {
+			RespondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+			return
+		}
+		if err != nil {
+			logger.WithErr(err).Errorf("download logs")
+		}
+
+		if err := lw.Close(); err != nil {
+			logger.WithErr(err).Errorf("finish logs download")
+		}
+	}
+}
+
+func writeLogs(ctx context.Context, request LogStreamRequest, kubeClient kubernetes.Interface, objectStore store.Store, lw *logDownloadWriter) error {
+	matcher := newLogMatcher(request.Filter)
+
+	write := func(podName, containerName string) error {
+		lines := make(chan string)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- container.LogsWithOptions(ctx, kubeClient, request.Namespace, podName, containerName, request.Options, lines)
+		}()
+
+		var writeErr error
+		for line := range lines {
+			if writeErr != nil {
+				continue
+			}
+
+			entry := parseLogLine(line, request.Options.Timestamps)
+			if request.IsAggregated() {
+				entry.Pod = podName
+				entry.Container = containerName
+			}
+
+			for _, e := range matcher.Apply(entry) {
+				if writeErr = lw.WriteEntry(e); writeErr != nil {
+					break
+				}
+			}
+		}
+
+		if err := <-errCh; err != nil {
+			return err
+		}
+		return writeErr
+	}
+
+	if !request.IsAggregated() {
+		return write(request.PodName, request.ContainerName)
+	}
+
+	pods, err := selectedPods(ctx, request, objectStore)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range downloadContainers(pods, request.Containers, request.Options.Previous) {
+		if err := write(c.pod, c.container); err != nil {
+			return errors.Wrapf(err, "logs for %s/%s", c.pod, c.container)
+		}
+	}
+
+	return nil
+}
+
+type podContainer struct {
+	pod       string
+	container string
+}
+
+// downloadContainers returns the containers of pods whose logs are
+// downloaded, in the order they are defined. Only containers named in names
+// are included if it isn't empty. Containers which haven't started have no
+// logs and reading them would wait until they start, so they are skipped.
+// With previous, containers which terminated before their current run are
+// included as well.
+func downloadContainers(pods []corev1.Pod, names []string, previous bool) []podContainer {
+	include := make(map[string]bool)
+	for _, name := range names {
+		include[name] = true
+	}
+
+	var list []podContainer
+	for _, pod := range pods {
+		statuses := make(map[string]corev1.ContainerStatus)
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			statuses[status.Name] = status
+		}
+
+		for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			if len(include) > 0 && !include[c.Name] {
+				continue
+			}
+
+			status, ok := statuses[c.Name]
+			if !ok {
+				continue
+			}
+
+			started := status.State.Running != nil || status.State.Terminated != nil
+			if previous {
+				started = status.LastTerminationState.Terminated != nil
+			}
+			if !started {
+				continue
+			}
+
+			list = append(list, podContainer{pod: pod.Name, container: c.Name})
+		}
+	}
+
+	return list
+}
+
+// selectedPods returns the pods matched by an aggregated request sorted by name.
+func selectedPods(ctx context.Context, request LogStreamRequest, objectStore store.Store) ([]corev1.Pod, error) {
+	selector := request.Selector
+	if request.Workload != nil {
+		var err error
+		selector, err = selectorFromWorkload(ctx, objectStore, *request.Workload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key := store.Key{
+		Namespace:  request.Namespace,
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Selector:   selectorToLabelSet(selector),
+	}
+
+	list, _, err := objectStore.List(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "list pods")
+	}
+
+	var pods []corev1.Pod
+	for i := range list.Items {
+		if !selector.Matches(labels.Set(list.Items[i].GetLabels())) {
+			continue
+		}
+
+		pod := corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &pod); err != nil {
+			return nil, errors.Wrap(err, "convert pod")
+		}
+		pods = append(pods, pod)
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+
+	return pods, nil
+}
+
+// logDownloadWriter writes log entries as text. Headers are written with the
+// first entry so errors which happen before any logs are read can still be
+// returned as an error response.
+type logDownloadWriter struct {
+	w        http.ResponseWriter
+	fileName string
+	gzip     bool
+
+	started bool
+	out     *bufio.Writer
+	gz      *gzip.Writer
+}
+
+func (lw *logDownloadWriter) start() {
+	lw.started = true
+
+	fileName := lw.fileName
+	var out io.Writer = lw.w
+	if lw.gzip {
+		fileName += ".gz"
+		lw.w.Header().Set("Content-Type", "application/gzip")
+		lw.gz = gzip.NewWriter(lw.w)
+		out = lw.gz
+	} else {
+		lw.w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+
+	lw.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	lw.out = bufio.NewWriter(out)
+}
+
+// WriteEntry writes an entry as a line.
+func (lw *logDownloadWriter) WriteEntry(entry LogEntry) error {
+	if !lw.started {
+		lw.start()
+	}
+
+	line := entry.Message
+	if entry.Timestamp != nil {
+		line = entry.Timestamp.Format(time.RFC3339Nano) + " " + line
+	}
+	if entry.Pod != "" {
+		line = fmt.Sprintf("[%s/%s] %s", entry.Pod, entry.Container, line)
+	}
+
+	_, err := lw.out.WriteString(line + "\n")
+	return err
+}
+
+// Close flushes buffered output. An empty download is written if no entries
+// were written.
+func (lw *logDownloadWriter) Close() error {
+	if !lw.started {
+		lw.start()
+	}
+
+	if err := lw.out.Flush(); err != nil {
+		return err
+	}
+
+	if lw.gz != nil {
+		return lw.gz.Close()
+	}
+
+	return nil
+}
+
+func logDownloadFileName(request LogStreamRequest) string {
+	switch {
+	case request.Workload != nil:
+		return fmt.Sprintf("%s-%s.log", request.Workload.Kind, request.Workload.Name)
+	case request.Selector != nil:
+		return fmt.Sprintf("%s-logs.log", request.Namespace)
+	default:
+		return fmt.Sprintf("%s-%s.log", request.PodName, request.ContainerName)
+	}
+}
+
+// payloadFromQuery converts query parameters to a log stream payload. Field
+// filters are passed as `field.<name>=<value>`.
+func payloadFromQuery(values url.Values) action.Payload {
+	payload := action.Payload{}
+
+	for key, list := range values {
+		if len(list) == 0 {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(key, "field."):
+			fields, ok := payload["fields"].(map[string]interface{})
+			if !ok {
+				fields = make(map[string]interface{})
+				payload["fields"] = fields
+			}
+			fields[strings.TrimPrefix(key, "field.")] = list[0]
+		case logQuerySliceFields[key]:
+			var items []interface{}
+			for _, v := range list {
+				items = append(items, v)
+			}
+			payload[key] = items
+		case logQueryBoolFields[key]:
+			b, err := strconv.ParseBool(list[0])
+			if err != nil {
+				// leave the field as a string so payload parsing reports it
+				payload[key] = list[0]
+				continue
+			}
+			payload[key] = b
+		default:
+			payload[key] = list[0]
+		}
+	}
+
+	return payload
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDownloadContainers(t *testing.T) {
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	waiting := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init"}},
+				Containers:     []corev1.Container{{Name: "app"}, {Name: "sidecar"}},
+			},
+			Status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{{Name: "init", State: terminated}},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "app", State: running, LastTerminationState: terminated},
+					{Name: "sidecar", State: running},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-2"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app"}, {Name: "sidecar"}},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "app", State: waiting},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		names    []string
+		previous bool
+		expected []podContainer
+	}{
+		{
+			name: "all started containers",
+			expected: []podContainer{
+				{pod: "web-1", container: "init"},
+				{pod: "web-1", container: "app"},
+				{pod: "web-1", container: "sidecar"},
+			},
+		},
+		{
+			name:  "named containers",
+			names: []string{"app"},
+			expected: []podContainer{
+				{pod: "web-1", container: "app"},
+			},
+		},
+		{
+			name:     "previous containers",
+			previous: true,
+			expected: []podContainer{
+				{pod: "web-1", container: "app"},
+			},
+		},
+		{
+			name:  "no matching containers",
+			names: []string{"missing"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, downloadContainers(pods, test.names, test.previous))
+		})
+	}
+}
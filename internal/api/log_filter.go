@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware/octant/pkg/action"
+)
+
+const (
+	maxLogContextLines = 100
+)
+
+// LogFilter filters log lines. A line is kept if it matches any include pattern
+// and no exclude pattern. Without include patterns, every line which is not
+// excluded is kept. If Fields is set, only structured lines whose fields have
+// the given values are kept. ContextLines lines before and after each kept
+// line are also kept.
+type LogFilter struct {
+	Include      []*regexp.Regexp
+	Exclude      []*regexp.Regexp
+	Fields       map[string]string
+	ContextLines int
+}
+
+// IsEmpty returns true if the filter keeps every line.
+func (f LogFilter) IsEmpty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0 && len(f.Fields) == 0
+}
+
+// LogFilterFromPayload creates a log filter from the include, exclude, fields,
+// contextLines and ignoreCase fields of a payload.
+func LogFilterFromPayload(payload action.Payload) (LogFilter, error) {
+	var filter LogFilter
+
+	ignoreCase, err := optionalBool(payload, "ignoreCase", false)
+	if err != nil {
+		return filter, err
+	}
+
+	if filter.Include, err = compileLogPatterns(payload, "include", ignoreCase); err != nil {
+		return filter, err
+	}
+
+	if filter.Exclude, err = compileLogPatterns(payload, "exclude", ignoreCase); err != nil {
+		return filter, err
+	}
+
+	fields, _, err := unstructured.NestedStringMap(payload, "fields")
+	if err != nil {
+		return filter, errors.Wrap(err, "parse fields")
+	}
+	filter.Fields = fields
+
+	if _, ok := payload["contextLines"]; ok {
+		contextLines, err := payload.Float64("contextLines")
+		if err != nil {
+			return filter, errors.Wrap(err, "parse contextLines")
+		}
+		if contextLines < 0 || contextLines > maxLogContextLines {
+			return filter, errors.Errorf("contextLines must be between 0 and %d", maxLogContextLines)
+		}
+		filter.ContextLines = int(contextLines)
+	}
+
+	return filter, nil
+}
+
+func compileLogPatterns(payload action.Payload, key string, ignoreCase bool) ([]*regexp.Regexp, error) {
+	if _, ok := payload[key]; !ok {
+		return nil, nil
+	}
+
+	patterns, err := payload.StringSlice(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*regexp.Regexp
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+
+		if ignoreCase {
+			pattern = "(?i)" + pattern
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s pattern", key)
+		}
+		list = append(list, re)
+	}
+
+	return list, nil
+}
+
+// logMatcher applies a LogFilter to a stream of entries. Context is tracked
+// separately for each pod and container in aggregated streams.
+type logMatcher struct {
+	filter LogFilter
+	states map[string]*logContextState
+}
+
+type logContextState struct {
+	before []LogEntry
+	after  int
+}
+
+func newLogMatcher(filter LogFilter) *logMatcher {
+	return &logMatcher{
+		filter: filter,
+		states: make(map[string]*logContextState),
+	}
+}
+
+// Apply returns the entries to emit for entry: buffered context lines followed
+// by entry if it matches, entry as a context line, or nothing.
+func (m *logMatcher) Apply(entry LogEntry) []LogEntry {
+	if m.filter.IsEmpty() {
+		return []LogEntry{entry}
+	}
+
+	for _, re := range m.filter.Exclude {
+		if re.MatchString(entry.Message) {
+			return nil
+		}
+	}
+
+	state := m.state(entry)
+
+	highlights, matched := m.match(entry)
+	if !matched {
+		if state.after > 0 {
+			state.after--
+			entry.Context = true
+			return []LogEntry{entry}
+		}
+
+		if m.filter.ContextLines > 0 {
+			if len(state.before) == m.filter.ContextLines {
+				state.before = state.before[1:]
+			}
+			state.before = append(state.before, entry)
+		}
+
+		return nil
+	}
+
+	entry.Highlights = highlights
+
+	var out []LogEntry
+	for _, before := range state.before {
+		before.Context = true
+		out = append(out, before)
+	}
+	state.before = nil
+	state.after = m.filter.ContextLines
+
+	return append(out, entry)
+}
+
+func (m *logMatcher) match(entry LogEntry) ([][]int, bool) {
+	for k, v := range m.filter.Fields {
+		if fieldValue, ok := entry.Fields[k]; !ok || fieldValue != v {
+			return nil, false
+		}
+	}
+
+	if len(m.filter.Include) == 0 {
+		return nil, true
+	}
+
+	var highlights [][]int
+	for _, re := range m.filter.Include {
+		highlights = append(highlights, re.FindAllStringIndex(entry.Message, -1)...)
+	}
+
+	return highlights, len(highlights) > 0
+}
+
+func (m *logMatcher) state(entry LogEntry) *logContextState {
+	key := fmt.Sprintf("%s/%s", entry.Pod, entry.Container)
+
+	state, ok := m.states[key]
+	if !ok {
+		state = &logContextState{}
+		m.states[key] = state
+	}
+
+	return state
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogMatcher_Apply(t *testing.T) {
+	matcher := newLogMatcher(LogFilter{
+		Include:      []*regexp.Regexp{regexp.MustCompile("error")},
+		Exclude:      []*regexp.Regexp{regexp.MustCompile("ignored")},
+		ContextLines: 1,
+	})
+
+	entry := func(container, message string) LogEntry {
+		return LogEntry{Pod: "web-1", Container: container, Message: message}
+	}
+
+	assert.Empty(t, matcher.Apply(entry("app", "starting")))
+	assert.Empty(t, matcher.Apply(entry("sidecar", "sidecar starting")))
+	assert.Empty(t, matcher.Apply(entry("app", "ignored error")))
+
+	got := matcher.Apply(entry("app", "an error"))
+	expected := []LogEntry{
+		{Pod: "web-1", Container: "app", Message: "starting", Context: true},
+		{Pod: "web-1", Container: "app", Message: "an error", Highlights: [][]int{{3, 8}}},
+	}
+	assert.Equal(t, expected, got)
+
+	// context after a match is tracked for each container
+	assert.Empty(t, matcher.Apply(entry("sidecar", "sidecar ready")))
+	assert.Equal(t, []LogEntry{
+		{Pod: "web-1", Container: "app", Message: "recovered", Context: true},
+	}, matcher.Apply(entry("app", "recovered")))
+	assert.Empty(t, matcher.Apply(entry("app", "serving")))
+}
+
+func TestLogMatcher_Apply_fields(t *testing.T) {
+	matcher := newLogMatcher(LogFilter{
+		Fields: map[string]string{"level": "error"},
+	})
+
+	assert.Empty(t, matcher.Apply(LogEntry{Message: "a", Fields: map[string]string{"level": "info"}}))
+	assert.Empty(t, matcher.Apply(LogEntry{Message: "b"}))
+	assert.Len(t, matcher.Apply(LogEntry{Message: "c", Fields: map[string]string{"level": "error"}}), 1)
+}
+
+func TestLogMatcher_Apply_empty(t *testing.T) {
+	matcher := newLogMatcher(LogFilter{})
+	entry := LogEntry{Message: "anything"}
+	assert.Equal(t, []LogEntry{entry}, matcher.Apply(entry))
+}
@@ -0,0 +1,304 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	kcache "k8s.io/client-go/tools/cache"
+
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/modules/overview/container"
+	"github.com/vmware/octant/pkg/store"
+)
+
+// logColors are assigned to pod/container pairs in the order they appear in a session.
+var logColors = []string{
+	"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b",
+	"#e377c2", "#7f7f7f", "#bcbd22", "#17becf", "#393b79", "#637939",
+}
+
+// podLogSession streams the logs of all containers in pods matching a selector.
+// Pods are tracked with an object store watch, so pods which are created after
+// the session starts are included and pods which are deleted are dropped.
+type podLogSession struct {
+	namespace   string
+	selector    labels.Selector
+	containers  map[string]bool
+	options     container.LogOptions
+	kubeClient  kubernetes.Interface
+	objectStore store.Store
+
+	mu       sync.Mutex
+	closed   bool
+	streams  map[string]context.CancelFunc
+	streamed map[string]string
+	ended    map[string]time.Time
+	colors   map[string]string
+	wg       sync.WaitGroup
+}
+
+func newPodLogSession(namespace string, selector labels.Selector, containerNames []string, options container.LogOptions, kubeClient kubernetes.Interface, objectStore store.Store) *podLogSession {
+	containers := make(map[string]bool)
+	for _, name := range containerNames {
+		containers[name] = true
+	}
+
+	return &podLogSession{
+		namespace:   namespace,
+		selector:    selector,
+		containers:  containers,
+		options:     options,
+		kubeClient:  kubeClient,
+		objectStore: objectStore,
+		streams:     make(map[string]context.CancelFunc),
+		streamed:    make(map[string]string),
+		ended:       make(map[string]time.Time),
+		colors:      make(map[string]string),
+	}
+}
+
+// run streams logs to out until ctx is cancelled. Without follow, it returns
+// once the logs of the pods which currently exist have been read. No logs are
+// sent to out after run returns.
+func (s *podLogSession) run(ctx context.Context, out chan<- LogEntry) error {
+	defer s.close()
+
+	key := store.Key{
+		Namespace:  s.namespace,
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Selector:   selectorToLabelSet(s.selector),
+	}
+
+	list, _, err := s.objectStore.List(ctx, key)
+	if err != nil {
+		return errors.Wrap(err, "list pods")
+	}
+
+	for i := range list.Items {
+		s.podUpdated(ctx, &list.Items[i], out)
+	}
+
+	if !s.options.Follow {
+		return nil
+	}
+
+	// event handlers can't be removed from informers, so the handler
+	// ignores events once ctx is cancelled and the session is closed
+	handler := kcache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			s.podUpdated(ctx, obj, out)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			s.podUpdated(ctx, obj, out)
+		},
+		DeleteFunc: func(obj interface{}) {
+			s.podDeleted(obj)
+		},
+	}
+
+	if err := s.objectStore.Watch(ctx, key, handler); err != nil {
+		return errors.Wrap(err, "watch pods")
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+// close stops new streams from starting and waits for running streams to end.
+func (s *podLogSession) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *podLogSession) podUpdated(ctx context.Context, obj interface{}, out chan<- LogEntry) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	pod, ok := s.convertPod(obj)
+	if !ok || pod.DeletionTimestamp != nil {
+		return
+	}
+
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if len(s.containers) > 0 && !s.containers[status.Name] {
+			continue
+		}
+
+		if status.State.Waiting != nil {
+			continue
+		}
+
+		s.startStream(ctx, pod.Name, status.Name, status.ContainerID, out)
+	}
+}
+
+func (s *podLogSession) podDeleted(obj interface{}) {
+	if tombstone, ok := obj.(kcache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	pod, ok := s.convertPod(obj)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prefix := pod.Name + "/"
+	for k, cancel := range s.streams {
+		if strings.HasPrefix(k, prefix) {
+			cancel()
+			delete(s.streams, k)
+		}
+	}
+}
+
+// convertPod converts a pod from the object store. The store only narrows
+// by equality requirements, so the selector is applied here.
+func (s *podLogSession) convertPod(obj interface{}) (*corev1.Pod, bool) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, false
+	}
+
+	if !s.selector.Matches(labels.Set(u.GetLabels())) {
+		return nil, false
+	}
+
+	pod := &corev1.Pod{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, pod); err != nil {
+		return nil, false
+	}
+
+	return pod, true
+}
+
+// startStream streams the logs of a container unless the session is closed or
+// they are already streamed. Containers are identified by their ID, so a
+// container which has terminated isn't streamed again, but one which has
+// restarted is.
+func (s *podLogSession) startStream(ctx context.Context, podName, containerName, containerID string, out chan<- LogEntry) {
+	k := fmt.Sprintf("%s/%s", podName, containerName)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	if _, ok := s.streams[k]; ok {
+		return
+	}
+
+	if id, ok := s.streamed[k]; ok && id == containerID {
+		return
+	}
+	s.streamed[k] = containerID
+
+	options := s.options
+	if endedAt, ok := s.ended[k]; ok {
+		// the container restarted; resume where the previous stream stopped
+		options.TailLines = nil
+		options.SinceTime = &endedAt
+	}
+
+	color, ok := s.colors[k]
+	if !ok {
+		color = logColors[len(s.colors)%len(logColors)]
+		s.colors[k] = color
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	s.streams[k] = cancel
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.streams, k)
+			s.ended[k] = time.Now()
+			s.mu.Unlock()
+			cancel()
+		}()
+
+		lines := make(chan string)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- container.LogsWithOptions(streamCtx, s.kubeClient, s.namespace, podName, containerName, options, lines)
+		}()
+
+		for line := range lines {
+			entry := parseLogLine(line, options.Timestamps)
+			entry.Pod = podName
+			entry.Container = containerName
+			entry.Color = color
+
+			select {
+			case <-streamCtx.Done():
+			case out <- entry:
+			}
+		}
+
+		if err := <-errCh; err != nil {
+			log.From(ctx).WithErr(err).Errorf("stream logs for %s", k)
+		}
+	}()
+}
+
+// selectorFromWorkload returns the label selector for a workload's pods.
+func selectorFromWorkload(ctx context.Context, objectStore store.Store, key store.Key) (labels.Selector, error) {
+	object, found, err := objectStore.Get(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get %s", key)
+	}
+
+	if !found {
+		return nil, errors.Errorf("%s was not found", key)
+	}
+
+	m, found, err := unstructured.NestedMap(object.Object, "spec", "selector")
+	if err != nil || !found {
+		return nil, errors.Errorf("%s does not have a selector", key)
+	}
+
+	var labelSelector metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &labelSelector); err != nil {
+		return nil, errors.Wrap(err, "convert selector")
+	}
+
+	return metav1.LabelSelectorAsSelector(&labelSelector)
+}
+
+// selectorToLabelSet narrows an object store list when the selector only
+// has equality requirements. Other selectors are applied by the caller.
+func selectorToLabelSet(selector labels.Selector) *labels.Set {
+	set, err := labels.ConvertSelectorToLabelsMap(selector.String())
+	if err != nil {
+		return nil
+	}
+
+	return &set
+}
@@ -0,0 +1,465 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/vmware/octant/internal/cluster"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/modules/overview/container"
+	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/store"
+)
+
+const (
+	// RequestStartLogStream is the request type for starting a log stream.
+	RequestStartLogStream = "startLogStream"
+	// RequestStopLogStream is the request type for stopping the current log stream.
+	RequestStopLogStream = "stopLogStream"
+
+	// EventTypeLogLines is the event type for a batch of log lines.
+	EventTypeLogLines = "logLines"
+	// EventTypeLogStreamEnded is the event type sent when a log stream ends.
+	EventTypeLogStreamEnded = "logStreamEnded"
+
+	defaultLogFlushInterval = 250 * time.Millisecond
+	defaultLogBatchSize     = 500
+)
+
+// LogStreamManagerConfig is configuration for LogStreamManager.
+type LogStreamManagerConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// LogStreamManagerOption is an option for configuring LogStreamManager.
+type LogStreamManagerOption func(m *LogStreamManager)
+
+// WithLogFlushInterval configures how often log lines are sent to the client.
+func WithLogFlushInterval(interval time.Duration) LogStreamManagerOption {
+	return func(m *LogStreamManager) {
+		m.flushInterval = interval
+	}
+}
+
+// LogStreamRequest is a request to stream logs. It streams a single container's
+// logs, or the logs for all pods matched by Selector or by Workload's selector.
+type LogStreamRequest struct {
+	// ID is chosen by the client and is included in every event for the stream.
+	ID            string
+	Namespace     string
+	PodName       string
+	ContainerName string
+	Selector      labels.Selector
+	Workload      *store.Key
+	// Containers limits the containers streamed for a selector. All containers
+	// are streamed if it is empty.
+	Containers []string
+	Options    container.LogOptions
+	Filter     LogFilter
+}
+
+// IsAggregated returns true if the request streams logs for multiple pods.
+func (r LogStreamRequest) IsAggregated() bool {
+	return r.Selector != nil || r.Workload != nil
+}
+
+// LogEntry is a log line. Pod, Container and Color are set for aggregated streams.
+// Highlights are the [start, end) offsets of filter matches in Message, and
+// Context is true for lines which are only shown around a match. Format,
+// Fields and Level are set for JSON and logfmt lines.
+type LogEntry struct {
+	Timestamp  *time.Time        `json:"timestamp,omitempty"`
+	Message    string            `json:"message"`
+	Pod        string            `json:"pod,omitempty"`
+	Container  string            `json:"container,omitempty"`
+	Color      string            `json:"color,omitempty"`
+	Highlights [][]int           `json:"highlights,omitempty"`
+	Context    bool              `json:"context,omitempty"`
+	Format     string            `json:"format,omitempty"`
+	Fields     map[string]string `json:"fields,omitempty"`
+	Level      string            `json:"level,omitempty"`
+}
+
+// LogStreamManager streams container logs to a client. A client has at most one
+// active stream; starting a stream or stopping it cancels the previous one.
+type LogStreamManager struct {
+	config        LogStreamManagerConfig
+	requests      chan *LogStreamRequest
+	flushInterval time.Duration
+	batchSize     int
+}
+
+var _ StateManager = (*LogStreamManager)(nil)
+
+// NewLogStreamManager creates an instance of LogStreamManager.
+func NewLogStreamManager(config LogStreamManagerConfig, options ...LogStreamManagerOption) *LogStreamManager {
+	m := &LogStreamManager{
+		config:        config,
+		requests:      make(chan *LogStreamRequest, 1),
+		flushInterval: defaultLogFlushInterval,
+		batchSize:     defaultLogBatchSize,
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// Handlers returns a slice of handlers.
+func (m *LogStreamManager) Handlers() []octant.ClientRequestHandler {
+	return []octant.ClientRequestHandler{
+		{
+			RequestType: RequestStartLogStream,
+			Handler:     m.StartStream,
+		},
+		{
+			RequestType: RequestStopLogStream,
+			Handler:     m.StopStream,
+		},
+	}
+}
+
+// StartStream starts streaming logs, replacing the current stream.
+func (m *LogStreamManager) StartStream(state octant.State, payload action.Payload) error {
+	request, err := LogStreamRequestFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	m.queue(&request)
+	return nil
+}
+
+// StopStream stops the current stream.
+func (m *LogStreamManager) StopStream(state octant.State, payload action.Payload) error {
+	m.queue(nil)
+	return nil
+}
+
+func (m *LogStreamManager) queue(request *LogStreamRequest) {
+	// replace a queued request which has not started yet
+	select {
+	case <-m.requests:
+	default:
+	}
+
+	m.requests <- request
+}
+
+// Start starts the manager. It runs log streams until the context is cancelled.
+func (m *LogStreamManager) Start(ctx context.Context, state octant.State, s OctantClient) {
+	var cancel context.CancelFunc = func() {}
+
+	for {
+		select {
+		case <-ctx.Done():
+			cancel()
+			return
+		case request := <-m.requests:
+			cancel()
+			cancel = func() {}
+
+			if request == nil {
+				continue
+			}
+
+			var streamCtx context.Context
+			streamCtx, cancel = context.WithCancel(ctx)
+			go m.stream(streamCtx, *request, s)
+		}
+	}
+}
+
+func (m *LogStreamManager) stream(ctx context.Context, request LogStreamRequest, s OctantClient) {
+	logger := log.From(ctx).With(
+		"namespace", request.Namespace,
+		"pod", request.PodName,
+		"container", request.ContainerName)
+
+	kubeClient, err := m.config.ClusterClient().KubernetesClient()
+	if err != nil {
+		s.Send(CreateLogStreamEndedEvent(request.ID, err))
+		return
+	}
+
+	lines := make(chan LogEntry)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(lines)
+		errCh <- m.readLogs(ctx, request, kubeClient, lines)
+	}()
+
+	ticker := time.NewTicker(m.flushInterval)
+	defer ticker.Stop()
+
+	matcher := newLogMatcher(request.Filter)
+
+	var entries []LogEntry
+	flush := func() {
+		if len(entries) == 0 || ctx.Err() != nil {
+			return
+		}
+		s.Send(CreateLogLinesEvent(request.ID, entries))
+		entries = nil
+	}
+
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				flush()
+
+				err := <-errCh
+				if err != nil {
+					logger.WithErr(err).Errorf("stream container logs")
+				}
+				if ctx.Err() == nil {
+					s.Send(CreateLogStreamEndedEvent(request.ID, err))
+				}
+				return
+			}
+
+			entries = append(entries, matcher.Apply(line)...)
+			if len(entries) >= m.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (m *LogStreamManager) readLogs(ctx context.Context, request LogStreamRequest, kubeClient kubernetes.Interface, out chan<- LogEntry) error {
+	if !request.IsAggregated() {
+		lines := make(chan string)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- container.LogsWithOptions(ctx, kubeClient, request.Namespace, request.PodName, request.ContainerName, request.Options, lines)
+		}()
+
+		for line := range lines {
+			select {
+			case <-ctx.Done():
+			case out <- parseLogLine(line, request.Options.Timestamps):
+			}
+		}
+
+		return <-errCh
+	}
+
+	objectStore := m.config.ObjectStore()
+
+	selector := request.Selector
+	if request.Workload != nil {
+		var err error
+		selector, err = selectorFromWorkload(ctx, objectStore, *request.Workload)
+		if err != nil {
+			return err
+		}
+	}
+
+	session := newPodLogSession(request.Namespace, selector, request.Containers, request.Options, kubeClient, objectStore)
+	return session.run(ctx, out)
+}
+
+// parseLogLine splits a timestamp from a log line and parses structured
+// messages. Lines without a timestamp are kept as is.
+func parseLogLine(line string, hasTimestamp bool) LogEntry {
+	entry := LogEntry{Message: line}
+
+	if hasTimestamp {
+		parts := strings.SplitN(line, " ", 2)
+		if timestamp, err := time.Parse(time.RFC3339Nano, parts[0]); err == nil {
+			entry.Timestamp = &timestamp
+			entry.Message = ""
+			if len(parts) == 2 {
+				entry.Message = parts[1]
+			}
+		}
+	}
+
+	if structured, ok := container.ParseStructuredLog(entry.Message); ok {
+		entry.Format = string(structured.Format)
+		entry.Fields = structured.Fields
+		entry.Level = structured.Level
+	}
+
+	return entry
+}
+
+// LogStreamRequestFromPayload creates a log stream request from a payload.
+// Timestamps are enabled unless the payload disables them.
+func LogStreamRequestFromPayload(payload action.Payload) (LogStreamRequest, error) {
+	var request LogStreamRequest
+	var err error
+
+	if request.ID, err = payload.OptionalString("id"); err != nil {
+		return LogStreamRequest{}, err
+	}
+	if request.Namespace, err = payload.String("namespace"); err != nil {
+		return LogStreamRequest{}, err
+	}
+
+	switch {
+	case payload["selector"] != nil:
+		raw, err := payload.String("selector")
+		if err != nil {
+			return LogStreamRequest{}, err
+		}
+		if request.Selector, err = labels.Parse(raw); err != nil {
+			return LogStreamRequest{}, errors.Wrap(err, "parse selector")
+		}
+	case payload["kind"] != nil:
+		key, err := store.KeyFromPayload(payload)
+		if err != nil {
+			return LogStreamRequest{}, err
+		}
+		request.Workload = &key
+	default:
+		if request.PodName, err = payload.String("podName"); err != nil {
+			return LogStreamRequest{}, err
+		}
+		if request.ContainerName, err = payload.String("containerName"); err != nil {
+			return LogStreamRequest{}, err
+		}
+	}
+
+	if _, ok := payload["containers"]; ok {
+		if request.Containers, err = payload.StringSlice("containers"); err != nil {
+			return LogStreamRequest{}, err
+		}
+	}
+
+	options, err := logOptionsFromPayload(payload)
+	if err != nil {
+		return LogStreamRequest{}, err
+	}
+	request.Options = options
+
+	filter, err := LogFilterFromPayload(payload)
+	if err != nil {
+		return LogStreamRequest{}, err
+	}
+	request.Filter = filter
+
+	return request, nil
+}
+
+func logOptionsFromPayload(payload action.Payload) (container.LogOptions, error) {
+	options := container.LogOptions{Timestamps: true}
+
+	var err error
+	if options.Follow, err = optionalBool(payload, "follow", false); err != nil {
+		return options, err
+	}
+	if options.Timestamps, err = optionalBool(payload, "timestamps", true); err != nil {
+		return options, err
+	}
+	if options.Previous, err = optionalBool(payload, "previous", false); err != nil {
+		return options, err
+	}
+
+	if _, ok := payload["tailLines"]; ok {
+		tailLines, err := payload.Float64("tailLines")
+		if err != nil {
+			return options, errors.Wrap(err, "parse tailLines")
+		}
+		if tailLines < 0 {
+			return options, errors.Errorf("tailLines must not be negative")
+		}
+		n := int64(tailLines)
+		options.TailLines = &n
+	}
+
+	sinceTime, err := payload.OptionalString("sinceTime")
+	if err != nil {
+		return options, err
+	}
+	if sinceTime != "" {
+		t, err := time.Parse(time.RFC3339, sinceTime)
+		if err != nil {
+			return options, errors.Wrap(err, "parse sinceTime")
+		}
+		options.SinceTime = &t
+	}
+
+	sinceDuration, err := payload.OptionalString("sinceDuration")
+	if err != nil {
+		return options, err
+	}
+	if sinceDuration != "" {
+		d, err := time.ParseDuration(sinceDuration)
+		if err != nil {
+			return options, errors.Wrap(err, "parse sinceDuration")
+		}
+		options.SinceDuration = d
+	}
+
+	return options, nil
+}
+
+func optionalBool(payload action.Payload, key string, defaultValue bool) (bool, error) {
+	b, found, err := unstructured.NestedBool(payload, key)
+	if err != nil {
+		return false, errors.Wrapf(err, "parse %s", key)
+	}
+
+	if !found {
+		return defaultValue, nil
+	}
+
+	return b, nil
+}
+
+// CreateLogLinesEvent creates a log lines event. It includes the sorted
+// field names of structured entries so clients can offer them as columns.
+func CreateLogLinesEvent(id string, entries []LogEntry) octant.Event {
+	keys := make(map[string]bool)
+	for _, entry := range entries {
+		for k := range entry.Fields {
+			keys[k] = true
+		}
+	}
+
+	fields := make([]string, 0, len(keys))
+	for k := range keys {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+
+	return CreateEvent(EventTypeLogLines, action.Payload{
+		"id":      id,
+		"entries": entries,
+		"fields":  fields,
+	})
+}
+
+// CreateLogStreamEndedEvent creates a log stream ended event.
+func CreateLogStreamEndedEvent(id string, err error) octant.Event {
+	payload := action.Payload{
+		"id": id,
+	}
+
+	if err != nil {
+		payload["error"] = err.Error()
+	}
+
+	return CreateEvent(EventTypeLogStreamEnded, payload)
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/pkg/action"
+)
+
+func TestParseLogLine(t *testing.T) {
+	timestamp := time.Date(2019, 10, 1, 12, 30, 0, 123456789, time.UTC)
+
+	tests := []struct {
+		name         string
+		line         string
+		hasTimestamp bool
+		expected     LogEntry
+	}{
+		{
+			name:         "timestamp",
+			line:         "2019-10-01T12:30:00.123456789Z started server",
+			hasTimestamp: true,
+			expected:     LogEntry{Timestamp: &timestamp, Message: "started server"},
+		},
+		{
+			name:         "timestamp without a message",
+			line:         "2019-10-01T12:30:00.123456789Z",
+			hasTimestamp: true,
+			expected:     LogEntry{Timestamp: &timestamp},
+		},
+		{
+			name:         "line without a timestamp is kept",
+			line:         "not a timestamp",
+			hasTimestamp: true,
+			expected:     LogEntry{Message: "not a timestamp"},
+		},
+		{
+			name:     "timestamps disabled",
+			line:     "2019-10-01T12:30:00.123456789Z started server",
+			expected: LogEntry{Message: "2019-10-01T12:30:00.123456789Z started server"},
+		},
+		{
+			name:         "structured message",
+			line:         `2019-10-01T12:30:00.123456789Z {"level":"error","msg":"failed"}`,
+			hasTimestamp: true,
+			expected: LogEntry{
+				Timestamp: &timestamp,
+				Message:   `{"level":"error","msg":"failed"}`,
+				Format:    "json",
+				Fields:    map[string]string{"level": "error", "msg": "failed"},
+				Level:     "error",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parseLogLine(test.line, test.hasTimestamp)
+			if test.expected.Timestamp != nil {
+				require.NotNil(t, got.Timestamp)
+				assert.True(t, test.expected.Timestamp.Equal(*got.Timestamp))
+				got.Timestamp = test.expected.Timestamp
+			}
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestLogStreamRequestFromPayload(t *testing.T) {
+	t.Run("container", func(t *testing.T) {
+		request, err := LogStreamRequestFromPayload(action.Payload{
+			"id":            "1",
+			"namespace":     "default",
+			"podName":       "web",
+			"containerName": "app",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "1", request.ID)
+		assert.Equal(t, "web", request.PodName)
+		assert.Equal(t, "app", request.ContainerName)
+		assert.False(t, request.IsAggregated())
+		assert.True(t, request.Options.Timestamps)
+		assert.False(t, request.Options.Follow)
+		assert.Nil(t, request.Options.TailLines)
+	})
+
+	t.Run("selector", func(t *testing.T) {
+		request, err := LogStreamRequestFromPayload(action.Payload{
+			"namespace":  "default",
+			"selector":   "app=web,tier!=db",
+			"containers": []interface{}{"app"},
+			"follow":     true,
+			"timestamps": false,
+			"tailLines":  float64(100),
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, request.Selector)
+		assert.Equal(t, "app=web,tier!=db", request.Selector.String())
+		assert.True(t, request.IsAggregated())
+		assert.Equal(t, []string{"app"}, request.Containers)
+		assert.True(t, request.Options.Follow)
+		assert.False(t, request.Options.Timestamps)
+		require.NotNil(t, request.Options.TailLines)
+		assert.Equal(t, int64(100), *request.Options.TailLines)
+	})
+
+	t.Run("workload", func(t *testing.T) {
+		request, err := LogStreamRequestFromPayload(action.Payload{
+			"namespace":     "default",
+			"apiVersion":    "apps/v1",
+			"kind":          "Deployment",
+			"name":          "web",
+			"sinceDuration": "5m",
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, request.Workload)
+		assert.Equal(t, "Deployment", request.Workload.Kind)
+		assert.Equal(t, "web", request.Workload.Name)
+		assert.True(t, request.IsAggregated())
+		assert.Equal(t, 5*time.Minute, request.Options.SinceDuration)
+	})
+
+	invalid := []struct {
+		name    string
+		payload action.Payload
+	}{
+		{name: "missing namespace", payload: action.Payload{"podName": "web", "containerName": "app"}},
+		{name: "missing container", payload: action.Payload{"namespace": "default", "podName": "web"}},
+		{name: "invalid selector", payload: action.Payload{"namespace": "default", "selector": "app in ("}},
+		{name: "negative tailLines", payload: action.Payload{"namespace": "default", "selector": "app=web", "tailLines": float64(-1)}},
+		{name: "invalid sinceTime", payload: action.Payload{"namespace": "default", "selector": "app=web", "sinceTime": "yesterday"}},
+		{name: "invalid sinceDuration", payload: action.Payload{"namespace": "default", "selector": "app=web", "sinceDuration": "a while"}},
+		{name: "invalid follow", payload: action.Payload{"namespace": "default", "selector": "app=web", "follow": "yes"}},
+	}
+
+	for _, test := range invalid {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := LogStreamRequestFromPayload(test.payload)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLogStreamManager_queue(t *testing.T) {
+	m := NewLogStreamManager(nil)
+
+	first := &LogStreamRequest{ID: "1"}
+	second := &LogStreamRequest{ID: "2"}
+
+	// a queued request which hasn't started is replaced
+	m.queue(first)
+	m.queue(second)
+	assert.Equal(t, second, <-m.requests)
+
+	m.queue(nil)
+	assert.Nil(t, <-m.requests)
+}
+
+func TestCreateLogLinesEvent(t *testing.T) {
+	entries := []LogEntry{
+		{Message: "plain"},
+		{Message: "one", Fields: map[string]string{"msg": "one", "level": "info"}},
+		{Message: "two", Fields: map[string]string{"msg": "two", "trace_id": "abc"}},
+	}
+
+	event := CreateLogLinesEvent("1", entries)
+
+	assert.Equal(t, action.Payload{
+		"id":      "1",
+		"entries": entries,
+		"fields":  []string{"level", "msg", "trace_id"},
+	}, event.Data)
+}
+
+func TestCreateLogStreamEndedEvent(t *testing.T) {
+	event := CreateLogStreamEndedEvent("1", nil)
+	assert.Equal(t, action.Payload{"id": "1"}, event.Data)
+
+	event = CreateLogStreamEndedEvent("1", errors.New("container not found"))
+	assert.Equal(t, action.Payload{"id": "1", "error": "container not found"}, event.Data)
+}
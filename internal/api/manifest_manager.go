@@ -0,0 +1,262 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubenext/kubeon/internal/manifest"
+	"github.com/vmware/octant/internal/cluster"
+	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/pkg/action"
+)
+
+const (
+	// RequestPreviewManifest is the request type for previewing manifests.
+	RequestPreviewManifest = "previewManifest"
+	// RequestApplyManifest is the request type for applying previewed manifests.
+	RequestApplyManifest = "applyManifest"
+
+	// EventTypeManifestPreview is the event type for the dry run results of manifests.
+	EventTypeManifestPreview = "manifestPreview"
+	// EventTypeManifestApplied is the event type sent after manifests are applied.
+	EventTypeManifestApplied = "manifestApplied"
+)
+
+// ManifestManagerConfig is configuration for ManifestManager.
+type ManifestManagerConfig interface {
+	ClusterClient() cluster.ClientInterface
+}
+
+// ManifestManager applies manifests of one or more objects. Manifests are
+// previewed with a server-side dry run apply first, and only manifests
+// which were previewed can be applied. Both requests can be made by forms,
+// so outcomes are sent as alerts as well as events.
+type ManifestManager struct {
+	config ManifestManagerConfig
+	events chan octant.Event
+
+	mu sync.Mutex
+	// previewed are the last manifests previewed.
+	previewed *manifestPreview
+}
+
+type manifestPreview struct {
+	manifests []string
+	namespace string
+}
+
+var _ StateManager = (*ManifestManager)(nil)
+
+// NewManifestManager creates an instance of ManifestManager.
+func NewManifestManager(config ManifestManagerConfig) *ManifestManager {
+	return &ManifestManager{
+		config: config,
+		events: make(chan octant.Event, 10),
+	}
+}
+
+// Handlers returns a slice of handlers.
+func (m *ManifestManager) Handlers() []octant.ClientRequestHandler {
+	return []octant.ClientRequestHandler{
+		{
+			RequestType: RequestPreviewManifest,
+			Handler:     m.Preview,
+		},
+		{
+			RequestType: RequestApplyManifest,
+			Handler:     m.Apply,
+		},
+	}
+}
+
+// Preview runs a server-side dry run apply of manifests and sends what would
+// happen to each object.
+func (m *ManifestManager) Preview(state octant.State, payload action.Payload) error {
+	manifests, namespace, err := manifestsFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	results, err := m.apply(manifests, namespace, true)
+	state.SendAlert(manifestAlert(true, results, err))
+	if err != nil {
+		m.events <- CreateManifestEvent(EventTypeManifestPreview, nil, err)
+		return nil
+	}
+
+	m.mu.Lock()
+	m.previewed = &manifestPreview{manifests: manifests, namespace: namespace}
+	m.mu.Unlock()
+
+	m.events <- CreateManifestEvent(EventTypeManifestPreview, results, nil)
+	return nil
+}
+
+// Apply applies the last manifests previewed. The apply must be confirmed.
+// If the payload has manifests, they must be the ones which were previewed.
+func (m *ManifestManager) Apply(state octant.State, payload action.Payload) error {
+	manifests, namespace, err := manifestsFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	confirmed, err := payload.OptionalStringSlice("confirm")
+	if err != nil {
+		return errors.Wrap(err, "extract confirm from payload")
+	}
+
+	if len(confirmed) == 0 {
+		state.SendAlert(manifestAlert(false, nil, errors.New("it was not confirmed")))
+		return nil
+	}
+
+	m.mu.Lock()
+	preview := m.previewed
+	if preview != nil && len(manifests) > 0 &&
+		hashManifests(manifests, namespace) != hashManifests(preview.manifests, preview.namespace) {
+		preview = nil
+	}
+	if preview != nil {
+		m.previewed = nil
+	}
+	m.mu.Unlock()
+
+	if preview == nil {
+		err := errors.New("manifests were not previewed; preview them before applying")
+		state.SendAlert(manifestAlert(false, nil, err))
+		m.events <- CreateManifestEvent(EventTypeManifestApplied, nil, err)
+		return nil
+	}
+
+	results, err := m.apply(preview.manifests, preview.namespace, false)
+	state.SendAlert(manifestAlert(false, results, err))
+	m.events <- CreateManifestEvent(EventTypeManifestApplied, results, err)
+	return nil
+}
+
+// manifestAlert summarizes the results of a preview or apply. The diff of
+// each object is in the manifest event.
+func manifestAlert(dryRun bool, results []manifest.Result, err error) action.Alert {
+	verb, done := "apply", "Applied"
+	if dryRun {
+		verb, done = "preview", "Previewed"
+	}
+
+	if err != nil {
+		message := fmt.Sprintf("Unable to %s manifests: %s", verb, err)
+		return action.CreateAlert(action.AlertTypeWarning, message, action.DefaultAlertExpiration)
+	}
+
+	counts := make(map[string]int)
+	var failures []string
+	for _, result := range results {
+		if result.Err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", result.Target, result.Err))
+			continue
+		}
+		counts[result.Action]++
+	}
+
+	message := fmt.Sprintf("%s %d objects: %d created, %d updated, %d unchanged",
+		done, len(results), counts[manifest.ActionCreate], counts[manifest.ActionUpdate], counts[manifest.ActionUnchanged])
+
+	alertType := action.AlertTypeInfo
+	if len(failures) > 0 {
+		alertType = action.AlertTypeWarning
+		message = fmt.Sprintf("%s, %d failed: %s", message, len(failures), strings.Join(failures, "; "))
+	}
+
+	return action.CreateAlert(alertType, message, action.DefaultAlertExpiration)
+}
+
+func (m *ManifestManager) apply(manifests []string, namespace string, dryRun bool) ([]manifest.Result, error) {
+	objects, err := manifest.Parse(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest.Apply(m.config.ClusterClient(), objects, namespace, dryRun), nil
+}
+
+// Start starts the manager. Results are sent until the context is cancelled.
+func (m *ManifestManager) Start(ctx context.Context, state octant.State, s OctantClient) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-m.events:
+			s.Send(event)
+		}
+	}
+}
+
+// manifestsFromPayload returns the pasted manifest and the contents of
+// uploaded files, and the namespace for objects without one.
+func manifestsFromPayload(payload action.Payload) ([]string, string, error) {
+	pasted, err := payload.OptionalString("manifest")
+	if err != nil {
+		return nil, "", errors.Wrap(err, "extract manifest from payload")
+	}
+
+	files, err := payload.OptionalStringSlice("files")
+	if err != nil {
+		return nil, "", errors.Wrap(err, "extract files from payload")
+	}
+
+	namespace, err := payload.OptionalString("namespace")
+	if err != nil {
+		return nil, "", errors.Wrap(err, "extract namespace from payload")
+	}
+
+	var manifests []string
+	if strings.TrimSpace(pasted) != "" {
+		manifests = append(manifests, pasted)
+	}
+	manifests = append(manifests, files...)
+
+	return manifests, strings.TrimSpace(namespace), nil
+}
+
+func hashManifests(manifests []string, namespace string) string {
+	return hashDocument(namespace + "\x00" + strings.Join(manifests, "\x00"))
+}
+
+// CreateManifestEvent creates a manifest event with the result for each
+// object. If err is set, the manifests couldn't be parsed and the event
+// includes it instead.
+func CreateManifestEvent(eventType string, results []manifest.Result, err error) octant.Event {
+	payload := action.Payload{}
+
+	if err != nil {
+		payload["error"] = err.Error()
+		return CreateEvent(eventType, payload)
+	}
+
+	var objects []map[string]interface{}
+	for _, result := range results {
+		object := map[string]interface{}{
+			"apiVersion": result.Target.APIVersion,
+			"kind":       result.Target.Kind,
+			"namespace":  result.Target.Namespace,
+			"name":       result.Target.Name,
+			"action":     result.Action,
+			"diff":       result.Diff,
+		}
+		if result.Err != nil {
+			object["error"] = result.Err.Error()
+		}
+		objects = append(objects, object)
+	}
+
+	payload["results"] = objects
+	return CreateEvent(eventType, payload)
+}
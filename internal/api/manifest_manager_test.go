@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/pkg/action"
+)
+
+// alertState records the alerts sent to it.
+type alertState struct {
+	octant.State
+	alerts []action.Alert
+}
+
+func (s *alertState) SendAlert(alert action.Alert) {
+	s.alerts = append(s.alerts, alert)
+}
+
+func TestManifestManager_Apply(t *testing.T) {
+	const manifest = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n"
+
+	tests := []struct {
+		name            string
+		payload         action.Payload
+		expectedMessage string
+	}{
+		{
+			name:            "not confirmed",
+			payload:         action.Payload{},
+			expectedMessage: "Unable to apply manifests: it was not confirmed",
+		},
+		{
+			name:            "resent manifests without confirming",
+			payload:         action.Payload{"manifest": manifest, "namespace": "default"},
+			expectedMessage: "Unable to apply manifests: it was not confirmed",
+		},
+		{
+			name: "resent manifests which weren't previewed",
+			payload: action.Payload{
+				"manifest":  "apiVersion: v1\nkind: Secret\nmetadata:\n  name: other\n",
+				"namespace": "default",
+				"confirm":   []interface{}{"true"},
+			},
+			expectedMessage: "Unable to apply manifests: manifests were not previewed; preview them before applying",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewManifestManager(nil)
+			m.previewed = &manifestPreview{manifests: []string{manifest}, namespace: "default"}
+
+			state := &alertState{}
+			require.NoError(t, m.Apply(state, test.payload))
+
+			require.Len(t, state.alerts, 1)
+			assert.Equal(t, action.AlertTypeWarning, state.alerts[0].Type)
+			assert.Equal(t, test.expectedMessage, state.alerts[0].Message)
+
+			// the preview can still be applied once it's confirmed
+			assert.NotNil(t, m.previewed)
+		})
+	}
+}
+
+func TestManifestManager_Apply_notPreviewed(t *testing.T) {
+	m := NewManifestManager(nil)
+
+	state := &alertState{}
+	require.NoError(t, m.Apply(state, action.Payload{"confirm": []interface{}{"true"}}))
+
+	require.Len(t, state.alerts, 1)
+	assert.Equal(t, "Unable to apply manifests: manifests were not previewed; preview them before applying", state.alerts[0].Message)
+
+	select {
+	case event := <-m.events:
+		assert.Equal(t, octant.EventType(EventTypeManifestApplied), event.Type)
+	default:
+		t.Fatal("expected a manifest event")
+	}
+}
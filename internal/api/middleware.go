@@ -9,6 +9,7 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/gorilla/mux"
@@ -53,3 +54,41 @@ func rebindHandler(ctx context.Context, acceptedHosts []string) mux.MiddlewareFu
 		})
 	}
 }
+
+// RequestHeader is a header the frontend adds to requests which change state.
+// Browsers don't let other sites add custom headers without a CORS preflight,
+// which the API doesn't allow.
+const RequestHeader = "X-Kubeon-Request"
+
+// originAllowed returns true if a request comes from an accepted origin. Requests
+// without an Origin header must have RequestHeader set instead.
+func originAllowed(r *http.Request, acceptedHosts []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return r.Header.Get(RequestHeader) != ""
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+
+	return shouldAllowHost(u.Hostname(), acceptedHosts)
+}
+
+// originHandler is a middleware which rejects cross site requests. Only
+// requests from the accepted hosts are allowed.
+func originHandler(ctx context.Context, acceptedHosts []string) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !originAllowed(r, acceptedHosts) {
+				logger := log.From(ctx)
+				logger.Debugf("Rejecting request from origin %q to %s", r.Header.Get("Origin"), r.URL.Path)
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
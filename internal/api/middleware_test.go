@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOriginAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected bool
+	}{
+		{
+			name:     "accepted origin",
+			headers:  map[string]string{"Origin": "http://localhost:7777"},
+			expected: true,
+		},
+		{
+			name:    "other origin",
+			headers: map[string]string{"Origin": "https://example.com"},
+		},
+		{
+			name:    "other origin with request header",
+			headers: map[string]string{"Origin": "https://example.com", RequestHeader: "1"},
+		},
+		{
+			name:    "opaque origin",
+			headers: map[string]string{"Origin": "null"},
+		},
+		{
+			name:     "no origin with request header",
+			headers:  map[string]string{RequestHeader: "1"},
+			expected: true,
+		},
+		{
+			name: "no origin",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/containers/files/upload", nil)
+			for k, v := range test.headers {
+				r.Header.Set(k, v)
+			}
+
+			assert.Equal(t, test.expected, originAllowed(r, []string{"localhost", "127.0.0.1"}))
+		})
+	}
+}
+
+func TestUpgraderCheckOrigin(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		origin     string
+		expected   bool
+	}{
+		{
+			name:       "accepted origin",
+			remoteAddr: "127.0.0.1:50000",
+			origin:     "http://127.0.0.1:7777",
+			expected:   true,
+		},
+		{
+			name:       "other origin",
+			remoteAddr: "127.0.0.1:50000",
+			origin:     "https://example.com",
+		},
+		{
+			name:       "no origin",
+			remoteAddr: "127.0.0.1:50000",
+			expected:   true,
+		},
+		{
+			name:       "remote address not accepted",
+			remoteAddr: "10.0.0.1:50000",
+			origin:     "http://localhost:7777",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/stream", nil)
+			r.RemoteAddr = test.remoteAddr
+			if test.origin != "" {
+				r.Header.Set("Origin", test.origin)
+			}
+
+			assert.Equal(t, test.expected, upgrader.CheckOrigin(r))
+		})
+	}
+}
@@ -0,0 +1,296 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubenext/kubeon/internal/objectedit"
+	"github.com/vmware/octant/internal/cluster"
+	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/pkg/action"
+)
+
+const (
+	// RequestPreviewObjectEdit is the request type for previewing an edited object.
+	RequestPreviewObjectEdit = "previewObjectEdit"
+	// RequestApplyObjectEdit is the request type for applying a previewed edit.
+	RequestApplyObjectEdit = "applyObjectEdit"
+
+	// EventTypeObjectEditPreview is the event type for the diff of an edit.
+	EventTypeObjectEditPreview = "objectEditPreview"
+	// EventTypeObjectEditApplied is the event type sent after an edit is applied.
+	EventTypeObjectEditApplied = "objectEditApplied"
+)
+
+// ObjectEditManagerConfig is configuration for ObjectEditManager.
+type ObjectEditManagerConfig interface {
+	ClusterClient() cluster.ClientInterface
+}
+
+// ObjectEditManager edits objects as YAML. An edit is previewed with a
+// server-side dry run first, and only a document which was previewed can be
+// applied. Both requests can be made by forms, so outcomes are sent as alerts
+// as well as events.
+type ObjectEditManager struct {
+	config ObjectEditManagerConfig
+	events chan octant.Event
+
+	mu sync.Mutex
+	// previewed is the last document previewed for each object.
+	previewed map[objectedit.Target]string
+}
+
+var _ StateManager = (*ObjectEditManager)(nil)
+
+// NewObjectEditManager creates an instance of ObjectEditManager.
+func NewObjectEditManager(config ObjectEditManagerConfig) *ObjectEditManager {
+	return &ObjectEditManager{
+		config:    config,
+		events:    make(chan octant.Event, 10),
+		previewed: make(map[objectedit.Target]string),
+	}
+}
+
+// Handlers returns a slice of handlers.
+func (m *ObjectEditManager) Handlers() []octant.ClientRequestHandler {
+	return []octant.ClientRequestHandler{
+		{
+			RequestType: RequestPreviewObjectEdit,
+			Handler:     m.Preview,
+		},
+		{
+			RequestType: RequestApplyObjectEdit,
+			Handler:     m.Apply,
+		},
+	}
+}
+
+// Preview validates an edited document, runs a server-side dry run update and
+// sends the diff against the live object.
+func (m *ObjectEditManager) Preview(state octant.State, payload action.Payload) error {
+	target, err := objectEditTargetFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	data, err := payload.String("yaml")
+	if err != nil {
+		return errors.Wrap(err, "extract yaml from payload")
+	}
+
+	diff, err := m.preview(target, data)
+	if err != nil {
+		state.SendAlert(objectEditAlert(target, "Unable to preview edit", err))
+		m.events <- CreateObjectEditEvent(EventTypeObjectEditPreview, target, action.Payload{}, err)
+		return nil
+	}
+
+	m.mu.Lock()
+	m.previewed[target] = data
+	m.mu.Unlock()
+
+	message := fmt.Sprintf("Previewed edit of %s %q; apply it to save the changes", target.Kind, target.Name)
+	if diff == "" {
+		message = fmt.Sprintf("Edit of %s %q doesn't change it", target.Kind, target.Name)
+	}
+	state.SendAlert(action.CreateAlert(action.AlertTypeInfo, message, action.DefaultAlertExpiration))
+
+	m.events <- CreateObjectEditEvent(EventTypeObjectEditPreview, target, action.Payload{
+		"diff": diff,
+		"hash": hashDocument(data),
+	}, nil)
+	return nil
+}
+
+func (m *ObjectEditManager) preview(target objectedit.Target, data string) (string, error) {
+	edited, err := objectedit.Parse(data, target)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := m.resourceClient(target)
+	if err != nil {
+		return "", err
+	}
+
+	return objectedit.Preview(client, target, edited)
+}
+
+// Apply applies the last document previewed for an object. If the payload
+// has a yaml or hash field, it must match the previewed document, so a
+// client can't apply something other than what it showed. Otherwise the
+// apply must be confirmed.
+func (m *ObjectEditManager) Apply(state octant.State, payload action.Payload) error {
+	target, err := objectEditTargetFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	hash, err := payload.OptionalString("hash")
+	if err != nil {
+		return errors.Wrap(err, "extract hash from payload")
+	}
+
+	if data, err := payload.OptionalString("yaml"); err != nil {
+		return errors.Wrap(err, "extract yaml from payload")
+	} else if data != "" {
+		hash = hashDocument(data)
+	}
+
+	confirmed, err := payload.OptionalStringSlice("confirm")
+	if err != nil {
+		return errors.Wrap(err, "extract confirm from payload")
+	}
+
+	if hash == "" && len(confirmed) == 0 {
+		// the Apply Edit form doesn't know which document was previewed, so
+		// applying it has to be confirmed
+		state.SendAlert(objectEditAlert(target, "Unable to apply edit", errors.New("it was not confirmed")))
+		return nil
+	}
+
+	m.mu.Lock()
+	data, previewed := m.previewed[target]
+	if previewed && hash != "" && hash != hashDocument(data) {
+		previewed = false
+	}
+	if previewed {
+		delete(m.previewed, target)
+	}
+	m.mu.Unlock()
+
+	if !previewed {
+		err = errors.New("edit was not previewed; preview it before applying")
+	} else {
+		err = m.apply(target, data)
+	}
+
+	if err != nil {
+		state.SendAlert(objectEditAlert(target, "Unable to apply edit", err))
+	} else {
+		state.SendAlert(action.CreateAlert(action.AlertTypeInfo,
+			fmt.Sprintf("Applied edit of %s %q", target.Kind, target.Name), action.DefaultAlertExpiration))
+	}
+
+	m.events <- CreateObjectEditEvent(EventTypeObjectEditApplied, target, action.Payload{}, err)
+	return nil
+}
+
+func objectEditAlert(target objectedit.Target, message string, err error) action.Alert {
+	message = fmt.Sprintf("%s of %s %q: %s", message, target.Kind, target.Name, err)
+	return action.CreateAlert(action.AlertTypeWarning, message, action.DefaultAlertExpiration)
+}
+
+func (m *ObjectEditManager) apply(target objectedit.Target, data string) error {
+	edited, err := objectedit.Parse(data, target)
+	if err != nil {
+		return err
+	}
+
+	client, err := m.resourceClient(target)
+	if err != nil {
+		return err
+	}
+
+	_, err = objectedit.Apply(client, target, edited)
+	return err
+}
+
+func (m *ObjectEditManager) resourceClient(target objectedit.Target) (dynamic.ResourceInterface, error) {
+	clusterClient := m.config.ClusterClient()
+
+	gvk := schema.FromAPIVersionAndKind(target.APIVersion, target.Kind)
+	gvr, err := clusterClient.Resource(gvk.GroupKind())
+	if err != nil {
+		return nil, errors.Wrapf(err, "find resource for %s", target)
+	}
+
+	dynamicClient, err := clusterClient.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if target.Namespace == "" {
+		return dynamicClient.Resource(gvr), nil
+	}
+	return dynamicClient.Resource(gvr).Namespace(target.Namespace), nil
+}
+
+// Start starts the manager. Edit results are sent until the context is cancelled.
+func (m *ObjectEditManager) Start(ctx context.Context, state octant.State, s OctantClient) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-m.events:
+			s.Send(event)
+		}
+	}
+}
+
+func objectEditTargetFromPayload(payload action.Payload) (objectedit.Target, error) {
+	var target objectedit.Target
+
+	apiVersion, err := payload.String("apiVersion")
+	if err != nil {
+		return target, errors.Wrap(err, "extract apiVersion from payload")
+	}
+
+	kind, err := payload.String("kind")
+	if err != nil {
+		return target, errors.Wrap(err, "extract kind from payload")
+	}
+
+	namespace, err := payload.OptionalString("namespace")
+	if err != nil {
+		return target, errors.Wrap(err, "extract namespace from payload")
+	}
+
+	name, err := payload.String("name")
+	if err != nil {
+		return target, errors.Wrap(err, "extract name from payload")
+	}
+
+	target = objectedit.Target{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  namespace,
+		Name:       name,
+	}
+
+	return target, nil
+}
+
+func hashDocument(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateObjectEditEvent creates an object edit event. If err is set, the
+// event includes it, and conflict is true if the object changed since the
+// edited document was read.
+func CreateObjectEditEvent(eventType string, target objectedit.Target, payload action.Payload, err error) octant.Event {
+	payload["apiVersion"] = target.APIVersion
+	payload["kind"] = target.Kind
+	payload["namespace"] = target.Namespace
+	payload["name"] = target.Name
+
+	if err != nil {
+		payload["error"] = err.Error()
+		payload["conflict"] = objectedit.IsConflict(err)
+	}
+
+	return CreateEvent(eventType, payload)
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubenext/kubeon/internal/queryer"
+	"github.com/kubenext/kubeon/internal/rollout"
+	"github.com/vmware/octant/internal/cluster"
+	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/store"
+)
+
+const (
+	// RequestCompareRevisions is the request type for comparing two revisions.
+	RequestCompareRevisions = "compareRevisions"
+
+	// EventTypeRevisionComparison is the event type for the changes between
+	// two revisions.
+	EventTypeRevisionComparison = "revisionComparison"
+)
+
+// RevisionManagerConfig is configuration for RevisionManager.
+type RevisionManagerConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// RevisionManager compares the pod templates of revisions of deployments,
+// daemon sets and stateful sets.
+type RevisionManager struct {
+	config RevisionManagerConfig
+	events chan octant.Event
+}
+
+var _ StateManager = (*RevisionManager)(nil)
+
+// NewRevisionManager creates an instance of RevisionManager.
+func NewRevisionManager(config RevisionManagerConfig) *RevisionManager {
+	return &RevisionManager{
+		config: config,
+		events: make(chan octant.Event, 10),
+	}
+}
+
+// Handlers returns a slice of handlers.
+func (m *RevisionManager) Handlers() []octant.ClientRequestHandler {
+	return []octant.ClientRequestHandler{
+		{
+			RequestType: RequestCompareRevisions,
+			Handler:     m.Compare,
+		},
+	}
+}
+
+// Compare sends the changes between two revisions of an object.
+func (m *RevisionManager) Compare(state octant.State, payload action.Payload) error {
+	key, err := store.KeyFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	from, err := revisionFromPayload(payload, "from")
+	if err != nil {
+		return err
+	}
+
+	to, err := revisionFromPayload(payload, "to")
+	if err != nil {
+		return err
+	}
+
+	event := action.Payload{
+		"apiVersion": key.APIVersion,
+		"kind":       key.Kind,
+		"namespace":  key.Namespace,
+		"name":       key.Name,
+		"from":       from,
+		"to":         to,
+	}
+
+	comparison, err := m.compare(key, from, to)
+	if err != nil {
+		event["error"] = err.Error()
+	} else {
+		event["changes"] = comparison.Changes
+		event["diff"] = comparison.Diff
+	}
+
+	state.SendAlert(comparisonAlert(key, comparison, err))
+	m.events <- CreateEvent(EventTypeRevisionComparison, event)
+	return nil
+}
+
+// comparisonAlert summarizes a comparison for clients which only show alerts.
+// The full diff is in the comparison event.
+func comparisonAlert(key store.Key, comparison rollout.Comparison, err error) action.Alert {
+	if err != nil {
+		message := fmt.Sprintf("Unable to compare revisions of %s %q: %s", key.Kind, key.Name, err)
+		return action.CreateAlert(action.AlertTypeWarning, message, action.DefaultAlertExpiration)
+	}
+
+	if len(comparison.Changes) == 0 {
+		message := fmt.Sprintf("Revisions %d and %d of %s %q have the same pod template",
+			comparison.From, comparison.To, key.Kind, key.Name)
+		return action.CreateAlert(action.AlertTypeInfo, message, action.DefaultAlertExpiration)
+	}
+
+	var paths []string
+	for _, change := range comparison.Changes {
+		paths = append(paths, change.Path)
+	}
+
+	message := fmt.Sprintf("Revision %d to %d of %s %q changes %s",
+		comparison.From, comparison.To, key.Kind, key.Name, strings.Join(paths, ", "))
+	return action.CreateAlert(action.AlertTypeInfo, message, action.DefaultAlertExpiration)
+}
+
+func (m *RevisionManager) compare(key store.Key, from, to int64) (rollout.Comparison, error) {
+	if !rollout.Supported(key.APIVersion, key.Kind) {
+		return rollout.Comparison{}, errors.Errorf("%s has no revisions", key.Kind)
+	}
+
+	clusterClient := m.config.ClusterClient()
+
+	gvk := schema.FromAPIVersionAndKind(key.APIVersion, key.Kind)
+	gvr, err := clusterClient.Resource(gvk.GroupKind())
+	if err != nil {
+		return rollout.Comparison{}, errors.Wrapf(err, "find resource for %s", key.Kind)
+	}
+
+	dynamicClient, err := clusterClient.DynamicClient()
+	if err != nil {
+		return rollout.Comparison{}, err
+	}
+
+	object, err := dynamicClient.Resource(gvr).Namespace(key.Namespace).Get(key.Name, metav1.GetOptions{})
+	if err != nil {
+		return rollout.Comparison{}, err
+	}
+
+	discoveryClient, err := clusterClient.DiscoveryClient()
+	if err != nil {
+		return rollout.Comparison{}, err
+	}
+
+	ctx := context.Background()
+	q := queryer.New(m.config.ObjectStore(), discoveryClient)
+
+	revisions, err := rollout.Revisions(ctx, q, object)
+	if err != nil {
+		return rollout.Comparison{}, err
+	}
+
+	return rollout.Compare(revisions, from, to)
+}
+
+// Start starts the manager. Comparisons are sent until the context is cancelled.
+func (m *RevisionManager) Start(ctx context.Context, state octant.State, s OctantClient) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-m.events:
+			s.Send(event)
+		}
+	}
+}
+
+func revisionFromPayload(payload action.Payload, key string) (int64, error) {
+	value, err := payload.String(key)
+	if err != nil {
+		return 0, errors.Wrapf(err, "extract %s from payload", key)
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("%s revision %q is not a number", key, value)
+	}
+
+	return number, nil
+}
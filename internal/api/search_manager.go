@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubenext/kubeon/internal/search"
+	"github.com/vmware/octant/internal/cluster"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/store"
+)
+
+const (
+	// RequestSetSearchQuery is the request type for running a search.
+	RequestSetSearchQuery = "setSearchQuery"
+
+	// EventTypeSearchResults is the event type for search results.
+	EventTypeSearchResults = "searchResults"
+)
+
+// SearchManagerConfig is configuration for SearchManager.
+type SearchManagerConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// SearchManager runs searches for a client. Only the latest query is run; a new
+// query cancels the search in progress.
+type SearchManager struct {
+	config  SearchManagerConfig
+	queries chan string
+}
+
+var _ StateManager = (*SearchManager)(nil)
+
+// NewSearchManager creates an instance of SearchManager.
+func NewSearchManager(config SearchManagerConfig) *SearchManager {
+	return &SearchManager{
+		config:  config,
+		queries: make(chan string, 1),
+	}
+}
+
+// Handlers returns a slice of handlers.
+func (sm *SearchManager) Handlers() []octant.ClientRequestHandler {
+	return []octant.ClientRequestHandler{
+		{
+			RequestType: RequestSetSearchQuery,
+			Handler:     sm.SetQuery,
+		},
+	}
+}
+
+// SetQuery queues a search query.
+func (sm *SearchManager) SetQuery(state octant.State, payload action.Payload) error {
+	query, err := payload.String("query")
+	if err != nil {
+		return errors.Wrap(err, "extract query from payload")
+	}
+
+	// replace a queued query which has not started yet
+	select {
+	case <-sm.queries:
+	default:
+	}
+
+	sm.queries <- query
+	return nil
+}
+
+// Start starts the manager. It runs queries until the context is cancelled.
+func (sm *SearchManager) Start(ctx context.Context, state octant.State, s OctantClient) {
+	var cancel context.CancelFunc = func() {}
+
+	for {
+		select {
+		case <-ctx.Done():
+			cancel()
+			return
+		case query := <-sm.queries:
+			cancel()
+
+			var searchCtx context.Context
+			searchCtx, cancel = context.WithCancel(ctx)
+			go sm.search(searchCtx, query, s)
+		}
+	}
+}
+
+func (sm *SearchManager) search(ctx context.Context, raw string, s OctantClient) {
+	logger := log.From(ctx).With("query", raw)
+
+	source := search.SourceFor(sm.config.ObjectStore())
+	query := search.ParseQuery(raw)
+	results, err := search.NewSearcher(source, sm.config.ClusterClient()).Search(ctx, query)
+	if err != nil {
+		logger.WithErr(err).Errorf("search objects")
+		return
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.Send(CreateSearchResultsEvent(query, results))
+}
+
+// CreateSearchResultsEvent creates a search results event.
+func CreateSearchResultsEvent(query search.Query, results []search.Result) octant.Event {
+	var items []map[string]interface{}
+	for _, result := range results {
+		object := result.Object
+		items = append(items, map[string]interface{}{
+			"apiVersion": object.GetAPIVersion(),
+			"kind":       object.GetKind(),
+			"namespace":  object.GetNamespace(),
+			"name":       object.GetName(),
+			"uid":        object.GetUID(),
+			"score":      result.Score,
+			"reasons":    result.Reasons,
+		})
+	}
+
+	return CreateEvent(EventTypeSearchResults, action.Payload{
+		"query":   query.String(),
+		"results": items,
+	})
+}
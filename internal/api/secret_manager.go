@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubenext/kubeon/internal/secretdata"
+	kubeonstore "github.com/kubenext/kubeon/pkg/store"
+	"github.com/vmware/octant/internal/cluster"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/store"
+)
+
+const (
+	// RequestRevealSecretValue is the request type for revealing the value of a secret key.
+	RequestRevealSecretValue = "revealSecretValue"
+
+	// EventTypeSecretValue is the event type for a revealed secret value.
+	EventTypeSecretValue = "secretValue"
+)
+
+// SecretManagerConfig is configuration for SecretManager.
+type SecretManagerConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// SecretManager reveals the values of secret keys. Values are read from the
+// cluster when they are revealed rather than sent with the secret's page,
+// and every reveal is logged.
+type SecretManager struct {
+	config SecretManagerConfig
+	logger log.Logger
+	events chan octant.Event
+}
+
+var _ StateManager = (*SecretManager)(nil)
+
+// NewSecretManager creates an instance of SecretManager.
+func NewSecretManager(config SecretManagerConfig, logger log.Logger) *SecretManager {
+	return &SecretManager{
+		config: config,
+		logger: logger.With("component", "secret-manager"),
+		events: make(chan octant.Event, 10),
+	}
+}
+
+// Handlers returns a slice of handlers.
+func (m *SecretManager) Handlers() []octant.ClientRequestHandler {
+	return []octant.ClientRequestHandler{
+		{
+			RequestType: RequestRevealSecretValue,
+			Handler:     m.Reveal,
+		},
+	}
+}
+
+// Reveal sends the decoded value of a secret key and its detected format.
+func (m *SecretManager) Reveal(state octant.State, payload action.Payload) error {
+	namespace, err := payload.String("namespace")
+	if err != nil {
+		return errors.Wrap(err, "extract namespace from payload")
+	}
+
+	name, err := payload.String("name")
+	if err != nil {
+		return errors.Wrap(err, "extract name from payload")
+	}
+
+	key, err := payload.String("key")
+	if err != nil {
+		return errors.Wrap(err, "extract key from payload")
+	}
+
+	eventPayload := action.Payload{
+		"namespace": namespace,
+		"name":      name,
+		"key":       key,
+	}
+
+	value, err := m.value(namespace, name, key)
+	if err != nil {
+		m.logger.WithErr(err).With("namespace", namespace, "name", name, "key", key).
+			Errorf("unable to reveal secret value")
+		eventPayload["error"] = err.Error()
+		// the reveal is usually requested by a button, so failures are also
+		// shown as an alert; values never are
+		state.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+			fmt.Sprintf("Unable to reveal key %q of secret %q: %s", key, name, err), action.DefaultAlertExpiration))
+		m.events <- CreateEvent(EventTypeSecretValue, eventPayload)
+		return nil
+	}
+
+	m.logger.With("namespace", namespace, "name", name, "key", key).Infof("revealed secret value")
+
+	revealed := secretdata.Reveal(value)
+	eventPayload["format"] = revealed.Format
+	eventPayload["size"] = revealed.Size
+	eventPayload["text"] = revealed.Text
+	eventPayload["base64"] = revealed.Base64
+	// the key is used as the file name when the value is downloaded
+	eventPayload["filename"] = key
+
+	m.events <- CreateEvent(EventTypeSecretValue, eventPayload)
+	return nil
+}
+
+func (m *SecretManager) value(namespace, name, key string) ([]byte, error) {
+	secretKey := kubeonstore.Key{Namespace: namespace, ApiVersion: "v1", Kind: "Secret", Name: name}
+	if err := kubeonstore.HasSubresourceAccess(context.Background(), m.config.ObjectStore(), secretKey, "", "get"); err != nil {
+		return nil, err
+	}
+
+	kubeClient, err := m.config.ClusterClient().KubernetesClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "create kubernetes client")
+	}
+
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, errors.Errorf("secret %q has no key %q", name, key)
+	}
+
+	return value, nil
+}
+
+// Start starts the manager. Revealed values are sent until the context is cancelled.
+func (m *SecretManager) Start(ctx context.Context, state octant.State, s OctantClient) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-m.events:
+			s.Send(event)
+		}
+	}
+}
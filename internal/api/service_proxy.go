@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kubenext/kubeon/internal/proxy"
+	kubeonstore "github.com/kubenext/kubeon/pkg/store"
+	"github.com/vmware/octant/internal/cluster"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/pkg/store"
+)
+
+// ServiceProxyConfig is configuration for the service proxy handler.
+type ServiceProxyConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// checkProxyAccess returns an error if the current user can't get the proxy
+// subresource of a pod or service. Access is denied if the object store can't
+// check it.
+func checkProxyAccess(ctx context.Context, objectStore store.Store, target proxy.Target) error {
+	key := kubeonstore.Key{
+		Namespace:  target.Namespace,
+		ApiVersion: "v1",
+		Kind:       target.Kind(),
+		Name:       target.Name,
+	}
+
+	return kubeonstore.HasSubresourceAccess(ctx, objectStore, key, "proxy", "get")
+}
+
+// serviceProxyHandler proxies GET and HEAD requests to a pod or service port
+// through the API server. The target is read from the resource, namespace,
+// name and port path variables. Navigations don't have an Origin header, but
+// requests which do must come from an accepted host.
+func serviceProxyHandler(ctx context.Context, config ServiceProxyConfig, acceptedHosts []string) http.HandlerFunc {
+	logger := log.From(ctx)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			RespondWithError(w, http.StatusMethodNotAllowed, "only GET and HEAD requests are proxied", logger)
+			return
+		}
+
+		if r.Header.Get("Origin") != "" && !originAllowed(r, acceptedHosts) {
+			RespondWithError(w, http.StatusForbidden, "cross origin requests are not proxied", logger)
+			return
+		}
+
+		vars := mux.Vars(r)
+		target := proxy.Target{
+			Resource:  vars["resource"],
+			Namespace: vars["namespace"],
+			Name:      vars["name"],
+			Port:      vars["port"],
+		}
+
+		if err := target.Validate(); err != nil {
+			RespondWithError(w, http.StatusBadRequest, err.Error(), logger)
+			return
+		}
+
+		// relative links only work if the root has a trailing slash
+		if !strings.HasPrefix(r.URL.Path, proxy.Path(target)) {
+			http.Redirect(w, r, proxy.Path(target), http.StatusMovedPermanently)
+			return
+		}
+
+		if err := checkProxyAccess(r.Context(), config.ObjectStore(), target); err != nil {
+			RespondWithError(w, http.StatusForbidden, err.Error(), logger)
+			return
+		}
+
+		clusterClient := config.ClusterClient()
+		kubeClient, err := clusterClient.KubernetesClient()
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+			return
+		}
+
+		reverseProxy, err := proxy.New(kubeClient, clusterClient.RestConfig(), target)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+			return
+		}
+
+		reverseProxy.ServeHTTP(w, r)
+	}
+}
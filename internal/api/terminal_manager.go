@@ -0,0 +1,328 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubenext/kubeon/internal/terminal"
+	kubeonstore "github.com/kubenext/kubeon/pkg/store"
+	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/store"
+)
+
+const (
+	// RequestStartTerminal is the request type for starting a terminal.
+	RequestStartTerminal = "startTerminal"
+	// RequestAttachTerminal is the request type for attaching to a running terminal.
+	RequestAttachTerminal = "attachTerminal"
+	// RequestSendTerminalInput is the request type for sending input to a terminal.
+	RequestSendTerminalInput = "sendTerminalInput"
+	// RequestResizeTerminal is the request type for resizing a terminal.
+	RequestResizeTerminal = "resizeTerminal"
+	// RequestStopTerminal is the request type for stopping a terminal.
+	RequestStopTerminal = "stopTerminal"
+
+	// EventTypeTerminalStarted is the event type sent when a terminal is attached.
+	EventTypeTerminalStarted = "terminalStarted"
+	// EventTypeTerminalOutput is the event type for terminal output.
+	EventTypeTerminalOutput = "terminalOutput"
+	// EventTypeTerminalExited is the event type sent when a terminal exits.
+	EventTypeTerminalExited = "terminalExited"
+	// EventTypeTerminalDetached is the event type sent when a client is
+	// detached from a running terminal because it didn't keep up with output.
+	EventTypeTerminalDetached = "terminalDetached"
+)
+
+// TerminalManagerConfig is configuration for TerminalManager.
+type TerminalManagerConfig interface {
+	ObjectStore() store.Store
+	TerminalManager() terminal.Manager
+}
+
+// checkExecAccess returns an error if the current user can't create pods/exec
+// for a pod. Access is denied if the object store can't check it.
+func checkExecAccess(ctx context.Context, objectStore store.Store, namespace, podName string) error {
+	key := kubeonstore.Key{
+		Namespace:  namespace,
+		ApiVersion: "v1",
+		Kind:       "Pod",
+		Name:       podName,
+	}
+
+	return kubeonstore.HasSubresourceAccess(ctx, objectStore, key, "exec", "create")
+}
+
+// TerminalManager bridges terminals to a websocket client. Terminals outlive
+// the client so they can be attached to again after a reload.
+type TerminalManager struct {
+	config  TerminalManagerConfig
+	pending chan terminal.Instance
+
+	mu       sync.Mutex
+	attached map[string]bool
+}
+
+var _ StateManager = (*TerminalManager)(nil)
+
+// NewTerminalManager creates an instance of TerminalManager.
+func NewTerminalManager(config TerminalManagerConfig) *TerminalManager {
+	return &TerminalManager{
+		config:   config,
+		pending:  make(chan terminal.Instance, 10),
+		attached: make(map[string]bool),
+	}
+}
+
+// Handlers returns a slice of handlers.
+func (tm *TerminalManager) Handlers() []octant.ClientRequestHandler {
+	return []octant.ClientRequestHandler{
+		{
+			RequestType: RequestStartTerminal,
+			Handler:     tm.StartTerminal,
+		},
+		{
+			RequestType: RequestAttachTerminal,
+			Handler:     tm.AttachTerminal,
+		},
+		{
+			RequestType: RequestSendTerminalInput,
+			Handler:     tm.SendInput,
+		},
+		{
+			RequestType: RequestResizeTerminal,
+			Handler:     tm.Resize,
+		},
+		{
+			RequestType: RequestStopTerminal,
+			Handler:     tm.StopTerminal,
+		},
+	}
+}
+
+// StartTerminal starts a terminal in a container and attaches to it. The
+// command is split on whitespace; the default shell is used if it is blank.
+func (tm *TerminalManager) StartTerminal(state octant.State, payload action.Payload) error {
+	namespace, err := payload.String("namespace")
+	if err != nil {
+		return errors.Wrap(err, "extract namespace from payload")
+	}
+
+	podName, err := payload.String("podName")
+	if err != nil {
+		return errors.Wrap(err, "extract pod name from payload")
+	}
+
+	containerName, err := payload.String("containerName")
+	if err != nil {
+		return errors.Wrap(err, "extract container name from payload")
+	}
+
+	command, err := payload.OptionalString("command")
+	if err != nil {
+		return errors.Wrap(err, "extract command from payload")
+	}
+
+	ctx := context.Background()
+
+	if err := checkExecAccess(ctx, tm.config.ObjectStore(), namespace, podName); err != nil {
+		return err
+	}
+
+	inst, err := tm.config.TerminalManager().Create(ctx, namespace, podName, containerName, strings.Fields(command))
+	if err != nil {
+		return errors.Wrap(err, "start terminal")
+	}
+
+	tm.pending <- inst
+	return nil
+}
+
+// AttachTerminal attaches to a running terminal.
+func (tm *TerminalManager) AttachTerminal(state octant.State, payload action.Payload) error {
+	inst, err := tm.instance(payload)
+	if err != nil {
+		return err
+	}
+
+	tm.pending <- inst
+	return nil
+}
+
+// SendInput writes input to a terminal.
+func (tm *TerminalManager) SendInput(state octant.State, payload action.Payload) error {
+	inst, err := tm.instance(payload)
+	if err != nil {
+		return err
+	}
+
+	data, err := payload.String("data")
+	if err != nil {
+		return errors.Wrap(err, "extract data from payload")
+	}
+
+	if _, err := inst.Write([]byte(data)); err != nil {
+		return errors.Wrap(err, "write terminal input")
+	}
+
+	return nil
+}
+
+// Resize resizes a terminal.
+func (tm *TerminalManager) Resize(state octant.State, payload action.Payload) error {
+	inst, err := tm.instance(payload)
+	if err != nil {
+		return err
+	}
+
+	cols, err := payload.Uint16("cols")
+	if err != nil {
+		return errors.Wrap(err, "extract cols from payload")
+	}
+
+	rows, err := payload.Uint16("rows")
+	if err != nil {
+		return errors.Wrap(err, "extract rows from payload")
+	}
+
+	inst.Resize(cols, rows)
+	return nil
+}
+
+// StopTerminal stops a terminal.
+func (tm *TerminalManager) StopTerminal(state octant.State, payload action.Payload) error {
+	id, err := payload.String("id")
+	if err != nil {
+		return errors.Wrap(err, "extract id from payload")
+	}
+
+	tm.config.TerminalManager().Delete(id)
+	return nil
+}
+
+// Start starts the manager. Output from attached terminals is sent until the
+// context is cancelled.
+func (tm *TerminalManager) Start(ctx context.Context, state octant.State, s OctantClient) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case inst := <-tm.pending:
+			if !tm.markAttached(inst.ID()) {
+				continue
+			}
+			go tm.stream(ctx, inst, s)
+		}
+	}
+}
+
+func (tm *TerminalManager) stream(ctx context.Context, inst terminal.Instance, s OctantClient) {
+	defer tm.markDetached(inst.ID())
+
+	output, unsubscribe := inst.Subscribe()
+	defer unsubscribe()
+
+	s.Send(CreateTerminalStartedEvent(inst))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case data, ok := <-output:
+			if !ok {
+				select {
+				case <-inst.Done():
+					s.Send(CreateTerminalExitedEvent(inst))
+				default:
+					s.Send(CreateTerminalDetachedEvent(inst.ID(), terminal.ErrSlowSubscriber))
+				}
+				return
+			}
+			s.Send(CreateTerminalOutputEvent(inst.ID(), data))
+		}
+	}
+}
+
+func (tm *TerminalManager) instance(payload action.Payload) (terminal.Instance, error) {
+	id, err := payload.String("id")
+	if err != nil {
+		return nil, errors.Wrap(err, "extract id from payload")
+	}
+
+	inst, ok := tm.config.TerminalManager().Get(id)
+	if !ok {
+		return nil, errors.Errorf("terminal %s not found", id)
+	}
+
+	return inst, nil
+}
+
+func (tm *TerminalManager) markAttached(id string) bool {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	if tm.attached[id] {
+		return false
+	}
+	tm.attached[id] = true
+	return true
+}
+
+func (tm *TerminalManager) markDetached(id string) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	delete(tm.attached, id)
+}
+
+// CreateTerminalStartedEvent creates a terminal started event.
+func CreateTerminalStartedEvent(inst terminal.Instance) octant.Event {
+	return CreateEvent(EventTypeTerminalStarted, action.Payload{
+		"id":            inst.ID(),
+		"namespace":     inst.Namespace(),
+		"podName":       inst.PodName(),
+		"containerName": inst.Container(),
+		"command":       strings.Join(inst.Command(), " "),
+		"createdAt":     inst.CreatedAt().Unix(),
+	})
+}
+
+// CreateTerminalOutputEvent creates a terminal output event. Output is base64
+// encoded since it may not be valid UTF-8.
+func CreateTerminalOutputEvent(id string, data []byte) octant.Event {
+	return CreateEvent(EventTypeTerminalOutput, action.Payload{
+		"id":   id,
+		"data": base64.StdEncoding.EncodeToString(data),
+	})
+}
+
+// CreateTerminalDetachedEvent creates a terminal detached event. The
+// terminal is still running and can be attached to again.
+func CreateTerminalDetachedEvent(id string, err error) octant.Event {
+	return CreateEvent(EventTypeTerminalDetached, action.Payload{
+		"id":    id,
+		"error": err.Error(),
+	})
+}
+
+// CreateTerminalExitedEvent creates a terminal exited event.
+func CreateTerminalExitedEvent(inst terminal.Instance) octant.Event {
+	payload := action.Payload{
+		"id": inst.ID(),
+	}
+
+	if err := inst.Err(); err != nil {
+		payload["error"] = err.Error()
+	}
+
+	return CreateEvent(EventTypeTerminalExited, payload)
+}
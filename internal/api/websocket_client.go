@@ -136,6 +136,8 @@ func (c *WebsocketClient) handle(message []byte) error {
 		return err
 	}
 
+	request = c.routeAction(request)
+
 	handlers, ok := c.handlers[request.Type]
 	if !ok {
 		return c.handleUnknownRequest(request)
@@ -160,6 +162,37 @@ func (c *WebsocketClient) handle(message []byte) error {
 	return nil
 }
 
+// formRequestTypes are the request types which forms and buttons can make.
+// Components can only send performAction requests, so these are sent with
+// the request type as the action name.
+var formRequestTypes = map[string]bool{
+	RequestPreviewObjectEdit: true,
+	RequestApplyObjectEdit:   true,
+	RequestCompareRevisions:  true,
+	RequestPreviewManifest:   true,
+	RequestApplyManifest:     true,
+	RequestRevealSecretValue: true,
+}
+
+// routeAction handles a performAction request for one of the form request
+// types as that request type. Other actions are dispatched to the modules.
+func (c *WebsocketClient) routeAction(request websocketRequest) websocketRequest {
+	if request.Type != RequestPerformAction {
+		return request
+	}
+
+	actionName, err := request.Payload.String("action")
+	if err != nil || !formRequestTypes[actionName] {
+		return request
+	}
+
+	if _, ok := c.handlers[actionName]; ok {
+		request.Type = actionName
+	}
+
+	return request
+}
+
 func (c *WebsocketClient) handleUnknownRequest(request websocketRequest) error {
 	message := "unknown request"
 	if request.Type != "" {
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/pkg/action"
+)
+
+func TestWebsocketClient_routeAction(t *testing.T) {
+	handler := func(state octant.State, payload action.Payload) error {
+		return nil
+	}
+
+	c := &WebsocketClient{
+		handlers: map[string][]octant.ClientRequestHandler{
+			RequestPerformAction:   {{RequestType: RequestPerformAction, Handler: handler}},
+			RequestApplyObjectEdit: {{RequestType: RequestApplyObjectEdit, Handler: handler}},
+			RequestStopTerminal:    {{RequestType: RequestStopTerminal, Handler: handler}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		request  websocketRequest
+		expected string
+	}{
+		{
+			name: "form request type",
+			request: websocketRequest{
+				Type:    RequestPerformAction,
+				Payload: action.Payload{"action": RequestApplyObjectEdit},
+			},
+			expected: RequestApplyObjectEdit,
+		},
+		{
+			name: "registered request type which forms can't make",
+			request: websocketRequest{
+				Type:    RequestPerformAction,
+				Payload: action.Payload{"action": RequestStopTerminal},
+			},
+			expected: RequestPerformAction,
+		},
+		{
+			name: "module action",
+			request: websocketRequest{
+				Type:    RequestPerformAction,
+				Payload: action.Payload{"action": "overview/startTerminal"},
+			},
+			expected: RequestPerformAction,
+		},
+		{
+			name: "not a performAction request",
+			request: websocketRequest{
+				Type:    RequestStopTerminal,
+				Payload: action.Payload{"action": RequestApplyObjectEdit},
+			},
+			expected: RequestStopTerminal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := c.routeAction(test.request)
+			assert.Equal(t, test.expected, got.Type)
+			assert.Equal(t, test.request.Payload, got.Payload)
+		})
+	}
+}
@@ -24,7 +24,16 @@ var (
 				return false
 			}
 
-			return shouldAllowHost(host, acceptedHosts())
+			if !shouldAllowHost(host, acceptedHosts()) {
+				return false
+			}
+
+			// browsers always send an Origin with websocket requests, so
+			// other sites can't open a connection from a user's browser
+			if r.Header.Get("Origin") == "" {
+				return true
+			}
+			return originAllowed(r, acceptedHosts())
 		},
 	}
 )
@@ -44,6 +44,13 @@ func defaultStateManagers(clientID string, dashConfig config.Dash) []StateManage
 		NewNamespacesManager(dashConfig),
 		NewContextManager(dashConfig),
 		NewActionRequestManager(),
+		NewSearchManager(dashConfig),
+		NewLogStreamManager(dashConfig),
+		NewTerminalManager(dashConfig),
+		NewObjectEditManager(dashConfig),
+		NewRevisionManager(dashConfig),
+		NewManifestManager(dashConfig),
+		NewSecretManager(dashConfig, logger),
 	}
 }
 
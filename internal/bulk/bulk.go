@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package bulk runs an operation for many objects and summarizes the results.
+package bulk
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubenext/kubeon/internal/metadataedit"
+	"github.com/kubenext/kubeon/internal/rollout"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+const (
+	// OperationDelete deletes objects.
+	OperationDelete = "delete"
+	// OperationLabel sets or removes labels.
+	OperationLabel = "label"
+	// OperationAnnotate sets or removes annotations.
+	OperationAnnotate = "annotate"
+	// OperationRestart restarts the rollout of workloads.
+	OperationRestart = "restart"
+)
+
+// ParseChanges parses label or annotation changes written like kubectl
+// arguments: "key=value" sets a key and "key-" removes it. Changes are
+// separated by new lines. Label changes can also be separated by commas,
+// since label values can't contain them; annotation values can. Removed keys
+// have a nil value.
+func ParseChanges(s string, labels bool) (map[string]interface{}, error) {
+	changes := make(map[string]interface{})
+
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '\n' || (labels && r == ',')
+	})
+
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		var key, value string
+		remove := false
+
+		switch {
+		case strings.Contains(field, "="):
+			parts := strings.SplitN(field, "=", 2)
+			key, value = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		case strings.HasSuffix(field, "-"):
+			key = strings.TrimSuffix(field, "-")
+			remove = true
+		default:
+			return nil, errors.Errorf("invalid change %q: use key=value to set or key- to remove", field)
+		}
+
+		var err error
+		if labels {
+			err = metadataedit.ValidateLabel(key, value)
+		} else {
+			err = metadataedit.ValidateAnnotationKey(key)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := changes[key]; ok {
+			return nil, errors.Errorf("%q is changed more than once", key)
+		}
+
+		if remove {
+			changes[key] = nil
+		} else {
+			changes[key] = value
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, errors.New("no changes")
+	}
+
+	return changes, nil
+}
+
+// Apply runs an operation for an object. changes are the label or annotation
+// changes for the label and annotate operations.
+func Apply(client dynamic.ResourceInterface, key store.Key, operation string, changes map[string]interface{}) error {
+	switch operation {
+	case OperationDelete:
+		return client.Delete(key.Name, &metav1.DeleteOptions{})
+	case OperationLabel, OperationAnnotate:
+		field := "labels"
+		if operation == OperationAnnotate {
+			field = "annotations"
+		}
+
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				field: changes,
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "marshal patch")
+		}
+
+		_, err = client.Patch(key.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+		return err
+	case OperationRestart:
+		if !rollout.Supported(key.ApiVersion, key.Kind) {
+			return errors.Errorf("%s can't be restarted", key.Kind)
+		}
+
+		object, err := client.Get(key.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		return rollout.Restart(client, object, time.Now())
+	default:
+		return errors.Errorf("unknown operation %q", operation)
+	}
+}
+
+// Result is the result of an operation for one object.
+type Result struct {
+	Key store.Key
+	Err error
+}
+
+// Summarize describes the results of an operation in one message. failed is
+// the number of objects the operation failed for.
+func Summarize(operation string, results []Result) (message string, failed int) {
+	var failures []string
+	for _, result := range results {
+		if result.Err != nil {
+			failures = append(failures, fmt.Sprintf("%s %q: %s", result.Key.Kind, result.Key.Name, result.Err))
+		}
+	}
+
+	failed = len(failures)
+	message = fmt.Sprintf("Ran %s for %d of %d objects", operation, len(results)-failed, len(results))
+	if failed > 0 {
+		message = fmt.Sprintf("%s. Failed: %s", message, strings.Join(failures, "; "))
+	}
+
+	return message, failed
+}
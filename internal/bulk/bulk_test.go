@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package bulk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		labels   bool
+		expected map[string]interface{}
+		isErr    bool
+	}{
+		{
+			name:     "labels separated by commas",
+			s:        "app=web, tier-",
+			labels:   true,
+			expected: map[string]interface{}{"app": "web", "tier": nil},
+		},
+		{
+			name:     "labels separated by new lines",
+			s:        "app=web\ntier=frontend\n",
+			labels:   true,
+			expected: map[string]interface{}{"app": "web", "tier": "frontend"},
+		},
+		{
+			name:     "annotation values with commas",
+			s:        "owners=alice,bob\nexample.com/note-",
+			expected: map[string]interface{}{"owners": "alice,bob", "example.com/note": nil},
+		},
+		{
+			name:   "invalid label value",
+			s:      "app=a b",
+			labels: true,
+			isErr:  true,
+		},
+		{
+			name:  "key changed twice",
+			s:     "owner=alice\nowner-",
+			isErr: true,
+		},
+		{
+			name:  "no changes",
+			s:     "\n",
+			isErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseChanges(test.s, test.labels)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
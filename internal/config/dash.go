@@ -19,6 +19,8 @@ import (
 	"github.com/vmware/octant/internal/module"
 	"github.com/vmware/octant/internal/portforward"
 	"github.com/vmware/octant/pkg/plugin"
+
+	"github.com/kubenext/kubeon/internal/terminal"
 )
 
 //go:generate mockgen -destination=./fake/mock_dash.go -package=fake github.com/vmware/octant/internal/config Dash
@@ -83,6 +85,8 @@ type Dash interface {
 
 	PortForwarder() portforward.PortForwarder
 
+	TerminalManager() terminal.Manager
+
 	KubeConfigPath() string
 
 	UseContext(ctx context.Context, contextName string) error
@@ -105,6 +109,7 @@ type Live struct {
 	objectStore        store.Store
 	pluginManager      plugin.ManagerInterface
 	portForwarder      portforward.PortForwarder
+	terminalManager    terminal.Manager
 	kubeConfigPath     string
 	currentContextName string
 	restConfigOptions  cluster.RESTConfigOptions
@@ -122,6 +127,7 @@ func NewLiveConfig(
 	objectStore store.Store,
 	pluginManager plugin.ManagerInterface,
 	portForwarder portforward.PortForwarder,
+	terminalManager terminal.Manager,
 	currentContextName string,
 	restConfigOptions cluster.RESTConfigOptions,
 ) *Live {
@@ -134,6 +140,7 @@ func NewLiveConfig(
 		objectStore:        objectStore,
 		pluginManager:      pluginManager,
 		portForwarder:      portForwarder,
+		terminalManager:    terminalManager,
 		currentContextName: currentContextName,
 		restConfigOptions:  restConfigOptions,
 	}
@@ -184,6 +191,11 @@ func (l *Live) PortForwarder() portforward.PortForwarder {
 	return l.portForwarder
 }
 
+// TerminalManager returns a terminal manager.
+func (l *Live) TerminalManager() terminal.Manager {
+	return l.terminalManager
+}
+
 // UseContext switches context name. This process should have synchronously.
 func (l *Live) UseContext(ctx context.Context, contextName string) error {
 	client, err := cluster.FromKubeConfig(ctx, l.kubeConfigPath, contextName, l.restConfigOptions)
@@ -198,6 +210,8 @@ func (l *Live) UseContext(ctx context.Context, contextName string) error {
 		return err
 	}
 
+	l.terminalManager.UpdateClusterClient(client)
+
 	if err := l.moduleManager.UpdateContext(ctx, contextName); err != nil {
 		return err
 	}
@@ -254,6 +268,10 @@ func (l *Live) Validate() error {
 		return errors.New("port forwarder is nil")
 	}
 
+	if l.terminalManager == nil {
+		return errors.New("terminal manager is nil")
+	}
+
 	return nil
 }
 
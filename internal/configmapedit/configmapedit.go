@@ -0,0 +1,327 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package configmapedit edits the data and binary data of config maps, and
+// finds the workloads which use them.
+package configmapedit
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/magiconair/properties"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// FormatText is the format of values which aren't validated.
+	FormatText = "text"
+	// FormatJSON is the format of JSON values.
+	FormatJSON = "json"
+	// FormatYAML is the format of YAML values.
+	FormatYAML = "yaml"
+	// FormatProperties is the format of Java properties values.
+	FormatProperties = "properties"
+
+	// EncodingText is the encoding of values stored in data.
+	EncodingText = "text"
+	// EncodingBase64 is the encoding of values stored in binaryData.
+	EncodingBase64 = "base64"
+
+	// MaxSize is the largest a config map's data can be.
+	MaxSize = 1024 * 1024
+
+	// DataFieldPrefix prefixes the names of form fields for text keys.
+	// Keys can't contain ":", so the rest of the name is the key.
+	DataFieldPrefix = "data:"
+	// BinaryDataFieldPrefix prefixes the names of form fields for binary keys.
+	BinaryDataFieldPrefix = "binaryData:"
+)
+
+// Detect detects the format of a value. The extension of the key is used if
+// it has one, so "app.properties" is validated as properties even if it
+// looks like YAML. Otherwise JSON objects and arrays are detected by their
+// content; other values are text.
+func Detect(key, value string) string {
+	switch strings.ToLower(path.Ext(key)) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".properties":
+		return FormatProperties
+	}
+
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		if json.Valid([]byte(trimmed)) {
+			return FormatJSON
+		}
+	}
+
+	return FormatText
+}
+
+// Validate returns an error if a value isn't valid for its format.
+func Validate(format, value string) error {
+	switch format {
+	case FormatJSON:
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return errors.Wrap(err, "invalid JSON")
+		}
+	case FormatYAML:
+		decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(value), 4096)
+		for {
+			var v interface{}
+			err := decoder.Decode(&v)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return errors.Wrap(err, "invalid YAML")
+			}
+		}
+	case FormatProperties:
+		if _, err := properties.LoadString(value); err != nil {
+			return errors.Wrap(err, "invalid properties")
+		}
+	}
+
+	return nil
+}
+
+// ValidateKey validates a config map key.
+func ValidateKey(key string) error {
+	if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+		return errors.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// DecodeBinary decodes a base64 binary value. Whitespace is ignored so
+// wrapped values can be pasted.
+func DecodeBinary(value string) ([]byte, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, value)
+
+	data, err := base64.StdEncoding.DecodeString(cleaned)
+	if err != nil {
+		return nil, errors.Wrap(err, "value is not valid base64")
+	}
+	return data, nil
+}
+
+// EncodeBinary encodes a binary value for editing.
+func EncodeBinary(value []byte) string {
+	return base64.StdEncoding.EncodeToString(value)
+}
+
+// Data is the data and binary data of a config map.
+type Data struct {
+	Data       map[string]string
+	BinaryData map[string][]byte
+}
+
+// Edit describes changes to the data of a config map.
+type Edit struct {
+	// Data are the edited values of existing text keys.
+	Data map[string]string
+	// BinaryData are the edited base64 values of existing binary keys.
+	BinaryData map[string]string
+	// Remove are the keys which are removed.
+	Remove []string
+	// NewKey and NewValue add a key. The value is stored as binary data if
+	// NewEncoding is EncodingBase64.
+	NewKey      string
+	NewValue    string
+	NewEncoding string
+}
+
+// Problem is a value which isn't valid for its detected format.
+type Problem struct {
+	Key    string
+	Format string
+	Err    error
+}
+
+func (p Problem) String() string {
+	return p.Key + ": " + p.Err.Error()
+}
+
+// Apply applies an edit to the data of a config map. Errors which make the
+// edit impossible, like invalid keys or base64, are returned as an error.
+// Values which don't match their detected format are returned as problems
+// so they can be saved anyway if that is confirmed.
+func Apply(current Data, edit Edit) (Data, []Problem, error) {
+	result := Data{
+		Data:       map[string]string{},
+		BinaryData: map[string][]byte{},
+	}
+	for key, value := range current.Data {
+		result.Data[key] = value
+	}
+	for key, value := range current.BinaryData {
+		result.BinaryData[key] = value
+	}
+
+	for key, value := range edit.Data {
+		if _, ok := result.Data[key]; !ok {
+			return Data{}, nil, errors.Errorf("key %q does not exist", key)
+		}
+		result.Data[key] = value
+	}
+
+	for key, value := range edit.BinaryData {
+		if _, ok := result.BinaryData[key]; !ok {
+			return Data{}, nil, errors.Errorf("binary key %q does not exist", key)
+		}
+		decoded, err := DecodeBinary(value)
+		if err != nil {
+			return Data{}, nil, errors.Wrapf(err, "key %q", key)
+		}
+		result.BinaryData[key] = decoded
+	}
+
+	for _, key := range edit.Remove {
+		delete(result.Data, key)
+		delete(result.BinaryData, key)
+	}
+
+	if newKey := strings.TrimSpace(edit.NewKey); newKey != "" {
+		if err := ValidateKey(newKey); err != nil {
+			return Data{}, nil, err
+		}
+		_, isText := result.Data[newKey]
+		_, isBinary := result.BinaryData[newKey]
+		if isText || isBinary {
+			return Data{}, nil, errors.Errorf("key %q already exists; edit it instead", newKey)
+		}
+
+		if edit.NewEncoding == EncodingBase64 {
+			decoded, err := DecodeBinary(edit.NewValue)
+			if err != nil {
+				return Data{}, nil, errors.Wrapf(err, "key %q", newKey)
+			}
+			result.BinaryData[newKey] = decoded
+		} else {
+			result.Data[newKey] = edit.NewValue
+		}
+	}
+
+	if size := result.Size(); size > MaxSize {
+		return Data{}, nil, errors.Errorf("config map would be %d bytes, more than the limit of %d", size, MaxSize)
+	}
+
+	var problems []Problem
+	keys, _ := result.Keys()
+	for _, key := range keys {
+		value := result.Data[key]
+		if previous, ok := current.Data[key]; ok && previous == value {
+			// unchanged values are saved even if they were already invalid
+			continue
+		}
+
+		format := Detect(key, value)
+		if err := Validate(format, value); err != nil {
+			problems = append(problems, Problem{Key: key, Format: format, Err: err})
+		}
+	}
+
+	return result, problems, nil
+}
+
+// Keys returns the text keys and binary keys of data, sorted.
+func (d Data) Keys() (text []string, binary []string) {
+	for key := range d.Data {
+		text = append(text, key)
+	}
+	for key := range d.BinaryData {
+		binary = append(binary, key)
+	}
+	sort.Strings(text)
+	sort.Strings(binary)
+	return text, binary
+}
+
+// Size returns the total size of config map data.
+func (d Data) Size() int {
+	size := 0
+	for key, value := range d.Data {
+		size += len(key) + len(value)
+	}
+	for key, value := range d.BinaryData {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+// Changed returns true if data differs from other data.
+func (d Data) Changed(other Data) bool {
+	if len(d.Data) != len(other.Data) || len(d.BinaryData) != len(other.BinaryData) {
+		return true
+	}
+	for key, value := range d.Data {
+		if otherValue, ok := other.Data[key]; !ok || otherValue != value {
+			return true
+		}
+	}
+	for key, value := range d.BinaryData {
+		if otherValue, ok := other.BinaryData[key]; !ok || !bytes.Equal(otherValue, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Patch creates a merge patch from the current data to edited data. The
+// patch only applies to the resource version the edit was made at.
+func Patch(resourceVersion string, current, edited Data) ([]byte, error) {
+	data := map[string]interface{}{}
+	for key := range current.Data {
+		data[key] = nil
+	}
+	for key, value := range edited.Data {
+		data[key] = value
+	}
+
+	binaryData := map[string]interface{}{}
+	for key := range current.BinaryData {
+		binaryData[key] = nil
+	}
+	for key, value := range edited.BinaryData {
+		// byte slices are encoded as base64
+		binaryData[key] = value
+	}
+
+	patch := map[string]interface{}{
+		"data":       data,
+		"binaryData": binaryData,
+	}
+	if resourceVersion != "" {
+		patch["metadata"] = map[string]interface{}{
+			"resourceVersion": resourceVersion,
+		}
+	}
+
+	encoded, err := json.Marshal(patch)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal patch")
+	}
+
+	return encoded, nil
+}
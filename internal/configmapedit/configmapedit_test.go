@@ -0,0 +1,230 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package configmapedit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		key      string
+		value    string
+		expected string
+	}{
+		{key: "app.json", value: "not json", expected: FormatJSON},
+		{key: "app.YAML", value: "a: b", expected: FormatYAML},
+		{key: "app.yml", value: "a: b", expected: FormatYAML},
+		{key: "app.properties", value: "a: b", expected: FormatProperties},
+		{key: "settings", value: ` {"a": 1}`, expected: FormatJSON},
+		{key: "hosts", value: `["a", "b"]`, expected: FormatJSON},
+		{key: "broken", value: `{"a": `, expected: FormatText},
+		{key: "config", value: "a: b", expected: FormatText},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			assert.Equal(t, test.expected, Detect(test.key, test.value))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		value  string
+		isErr  bool
+	}{
+		{name: "json", format: FormatJSON, value: `{"a": [1, 2]}`},
+		{name: "invalid json", format: FormatJSON, value: `{"a": }`, isErr: true},
+		{name: "yaml", format: FormatYAML, value: "a: b\nc:\n  - d\n"},
+		{name: "multiple yaml documents", format: FormatYAML, value: "a: b\n---\nc: d\n"},
+		{name: "invalid yaml", format: FormatYAML, value: "a: [b\n", isErr: true},
+		{name: "properties", format: FormatProperties, value: "a=b\n# comment\nc: d\n"},
+		{name: "invalid properties", format: FormatProperties, value: "a=${a}\n", isErr: true},
+		{name: "text isn't validated", format: FormatText, value: "{"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.format, test.value)
+			if test.isErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	current := Data{
+		Data: map[string]string{
+			"app.json": `{"debug": false}`,
+			"broken":   `{"unterminated": `,
+			"notes":    "hello",
+		},
+		BinaryData: map[string][]byte{
+			"logo": []byte("png"),
+		},
+	}
+
+	tests := []struct {
+		name             string
+		edit             Edit
+		expected         Data
+		expectedProblems []string
+		isErr            bool
+	}{
+		{
+			name: "edit, remove and add keys",
+			edit: Edit{
+				Data:       map[string]string{"notes": "goodbye"},
+				BinaryData: map[string]string{"logo": "Z2lm"},
+				Remove:     []string{"app.json"},
+				NewKey:     " extra ",
+				NewValue:   "value",
+			},
+			expected: Data{
+				Data: map[string]string{
+					"broken": `{"unterminated": `,
+					"notes":  "goodbye",
+					"extra":  "value",
+				},
+				BinaryData: map[string][]byte{"logo": []byte("gif")},
+			},
+		},
+		{
+			name: "add a binary key",
+			edit: Edit{NewKey: "icon", NewValue: "aWNv\nbg==", NewEncoding: EncodingBase64},
+			expected: Data{
+				Data:       current.Data,
+				BinaryData: map[string][]byte{"logo": []byte("png"), "icon": []byte("icon")},
+			},
+		},
+		{
+			name:             "invalid values are problems",
+			edit:             Edit{Data: map[string]string{"app.json": `{"debug": }`}},
+			expectedProblems: []string{"app.json"},
+		},
+		{
+			name: "unchanged invalid values are skipped",
+			edit: Edit{Data: map[string]string{"notes": "changed"}},
+			expected: Data{
+				Data: map[string]string{
+					"app.json": `{"debug": false}`,
+					"broken":   `{"unterminated": `,
+					"notes":    "changed",
+				},
+				BinaryData: current.BinaryData,
+			},
+		},
+		{
+			name:  "missing key",
+			edit:  Edit{Data: map[string]string{"missing": "value"}},
+			isErr: true,
+		},
+		{
+			name:  "missing binary key",
+			edit:  Edit{BinaryData: map[string]string{"notes": "aGk="}},
+			isErr: true,
+		},
+		{
+			name:  "invalid base64",
+			edit:  Edit{BinaryData: map[string]string{"logo": "not base64!"}},
+			isErr: true,
+		},
+		{
+			name:  "invalid new key",
+			edit:  Edit{NewKey: "not/valid", NewValue: "value"},
+			isErr: true,
+		},
+		{
+			name:  "duplicate new key",
+			edit:  Edit{NewKey: "logo", NewValue: "value"},
+			isErr: true,
+		},
+		{
+			name:  "size limit",
+			edit:  Edit{NewKey: "big", NewValue: strings.Repeat("x", MaxSize)},
+			isErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, problems, err := Apply(current, test.edit)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var problemKeys []string
+			for _, problem := range problems {
+				problemKeys = append(problemKeys, problem.Key)
+			}
+			assert.Equal(t, test.expectedProblems, problemKeys)
+
+			if test.expected.Data != nil {
+				assert.Equal(t, test.expected, got)
+			}
+		})
+	}
+
+	// the current data isn't changed
+	assert.Equal(t, "hello", current.Data["notes"])
+	assert.Len(t, current.Data, 3)
+}
+
+func TestData_Changed(t *testing.T) {
+	data := Data{
+		Data:       map[string]string{"a": "1"},
+		BinaryData: map[string][]byte{"b": []byte("2")},
+	}
+
+	assert.False(t, data.Changed(Data{
+		Data:       map[string]string{"a": "1"},
+		BinaryData: map[string][]byte{"b": []byte("2")},
+	}))
+	assert.True(t, data.Changed(Data{
+		Data:       map[string]string{"a": "changed"},
+		BinaryData: map[string][]byte{"b": []byte("2")},
+	}))
+	assert.True(t, data.Changed(Data{
+		Data:       map[string]string{"a": "1"},
+		BinaryData: map[string][]byte{"b": []byte("changed")},
+	}))
+	assert.True(t, data.Changed(Data{Data: map[string]string{"a": "1"}}))
+}
+
+func TestPatch(t *testing.T) {
+	current := Data{
+		Data:       map[string]string{"kept": "1", "removed": "2"},
+		BinaryData: map[string][]byte{"logo": []byte("png")},
+	}
+	edited := Data{
+		Data:       map[string]string{"kept": "changed", "added": "3"},
+		BinaryData: map[string][]byte{},
+	}
+
+	got, err := Patch("42", current, edited)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"data": {"kept": "changed", "added": "3", "removed": null},
+		"binaryData": {"logo": null},
+		"metadata": {"resourceVersion": "42"}
+	}`, string(got))
+
+	got, err = Patch("", Data{}, Data{BinaryData: map[string][]byte{"icon": []byte("gif")}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data": {}, "binaryData": {"icon": "Z2lm"}}`, string(got))
+}
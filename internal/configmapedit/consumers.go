@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package configmapedit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubenext/kubeon/internal/gvk"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// restartable are the kinds of workloads which can be restarted to pick up
+// changes to a config map.
+var restartable = []schema.GroupVersionKind{
+	gvk.Deployment,
+	gvk.StatefulSet,
+	gvk.DaemonSet,
+}
+
+// Consumer is a workload whose pods mount a config map or reference it in
+// their environment.
+type Consumer struct {
+	Key store.Key
+}
+
+func (c Consumer) String() string {
+	return fmt.Sprintf("%s %q", c.Key.Kind, c.Key.Name)
+}
+
+// Value encodes a consumer as a form choice value.
+func (c Consumer) Value() string {
+	return c.Key.Kind + "/" + c.Key.Name
+}
+
+// Consumers returns the deployments, stateful sets and daemon sets in a
+// namespace which use a config map, sorted by kind and name.
+func Consumers(ctx context.Context, objectStore store.Store, namespace, name string) ([]Consumer, error) {
+	var consumers []Consumer
+
+	for _, workloadGVK := range restartable {
+		apiVersion, kind := workloadGVK.ToAPIVersionAndKind()
+		list, _, err := objectStore.List(ctx, store.Key{
+			Namespace:  namespace,
+			ApiVersion: apiVersion,
+			Kind:       kind,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "list %s", kind)
+		}
+
+		for i := range list.Items {
+			spec, err := podSpec(&list.Items[i])
+			if err != nil {
+				return nil, errors.Wrapf(err, "pod template of %s %q", kind, list.Items[i].GetName())
+			}
+
+			if usesConfigMap(spec, name) {
+				consumers = append(consumers, Consumer{Key: store.Key{
+					Namespace:  namespace,
+					ApiVersion: apiVersion,
+					Kind:       kind,
+					Name:       list.Items[i].GetName(),
+				}})
+			}
+		}
+	}
+
+	sort.Slice(consumers, func(i, j int) bool {
+		if consumers[i].Key.Kind != consumers[j].Key.Kind {
+			return consumers[i].Key.Kind < consumers[j].Key.Kind
+		}
+		return consumers[i].Key.Name < consumers[j].Key.Name
+	})
+
+	return consumers, nil
+}
+
+func podSpec(object *unstructured.Unstructured) (corev1.PodSpec, error) {
+	var spec corev1.PodSpec
+
+	m, found, err := unstructured.NestedMap(object.Object, "spec", "template", "spec")
+	if err != nil || !found {
+		return spec, err
+	}
+
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(m, &spec)
+	return spec, err
+}
+
+// usesConfigMap returns true if pods with a spec mount a config map, directly
+// or in a projected volume, or load it into their environment.
+func usesConfigMap(spec corev1.PodSpec, name string) bool {
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil && volume.ConfigMap.Name == name {
+			return true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil && source.ConfigMap.Name == name {
+					return true
+				}
+			}
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, source := range container.EnvFrom {
+			if source.ConfigMapRef != nil && source.ConfigMapRef.Name == name {
+				return true
+			}
+		}
+		for _, envVar := range container.Env {
+			if envVar.ValueFrom != nil && envVar.ValueFrom.ConfigMapKeyRef != nil &&
+				envVar.ValueFrom.ConfigMapKeyRef.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
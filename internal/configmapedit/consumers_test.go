@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package configmapedit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+func TestUsesConfigMap(t *testing.T) {
+	keyRef := &corev1.EnvVarSource{
+		ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+			Key:                  "level",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		spec     corev1.PodSpec
+		expected bool
+	}{
+		{
+			name: "volume",
+			spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+				Name: "config",
+				VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+				}},
+			}}},
+			expected: true,
+		},
+		{
+			name: "projected volume",
+			spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+				Name: "config",
+				VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}},
+						{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}},
+					},
+				}},
+			}}},
+			expected: true,
+		},
+		{
+			name: "envFrom",
+			spec: corev1.PodSpec{Containers: []corev1.Container{{
+				EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+				}}},
+			}}},
+			expected: true,
+		},
+		{
+			name: "key reference",
+			spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Env: []corev1.EnvVar{{Name: "LEVEL", ValueFrom: keyRef}},
+			}}},
+			expected: true,
+		},
+		{
+			name: "init container",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{
+					Env: []corev1.EnvVar{{Name: "LEVEL", ValueFrom: keyRef}},
+				}},
+				Containers: []corev1.Container{{}},
+			},
+			expected: true,
+		},
+		{
+			name: "other config maps and secrets",
+			spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{
+					Name: "config",
+					VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "other"},
+					}},
+				}},
+				Containers: []corev1.Container{{
+					EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+					}}},
+					Env: []corev1.EnvVar{{Name: "PLAIN", Value: "settings"}},
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, usesConfigMap(test.spec, "settings"))
+		})
+	}
+}
+
+// kindStore lists the objects of the kind of a key.
+type kindStore struct {
+	store.Store
+	objects []runtime.Object
+}
+
+func (s *kindStore) List(ctx context.Context, key store.Key) (*unstructured.UnstructuredList, bool, error) {
+	list := &unstructured.UnstructuredList{}
+	for _, object := range s.objects {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err != nil {
+			return nil, false, err
+		}
+		u := unstructured.Unstructured{Object: m}
+		if u.GetKind() == key.Kind && u.GetNamespace() == key.Namespace {
+			list.Items = append(list.Items, u)
+		}
+	}
+	return list, false, nil
+}
+
+func TestConsumers(t *testing.T) {
+	template := func(configMap string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMap},
+			}}},
+		}}}}
+	}
+	deployment := func(name, configMap string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       appsv1.DeploymentSpec{Template: template(configMap)},
+		}
+	}
+
+	objectStore := &kindStore{objects: []runtime.Object{
+		deployment("web", "settings"),
+		deployment("api", "settings"),
+		deployment("worker", "other"),
+		&appsv1.DaemonSet{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "agent"},
+			Spec:       appsv1.DaemonSetSpec{Template: template("settings")},
+		},
+	}}
+
+	consumers, err := Consumers(context.Background(), objectStore, "default", "settings")
+	require.NoError(t, err)
+
+	var got []string
+	for _, consumer := range consumers {
+		got = append(got, consumer.Value())
+	}
+	assert.Equal(t, []string{"DaemonSet/agent", "Deployment/api", "Deployment/web"}, got)
+	assert.Equal(t, `DaemonSet "agent"`, consumers[0].String())
+}
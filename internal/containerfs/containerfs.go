@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package containerfs browses and copies files in containers by running
+// commands over exec, the same way `kubectl cp` does. Containers need `tar`,
+// `find` and `stat`, which busybox provides.
+package containerfs
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/kubenext/kubeon/internal/terminal"
+)
+
+// Container identifies a container in a pod.
+type Container struct {
+	Namespace string
+	PodName   string
+	Name      string
+}
+
+// FS runs file operations in a container.
+type FS struct {
+	kubeClient kubernetes.Interface
+	restConfig *rest.Config
+	container  Container
+}
+
+// New creates an instance of FS.
+func New(kubeClient kubernetes.Interface, restConfig *rest.Config, container Container) (*FS, error) {
+	if kubeClient == nil {
+		return nil, errors.New("kubernetes client is nil")
+	}
+
+	if restConfig == nil {
+		return nil, errors.New("rest config is nil")
+	}
+
+	return &FS{
+		kubeClient: kubeClient,
+		restConfig: restConfig,
+		container:  container,
+	}, nil
+}
+
+// exec runs command with stdin and writes its output to stdout. The command's
+// stderr is returned as the error if it fails.
+func (fs *FS) exec(command []string, stdin io.Reader, stdout io.Writer) error {
+	c := fs.container
+	executor, err := terminal.NewExecutor(fs.kubeClient, fs.restConfig, c.Namespace, c.PodName, c.Name, command, false)
+	if err != nil {
+		return err
+	}
+
+	if stdin == nil {
+		stdin = bytes.NewReader(nil)
+	}
+
+	var stderr bytes.Buffer
+	err = executor.Stream(remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.Errorf("%s: %s", command[0], msg)
+		}
+		return errors.Wrapf(err, "run %s", command[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package containerfs
+
+import (
+	"archive/tar"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var errNoRegularFile = errors.New("not a regular file")
+
+// Archive writes the directory p as a tar archive to w. If p is a symbolic
+// link, the directory it points to is archived. Links within it are archived
+// as links.
+func (fs *FS) Archive(p string, w io.Writer) error {
+	p, err := cleanPath(p)
+	if err != nil {
+		return err
+	}
+
+	command := tarCreateCommand(p, "cf")
+	if p != "/" {
+		// with a trailing slash, a link to a directory is followed
+		command[len(command)-1] += "/"
+	}
+
+	return fs.exec(command, nil, w)
+}
+
+// Download writes the contents of the regular file p to w. If p is a symbolic
+// link, the file it points to is downloaded.
+func (fs *FS) Download(p string, w io.Writer) error {
+	p, err := cleanPath(p)
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	errCh := make(chan error, 1)
+	go func() {
+		err := fs.exec(tarCreateCommand(p, "chf"), nil, pw)
+		_ = pw.CloseWithError(err)
+		errCh <- err
+	}()
+
+	copyErr := copyFirstFile(tar.NewReader(pr), w)
+	if copyErr != nil {
+		// stops the command's output
+		_ = pr.CloseWithError(copyErr)
+	} else {
+		// drain the archive so the command can exit
+		_, _ = io.Copy(ioutil.Discard, pr)
+	}
+
+	execErr := <-errCh
+	if copyErr != nil && copyErr != errNoRegularFile {
+		return copyErr
+	}
+	if execErr != nil {
+		return execErr
+	}
+
+	return copyErr
+}
+
+func copyFirstFile(tr *tar.Reader, w io.Writer) error {
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return errNoRegularFile
+		}
+		if err != nil {
+			return errors.Wrap(err, "read archive")
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		_, err = io.Copy(w, tr)
+		return err
+	}
+}
+
+// File is a file to upload.
+type File struct {
+	Name string
+	Size int64
+	Mode int64
+	Body io.Reader
+}
+
+// Upload writes files to dir. Existing files are overwritten.
+func (fs *FS) Upload(dir string, files []File) error {
+	dir, err := cleanPath(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if err := validateFileName(f.Name); err != nil {
+			return err
+		}
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(writeArchive(pw, files))
+	}()
+
+	err = fs.exec([]string{"tar", "xmf", "-", "-C", dir}, pr, ioutil.Discard)
+	_ = pr.Close()
+	return err
+}
+
+func writeArchive(w io.Writer, files []File) error {
+	tw := tar.NewWriter(w)
+
+	for _, f := range files {
+		mode := f.Mode
+		if mode == 0 {
+			mode = 0644
+		}
+
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     f.Name,
+			Size:     f.Size,
+			Mode:     mode,
+			ModTime:  time.Now(),
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return errors.Wrapf(err, "write header for %s", f.Name)
+		}
+
+		if _, err := io.CopyN(tw, f.Body, f.Size); err != nil {
+			return errors.Wrapf(err, "write %s", f.Name)
+		}
+	}
+
+	return tw.Close()
+}
+
+// tarCreateCommand archives p relative to its parent with tar's flags. The
+// name is prefixed with ./ so names starting with - aren't read as options.
+func tarCreateCommand(p, flags string) []string {
+	dir, name := path.Split(p)
+	if name == "" {
+		// archiving the root directory
+		return []string{"tar", flags, "-", "-C", "/", "."}
+	}
+
+	return []string{"tar", flags, "-", "-C", dir, "./" + name}
+}
+
+func validateFileName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+		return errors.Errorf("invalid file name %q", name)
+	}
+
+	return nil
+}
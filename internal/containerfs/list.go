@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package containerfs
+
+import (
+	"bufio"
+	"bytes"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EntryType is the type of a file system entry.
+type EntryType string
+
+const (
+	// EntryTypeFile is a regular file.
+	EntryTypeFile EntryType = "file"
+	// EntryTypeDirectory is a directory.
+	EntryTypeDirectory EntryType = "directory"
+	// EntryTypeSymlink is a symbolic link.
+	EntryTypeSymlink EntryType = "symlink"
+	// EntryTypeOther is any other type of entry, e.g. a socket.
+	EntryTypeOther EntryType = "other"
+)
+
+// statFormat prints the type, size and modification time before the name
+// since names may contain the separator.
+const statFormat = "%F|%s|%Y|%n"
+
+// Entry is a file system entry.
+type Entry struct {
+	Name    string    `json:"name"`
+	Path    string    `json:"path"`
+	Type    EntryType `json:"type"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+}
+
+// Stat returns the entry for p.
+func (fs *FS) Stat(p string) (Entry, error) {
+	return fs.stat(p, "-c")
+}
+
+// Resolve returns the entry for p. If p is a symbolic link, the type, size
+// and modification time are those of the entry it points to.
+func (fs *FS) Resolve(p string) (Entry, error) {
+	return fs.stat(p, "-Lc")
+}
+
+func (fs *FS) stat(p, flags string) (Entry, error) {
+	p, err := cleanPath(p)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	var out bytes.Buffer
+	if err := fs.exec([]string{"stat", flags, statFormat, p}, nil, &out); err != nil {
+		return Entry{}, err
+	}
+
+	entries, err := parseStat(out.Bytes())
+	if err != nil {
+		return Entry{}, err
+	}
+
+	if len(entries) != 1 {
+		return Entry{}, errors.Errorf("stat %s: unexpected output", p)
+	}
+
+	return entries[0], nil
+}
+
+// List lists the entries in dir. Directories are listed first.
+func (fs *FS) List(dir string) ([]Entry, error) {
+	dir, err := cleanPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	command := []string{"find", dir, "-mindepth", "1", "-maxdepth", "1", "-exec", "stat", "-c", statFormat, "{}", "+"}
+
+	var out bytes.Buffer
+	if err := fs.exec(command, nil, &out); err != nil {
+		return nil, err
+	}
+
+	entries, err := parseStat(out.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if (a.Type == EntryTypeDirectory) != (b.Type == EntryTypeDirectory) {
+			return a.Type == EntryTypeDirectory
+		}
+		return a.Name < b.Name
+	})
+
+	return entries, nil
+}
+
+func parseStat(data []byte) ([]Entry, error) {
+	var entries []Entry
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, "|", 4)
+		if len(parts) != 4 {
+			return nil, errors.Errorf("unexpected stat output %q", line)
+		}
+
+		size, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse size of %s", parts[3])
+		}
+
+		modTime, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse modification time of %s", parts[3])
+		}
+
+		entries = append(entries, Entry{
+			Name:    path.Base(parts[3]),
+			Path:    parts[3],
+			Type:    entryType(parts[0]),
+			Size:    size,
+			ModTime: time.Unix(modTime, 0).UTC(),
+		})
+	}
+
+	return entries, scanner.Err()
+}
+
+func entryType(s string) EntryType {
+	switch {
+	case strings.Contains(s, "directory"):
+		return EntryTypeDirectory
+	case strings.Contains(s, "symbolic link"):
+		return EntryTypeSymlink
+	case strings.Contains(s, "regular"):
+		return EntryTypeFile
+	default:
+		return EntryTypeOther
+	}
+}
+
+// cleanPath cleans an absolute path.
+func cleanPath(p string) (string, error) {
+	if !path.IsAbs(p) {
+		return "", errors.Errorf("path %q is not absolute", p)
+	}
+
+	return path.Clean(p), nil
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubenext/kubeon/internal/bulk"
+	"github.com/kubenext/kubeon/internal/cluster"
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/pkg/action"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// BulkOperatorConfig is configuration for BulkOperator.
+type BulkOperatorConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// BulkOperator runs an operation for the selected rows of a table and sends
+// one alert with the result for each object.
+type BulkOperator struct {
+	config BulkOperatorConfig
+}
+
+var _ action.Dispatcher = (*BulkOperator)(nil)
+
+// NewBulkOperator creates an instance of BulkOperator.
+func NewBulkOperator(config BulkOperatorConfig) *BulkOperator {
+	return &BulkOperator{
+		config: config,
+	}
+}
+
+// ActionName returns name of this action.
+func (b *BulkOperator) ActionName() string {
+	return "overview/bulk"
+}
+
+// Handle runs the operation in the payload for each selected object.
+func (b *BulkOperator) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", b.ActionName())
+	logger.With("payload", payload).Debugf("received action payload")
+
+	operation, err := payload.String("operation")
+	if err != nil {
+		return err
+	}
+
+	rowKeys, err := payload.StringSlice("selected")
+	if err != nil {
+		return err
+	}
+
+	var changes map[string]interface{}
+	if operation == bulk.OperationLabel || operation == bulk.OperationAnnotate {
+		s, err := payload.String("changes")
+		if err != nil {
+			return err
+		}
+
+		changes, err = bulk.ParseChanges(s, operation == bulk.OperationLabel)
+		if err != nil {
+			alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+				fmt.Sprintf("Unable to %s selected objects: %s", operation, err), action.DefaultAlertExpiration))
+			return nil
+		}
+	}
+
+	var results []bulk.Result
+	for _, rowKey := range rowKeys {
+		key, err := store.KeyFromRowKey(rowKey)
+		if err == nil {
+			err = b.apply(ctx, key, operation, changes)
+		}
+		if err != nil {
+			logger.WithErr(err).With("key", key).Errorf("bulk %s", operation)
+		}
+
+		results = append(results, bulk.Result{Key: key, Err: err})
+	}
+
+	message, failed := bulk.Summarize(operation, results)
+
+	alertType := action.AlertTypeInfo
+	if failed > 0 {
+		alertType = action.AlertTypeWarning
+	}
+
+	alerter.SendAlert(action.CreateAlert(alertType, message, action.DefaultAlertExpiration))
+	return nil
+}
+
+func (b *BulkOperator) apply(ctx context.Context, key store.Key, operation string, changes map[string]interface{}) error {
+	verb := "patch"
+	if operation == bulk.OperationDelete {
+		verb = "delete"
+	}
+
+	if err := store.HasSubresourceAccess(ctx, b.config.ObjectStore(), key, "", verb); err != nil {
+		return err
+	}
+
+	client, err := resourceClient(b.config.ClusterClient(), key)
+	if err != nil {
+		return err
+	}
+
+	return bulk.Apply(client, key, operation, changes)
+}
+
+// resourceClient returns a dynamic client for the resource of a key.
+func resourceClient(clusterClient cluster.ClientInterface, key store.Key) (dynamic.ResourceInterface, error) {
+	gvr, err := clusterClient.Resource(key.GroupVersionKind().GroupKind())
+	if err != nil {
+		return nil, errors.Wrap(err, "find resource")
+	}
+
+	dynamicClient, err := clusterClient.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if key.Namespace == "" {
+		return dynamicClient.Resource(gvr), nil
+	}
+	return dynamicClient.Resource(gvr).Namespace(key.Namespace), nil
+}
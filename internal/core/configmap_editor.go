@@ -0,0 +1,252 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubenext/kubeon/internal/cluster"
+	"github.com/kubenext/kubeon/internal/configmapedit"
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/internal/rollout"
+	"github.com/kubenext/kubeon/pkg/action"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// ConfigMapEditorConfig is configuration for ConfigMapEditor.
+type ConfigMapEditorConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// ConfigMapEditor edits the data and binary data of config maps. Values
+// which aren't valid JSON, YAML or properties are only saved if that is
+// confirmed. Workloads which use the config map can be restarted after it
+// is saved.
+type ConfigMapEditor struct {
+	config ConfigMapEditorConfig
+}
+
+var _ action.Dispatcher = (*ConfigMapEditor)(nil)
+
+// NewConfigMapEditor creates an instance of ConfigMapEditor.
+func NewConfigMapEditor(config ConfigMapEditorConfig) *ConfigMapEditor {
+	return &ConfigMapEditor{
+		config: config,
+	}
+}
+
+// ActionName returns name of this action.
+func (c *ConfigMapEditor) ActionName() string {
+	return "overview/editConfigMap"
+}
+
+// Handle saves the config map data in the payload and restarts the selected workloads.
+func (c *ConfigMapEditor) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", c.ActionName())
+	logger.With("payload", payload).Debugf("received action payload")
+
+	key, err := store.KeyFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	warn := func(err error) error {
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+			fmt.Sprintf("Unable to update config map %q: %s", key.Name, err), action.DefaultAlertExpiration))
+		return nil
+	}
+
+	edit, err := configMapEditFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	resourceVersion, err := payload.OptionalString("resourceVersion")
+	if err != nil {
+		return err
+	}
+	restart, err := payload.OptionalStringSlice("restart")
+	if err != nil {
+		return err
+	}
+	ignoreWarnings, err := payload.OptionalStringSlice("ignoreWarnings")
+	if err != nil {
+		return err
+	}
+
+	if key.Kind != "ConfigMap" {
+		return warn(errors.Errorf("%s is not a config map", key.Kind))
+	}
+
+	if err := store.HasSubresourceAccess(ctx, c.config.ObjectStore(), key, "", "patch"); err != nil {
+		return warn(err)
+	}
+
+	kubeClient, err := c.config.ClusterClient().KubernetesClient()
+	if err != nil {
+		return warn(errors.Wrap(err, "create kubernetes client"))
+	}
+
+	configMaps := kubeClient.CoreV1().ConfigMaps(key.Namespace)
+
+	configMap, err := configMaps.Get(key.Name, metav1.GetOptions{})
+	if err != nil {
+		return warn(err)
+	}
+
+	current := configmapedit.Data{Data: configMap.Data, BinaryData: configMap.BinaryData}
+	edited, problems, err := configmapedit.Apply(current, edit)
+	if err != nil {
+		return warn(err)
+	}
+
+	if len(problems) > 0 && len(ignoreWarnings) == 0 {
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+			fmt.Sprintf("Config map %q was not saved because %s. Confirm to save anyway.",
+				key.Name, describeProblems(problems)), action.DefaultAlertExpiration))
+		return nil
+	}
+
+	var messages []string
+
+	if edited.Changed(current) {
+		patch, err := configmapedit.Patch(resourceVersion, current, edited)
+		if err != nil {
+			return err
+		}
+
+		if _, err := configMaps.Patch(key.Name, types.MergePatchType, patch); err != nil {
+			if kerrors.IsConflict(err) {
+				err = errors.New("it was changed by someone else; reload it and edit again")
+			}
+			return warn(err)
+		}
+		messages = append(messages, fmt.Sprintf("Updated config map %q", key.Name))
+	} else {
+		messages = append(messages, fmt.Sprintf("Config map %q is unchanged", key.Name))
+	}
+
+	consumers, err := configmapedit.Consumers(ctx, c.config.ObjectStore(), key.Namespace, key.Name)
+	if err != nil {
+		logger.WithErr(err).Errorf("finding workloads using config map")
+	}
+
+	selected := make(map[string]bool)
+	for _, value := range restart {
+		selected[value] = true
+	}
+
+	alertType := action.AlertTypeInfo
+	var restarted, failed, notRestarted []string
+	for _, consumer := range consumers {
+		if !selected[consumer.Value()] {
+			notRestarted = append(notRestarted, consumer.String())
+			continue
+		}
+
+		if err := c.restart(ctx, consumer.Key); err != nil {
+			logger.WithErr(err).With("workload", consumer.String()).Errorf("restarting workload")
+			failed = append(failed, fmt.Sprintf("%s (%s)", consumer, err))
+			continue
+		}
+		restarted = append(restarted, consumer.String())
+	}
+
+	if len(restarted) > 0 {
+		messages = append(messages, fmt.Sprintf("Restarted %s", strings.Join(restarted, ", ")))
+	}
+	if len(failed) > 0 {
+		alertType = action.AlertTypeWarning
+		messages = append(messages, fmt.Sprintf("Unable to restart %s", strings.Join(failed, ", ")))
+	}
+	if len(notRestarted) > 0 && edited.Changed(current) {
+		messages = append(messages, fmt.Sprintf("%s may use the old values until restarted", strings.Join(notRestarted, ", ")))
+	}
+
+	alerter.SendAlert(action.CreateAlert(alertType, strings.Join(messages, ". ")+".", action.DefaultAlertExpiration))
+	return nil
+}
+
+func (c *ConfigMapEditor) restart(ctx context.Context, key store.Key) error {
+	if err := store.HasSubresourceAccess(ctx, c.config.ObjectStore(), key, "", "patch"); err != nil {
+		return err
+	}
+
+	client, err := resourceClient(c.config.ClusterClient(), key)
+	if err != nil {
+		return err
+	}
+
+	object, err := client.Get(key.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	return rollout.Restart(client, object, time.Now())
+}
+
+// configMapEditFromPayload reads the edited values, which are in fields
+// named with the key after a prefix, the removed keys and a new key.
+func configMapEditFromPayload(payload action.Payload) (configmapedit.Edit, error) {
+	edit := configmapedit.Edit{
+		Data:       map[string]string{},
+		BinaryData: map[string]string{},
+	}
+
+	for name := range payload {
+		var values map[string]string
+		var key string
+		switch {
+		case strings.HasPrefix(name, configmapedit.DataFieldPrefix):
+			values, key = edit.Data, strings.TrimPrefix(name, configmapedit.DataFieldPrefix)
+		case strings.HasPrefix(name, configmapedit.BinaryDataFieldPrefix):
+			values, key = edit.BinaryData, strings.TrimPrefix(name, configmapedit.BinaryDataFieldPrefix)
+		default:
+			continue
+		}
+
+		value, err := payload.String(name)
+		if err != nil {
+			return edit, err
+		}
+		values[key] = value
+	}
+
+	var err error
+	if edit.Remove, err = payload.OptionalStringSlice("remove"); err != nil {
+		return edit, err
+	}
+	if edit.NewKey, err = payload.OptionalString("newKey"); err != nil {
+		return edit, err
+	}
+	if edit.NewValue, err = payload.OptionalString("newValue"); err != nil {
+		return edit, err
+	}
+	if edit.NewEncoding, err = payload.OptionalString("newEncoding"); err != nil {
+		return edit, err
+	}
+
+	return edit, nil
+}
+
+func describeProblems(problems []configmapedit.Problem) string {
+	var descriptions []string
+	for _, problem := range problems {
+		descriptions = append(descriptions, problem.String())
+	}
+	sort.Strings(descriptions)
+	return strings.Join(descriptions, "; ")
+}
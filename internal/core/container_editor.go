@@ -9,11 +9,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/kubenext/kubeon/internal/envedit"
 	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/internal/resourceedit"
 	"github.com/kubenext/kubeon/pkg/action"
 	"github.com/kubenext/kubeon/pkg/store"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"strings"
 )
 
 // ContainerEditor edits containers.
@@ -39,9 +44,12 @@ func (e *ContainerEditor) ActionName() string {
 
 // Handle edits a container. Supported edits:
 //   * image
+//   * env: environment variables and environment sources
+//   * resources: CPU and memory requests and limits
 func (e *ContainerEditor) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
 	logger := log.From(ctx).With("actionName", e.ActionName())
-	logger.With("payload", payload).Infof("received action payload")
+	// the payload isn't logged because environment variables can hold secret values
+	logger.Debugf("received action payload")
 
 	key, err := store.KeyFromPayload(payload)
 	if err != nil {
@@ -63,15 +71,102 @@ func (e *ContainerEditor) Handle(ctx context.Context, alerter action.Alerter, pa
 		return err
 	}
 
-	containerImage, err := payload.String("containerImage")
+	edit, err := payload.OptionalString("edit")
 	if err != nil {
 		return err
 	}
 
-	fn := updateContainer(containersPath, logger, containerName, containerImage)
+	var fn func(object *unstructured.Unstructured) error
+	var details string
+	var warnings []string
+
+	switch edit {
+	case "", "image":
+		containerImage, err := payload.String("containerImage")
+		if err != nil {
+			return err
+		}
+
+		fn = updateContainer(containersPath, logger, containerName, containerImage)
+	case "env":
+		envEdit, err := envEditFromPayload(payload)
+		if err != nil {
+			alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+				fmt.Sprintf("Unable to update container %q: %s", containerName, err), action.DefaultAlertExpiration))
+			return nil
+		}
+
+		fn = editContainer(containersPath, containerName, []string{"env", "envFrom"}, func(container *corev1.Container) error {
+			envedit.Apply(container, envEdit)
+			return nil
+		})
+	case "resources":
+		values, err := resourceValuesFromPayload(payload)
+		if err != nil {
+			return err
+		}
+
+		requirements, err := resourceedit.Parse(values)
+		if err != nil {
+			alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+				fmt.Sprintf("Unable to update container %q: %s", containerName, err), action.DefaultAlertExpiration))
+			return nil
+		}
+
+		object, found, err := e.store.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.Errorf("%s was not found", key)
+		}
+
+		review, err := resourceedit.ReviewChange(ctx, e.store, object, containersPath, containerName, requirements)
+		if err != nil {
+			alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+				fmt.Sprintf("Unable to update container %q: %s", containerName, err), action.DefaultAlertExpiration))
+			return nil
+		}
+
+		confirmed, err := payload.OptionalStringSlice("ignoreWarnings")
+		if err != nil {
+			return err
+		}
+
+		if len(review.Warnings) > 0 && len(confirmed) == 0 {
+			alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+				fmt.Sprintf("Container %q was not updated: %s. Confirm to apply anyway.",
+					containerName, strings.Join(review.Warnings, "; ")), action.DefaultAlertExpiration))
+			return nil
+		}
+
+		warnings = review.Warnings
+		details = fmt.Sprintf("Totals across %d replicas: %s (was %s)",
+			review.Replicas, resourceedit.Describe(review.After), resourceedit.Describe(review.Before))
+
+		edit := editContainer(containersPath, containerName, []string{"resources"}, func(container *corev1.Container) error {
+			resourceedit.Apply(container, requirements)
+			return nil
+		})
+		fn = func(object *unstructured.Unstructured) error {
+			if err := edit(object); err != nil {
+				return err
+			}
+			return resourceedit.SetPreviousTotals(object, review.Before)
+		}
+	default:
+		return errors.Errorf("unknown container edit %q", edit)
+	}
 
 	message := fmt.Sprintf("Container %q was updated", containerName)
 	alertType := action.AlertTypeInfo
+	if details != "" {
+		message = fmt.Sprintf("%s. %s", message, details)
+	}
+	if len(warnings) > 0 {
+		alertType = action.AlertTypeWarning
+		message = fmt.Sprintf("%s. Warnings: %s", message, strings.Join(warnings, "; "))
+	}
 	if err := e.store.Update(ctx, key, fn); err != nil {
 		message = fmt.Sprintf("Unable to update container %q: %s", containerName, err)
 		alertType = action.AlertTypeWarning
@@ -118,3 +213,152 @@ func updateContainer(containersPath []string, logger log.Logger, containerName s
 		return unstructured.SetNestedSlice(object.Object, updatedContainers, containersPath...)
 	}
 }
+
+// editContainer returns a function which updates fields of a container with
+// fn. Only the listed fields are converted and set, so fields which aren't
+// known to this version of the API types are kept.
+func editContainer(containersPath []string, containerName string, fields []string, fn func(container *corev1.Container) error) func(object *unstructured.Unstructured) error {
+	return func(object *unstructured.Unstructured) error {
+		containersRaw, found, err := unstructured.NestedSlice(object.Object, containersPath...)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return errors.Errorf("unable to find containers within object")
+		}
+
+		updated := false
+		for _, containerRaw := range containersRaw {
+			m, ok := containerRaw.(map[string]interface{})
+			if !ok {
+				return errors.New("unable to parse containers format")
+			}
+
+			if name, _, _ := unstructured.NestedString(m, "name"); name != containerName {
+				continue
+			}
+
+			edited := make(map[string]interface{})
+			for _, field := range fields {
+				if value, ok := m[field]; ok {
+					edited[field] = value
+				}
+			}
+
+			var container corev1.Container
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(edited, &container); err != nil {
+				return errors.Wrapf(err, "convert container %q", containerName)
+			}
+
+			if err := fn(&container); err != nil {
+				return err
+			}
+
+			edited, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&container)
+			if err != nil {
+				return errors.Wrapf(err, "convert container %q", containerName)
+			}
+
+			for _, field := range fields {
+				if value, ok := edited[field]; ok {
+					m[field] = value
+				} else {
+					delete(m, field)
+				}
+			}
+
+			updated = true
+		}
+
+		if !updated {
+			return errors.Errorf("container %q was not found", containerName)
+		}
+
+		return unstructured.SetNestedSlice(object.Object, containersRaw, containersPath...)
+	}
+}
+
+func envEditFromPayload(payload action.Payload) (envedit.Edit, error) {
+	var edit envedit.Edit
+
+	env, err := payload.OptionalString("env")
+	if err != nil {
+		return edit, err
+	}
+
+	edit.Variables, err = envedit.ParseVariables(env)
+	if err != nil {
+		return edit, err
+	}
+
+	edit.Keep, err = payload.OptionalStringSlice("keepReferences")
+	if err != nil {
+		return edit, err
+	}
+
+	referenceName, err := payload.OptionalString("referenceName")
+	if err != nil {
+		return edit, err
+	}
+
+	referenceKeys, err := payload.OptionalStringSlice("referenceKey")
+	if err != nil {
+		return edit, err
+	}
+
+	switch {
+	case referenceName != "" && len(referenceKeys) == 1:
+		reference, err := envedit.ParseReference(referenceKeys[0])
+		if err != nil {
+			return edit, err
+		}
+
+		envVar, err := envedit.NewReference(referenceName, reference)
+		if err != nil {
+			return edit, err
+		}
+		edit.Reference = &envVar
+	case referenceName != "":
+		return edit, errors.Errorf("choose a key for %q", referenceName)
+	}
+
+	sources, err := payload.OptionalStringSlice("envFrom")
+	if err != nil {
+		return edit, err
+	}
+
+	for _, value := range sources {
+		reference, err := envedit.ParseReference(value)
+		if err != nil {
+			return edit, err
+		}
+		edit.Sources = append(edit.Sources, reference)
+	}
+
+	return edit, nil
+}
+
+func resourceValuesFromPayload(payload action.Payload) (resourceedit.Values, error) {
+	values := resourceedit.Values{
+		Requests: map[corev1.ResourceName]string{},
+		Limits:   map[corev1.ResourceName]string{},
+	}
+
+	// blank values unset the request or limit
+	for _, name := range resourceedit.Edited {
+		request, err := payload.OptionalString("requests." + string(name))
+		if err != nil {
+			return values, err
+		}
+		values.Requests[name] = request
+
+		limit, err := payload.OptionalString("limits." + string(name))
+		if err != nil {
+			return values, err
+		}
+		values.Limits[name] = limit
+	}
+
+	return values, nil
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubenext/kubeon/internal/cluster"
+	"github.com/kubenext/kubeon/internal/cronjob"
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/pkg/action"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// CronJobOperatorConfig is configuration for CronJobOperator.
+type CronJobOperatorConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// CronJobOperator runs cron jobs now, and suspends and resumes them.
+type CronJobOperator struct {
+	config CronJobOperatorConfig
+}
+
+var _ action.Dispatcher = (*CronJobOperator)(nil)
+
+// NewCronJobOperator creates an instance of CronJobOperator.
+func NewCronJobOperator(config CronJobOperatorConfig) *CronJobOperator {
+	return &CronJobOperator{
+		config: config,
+	}
+}
+
+// ActionName returns name of this action.
+func (c *CronJobOperator) ActionName() string {
+	return "overview/cronJob"
+}
+
+// Handle runs the cron job operation in the payload.
+func (c *CronJobOperator) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", c.ActionName())
+	logger.With("payload", payload).Debugf("received action payload")
+
+	key, err := store.KeyFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	operation, err := payload.String("operation")
+	if err != nil {
+		return err
+	}
+
+	message, err := c.run(ctx, key, operation)
+	if err != nil {
+		logger.WithErr(err).Errorf("cron job %s", operation)
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+			fmt.Sprintf("Unable to %s cron job %q: %s", operation, key.Name, err), action.DefaultAlertExpiration))
+		return nil
+	}
+
+	alerter.SendAlert(action.CreateAlert(action.AlertTypeInfo, message, action.DefaultAlertExpiration))
+	return nil
+}
+
+func (c *CronJobOperator) run(ctx context.Context, key store.Key, operation string) (string, error) {
+	if key.Kind != "CronJob" {
+		return "", errors.Errorf("%s is not a cron job", key.Kind)
+	}
+
+	kubeClient, err := c.config.ClusterClient().KubernetesClient()
+	if err != nil {
+		return "", errors.Wrap(err, "create kubernetes client")
+	}
+
+	switch operation {
+	case cronjob.OperationRun:
+		jobKey := store.Key{Namespace: key.Namespace, ApiVersion: "batch/v1", Kind: "Job"}
+		if err := store.HasSubresourceAccess(ctx, c.config.ObjectStore(), jobKey, "", "create"); err != nil {
+			return "", err
+		}
+
+		cronJob, err := kubeClient.BatchV1beta1().CronJobs(key.Namespace).Get(key.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+
+		job, err := cronjob.Run(kubeClient, cronJob)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("Created job %q from cron job %q", job.Name, key.Name), nil
+	case cronjob.OperationSuspend, cronjob.OperationResume:
+		if err := store.HasSubresourceAccess(ctx, c.config.ObjectStore(), key, "", "patch"); err != nil {
+			return "", err
+		}
+
+		suspend := operation == cronjob.OperationSuspend
+		if err := cronjob.Suspend(kubeClient, key.Namespace, key.Name, suspend); err != nil {
+			return "", err
+		}
+
+		if suspend {
+			return fmt.Sprintf("Suspended cron job %q", key.Name), nil
+		}
+		return fmt.Sprintf("Resumed cron job %q", key.Name), nil
+	default:
+		return "", errors.Errorf("unknown operation %q", operation)
+	}
+}
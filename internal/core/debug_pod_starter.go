@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubenext/kubeon/internal/cluster"
+	"github.com/kubenext/kubeon/internal/debugpod"
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/internal/terminal"
+	"github.com/kubenext/kubeon/pkg/action"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// DebugPodConfig is configuration for DebugPodStarter.
+type DebugPodConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+	TerminalManager() terminal.Manager
+}
+
+// DebugPodStarter starts debug pods for pods and nodes and attaches a terminal
+// to them. Debug pods are deleted when their terminal exits.
+type DebugPodStarter struct {
+	config DebugPodConfig
+}
+
+var _ action.Dispatcher = (*DebugPodStarter)(nil)
+
+// NewDebugPodStarter creates an instance of DebugPodStarter.
+func NewDebugPodStarter(config DebugPodConfig) *DebugPodStarter {
+	return &DebugPodStarter{
+		config: config,
+	}
+}
+
+// ActionName returns name of this action.
+func (s *DebugPodStarter) ActionName() string {
+	return "overview/startDebugPod"
+}
+
+// Handle starts a debug pod. Pods are debugged with a pod on the same node
+// which shares its network settings. Nodes are debugged with a privileged pod
+// in the namespace given by debugNamespace.
+func (s *DebugPodStarter) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", s.ActionName())
+	logger.With("payload", payload).Debugf("received action payload")
+
+	key, err := store.KeyFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	image, err := payload.OptionalString("image")
+	if err != nil {
+		return err
+	}
+
+	pod, target, err := s.debugPod(ctx, key, image, payload)
+	if err != nil {
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+			fmt.Sprintf("Unable to debug %s %q: %s", key.Kind, key.Name, err), action.DefaultAlertExpiration))
+		return nil
+	}
+
+	alerter.SendAlert(action.CreateAlert(action.AlertTypeInfo,
+		fmt.Sprintf("Starting debug pod for %s %q", key.Kind, key.Name), action.DefaultAlertExpiration))
+
+	// debug pods can take a while to start, so the rest happens in the background
+	go func() {
+		ctx := log.WithLoggerContext(context.Background(), logger)
+
+		message := fmt.Sprintf("Debug pod for %s %q is running", key.Kind, key.Name)
+		alertType := action.AlertTypeInfo
+		if err := s.start(ctx, pod, target); err != nil {
+			message = fmt.Sprintf("Unable to start debug pod for %s %q: %s", key.Kind, key.Name, err)
+			alertType = action.AlertTypeWarning
+			logger.WithErr(err).Errorf("start debug pod")
+		}
+
+		alerter.SendAlert(action.CreateAlert(alertType, message, action.DefaultAlertExpiration))
+	}()
+
+	return nil
+}
+
+func (s *DebugPodStarter) debugPod(ctx context.Context, key store.Key, image string, payload action.Payload) (*corev1.Pod, terminal.Target, error) {
+	target := terminal.Target{Kind: key.Kind, Namespace: key.Namespace, Name: key.Name}
+
+	var pod *corev1.Pod
+	switch key.Kind {
+	case "Pod":
+		object, found, err := s.config.ObjectStore().Get(ctx, key)
+		if err != nil {
+			return nil, target, err
+		}
+		if !found {
+			return nil, target, errors.Errorf("pod not found")
+		}
+
+		targetPod := &corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, targetPod); err != nil {
+			return nil, target, errors.Wrap(err, "convert pod")
+		}
+
+		pod = debugpod.ForPod(targetPod, image)
+	case "Node":
+		namespace, err := payload.OptionalString("debugNamespace")
+		if err != nil {
+			return nil, target, err
+		}
+		if namespace == "" {
+			namespace = "default"
+		}
+
+		pod = debugpod.ForNode(namespace, key.Name, image)
+	default:
+		return nil, target, errors.Errorf("%s can't be debugged", key.Kind)
+	}
+
+	objectStore := s.config.ObjectStore()
+	podKey := store.Key{Namespace: pod.Namespace, ApiVersion: "v1", Kind: "Pod"}
+	if err := store.HasSubresourceAccess(ctx, objectStore, podKey, "", "create"); err != nil {
+		return nil, target, err
+	}
+	if err := store.HasSubresourceAccess(ctx, objectStore, podKey, "exec", "create"); err != nil {
+		return nil, target, err
+	}
+
+	return pod, target, nil
+}
+
+func (s *DebugPodStarter) start(ctx context.Context, pod *corev1.Pod, target terminal.Target) error {
+	kubeClient, err := s.config.ClusterClient().KubernetesClient()
+	if err != nil {
+		return errors.Wrap(err, "create kubernetes client")
+	}
+
+	running, err := debugpod.Run(ctx, kubeClient, pod)
+	if err != nil {
+		return err
+	}
+
+	logger := log.From(ctx).With("debugPod", running.Name)
+
+	deletePod := func() {
+		if err := debugpod.Delete(kubeClient, running.Namespace, running.Name); err != nil {
+			logger.WithErr(err).Errorf("delete debug pod")
+		}
+	}
+
+	_, err = s.config.TerminalManager().Create(ctx, running.Namespace, running.Name, debugpod.ContainerName, nil,
+		terminal.WithTarget(target),
+		terminal.WithOnExit(deletePod))
+	if err != nil {
+		// onExit is only called for terminals which started
+		deletePod()
+		return errors.Wrap(err, "start terminal")
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubenext/kubeon/internal/cluster"
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/internal/metadataedit"
+	"github.com/kubenext/kubeon/pkg/action"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// MetadataEditorConfig is configuration for MetadataEditor.
+type MetadataEditorConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// MetadataEditor edits the labels and annotations of an object. Label changes
+// which would take a pod out of a Service or controller selector are only
+// applied if they are confirmed.
+type MetadataEditor struct {
+	config MetadataEditorConfig
+}
+
+var _ action.Dispatcher = (*MetadataEditor)(nil)
+
+// NewMetadataEditor creates an instance of MetadataEditor.
+func NewMetadataEditor(config MetadataEditorConfig) *MetadataEditor {
+	return &MetadataEditor{
+		config: config,
+	}
+}
+
+// ActionName returns name of this action.
+func (m *MetadataEditor) ActionName() string {
+	return "overview/editMetadata"
+}
+
+// Handle updates the labels and annotations of an object to the ones in the payload.
+func (m *MetadataEditor) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", m.ActionName())
+	logger.With("payload", payload).Debugf("received action payload")
+
+	key, err := store.KeyFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	warn := func(err error) error {
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+			fmt.Sprintf("Unable to update %s %q: %s", key.Kind, key.Name, err), action.DefaultAlertExpiration))
+		return nil
+	}
+
+	labelsText, err := payload.OptionalString("labels")
+	if err != nil {
+		return err
+	}
+	annotationsText, err := payload.OptionalString("annotations")
+	if err != nil {
+		return err
+	}
+	resourceVersion, err := payload.OptionalString("resourceVersion")
+	if err != nil {
+		return err
+	}
+
+	labels, err := metadataedit.Parse(labelsText, true)
+	if err != nil {
+		return warn(errors.Wrap(err, "labels"))
+	}
+	annotations, err := metadataedit.Parse(annotationsText, false)
+	if err != nil {
+		return warn(errors.Wrap(err, "annotations"))
+	}
+
+	if err := store.HasSubresourceAccess(ctx, m.config.ObjectStore(), key, "", "patch"); err != nil {
+		return warn(err)
+	}
+
+	client, err := resourceClient(m.config.ClusterClient(), key)
+	if err != nil {
+		return warn(err)
+	}
+
+	object, err := client.Get(key.Name, metav1.GetOptions{})
+	if err != nil {
+		return warn(err)
+	}
+
+	labelChanges := metadataedit.Changes(object.GetLabels(), labels)
+	annotationChanges := metadataedit.Changes(object.GetAnnotations(), annotations)
+	if len(labelChanges) == 0 && len(annotationChanges) == 0 {
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeInfo,
+			fmt.Sprintf("Labels and annotations of %s %q are unchanged", key.Kind, key.Name), action.DefaultAlertExpiration))
+		return nil
+	}
+
+	var lost []metadataedit.Selector
+	if len(labelChanges) > 0 {
+		lost, err = metadataedit.LostSelectors(ctx, m.config.ObjectStore(), key, object.GetLabels(), labels)
+		if err != nil {
+			logger.WithErr(err).Errorf("finding selectors")
+		}
+	}
+
+	confirmed := false
+	// unchecked options aren't sent
+	if _, ok := payload["ignoreSelectors"]; ok {
+		values, err := payload.StringSlice("ignoreSelectors")
+		if err != nil {
+			return err
+		}
+		confirmed = len(values) > 0
+	}
+
+	if len(lost) > 0 && !confirmed {
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+			fmt.Sprintf("%s %q was not changed because it would no longer be selected by %s. Confirm to apply anyway.",
+				key.Kind, key.Name, describeSelectors(lost)), action.DefaultAlertExpiration))
+		return nil
+	}
+
+	patch, err := metadataedit.Patch(resourceVersion, labelChanges, annotationChanges)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Patch(key.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		if kerrors.IsConflict(err) {
+			err = errors.New("it was changed by someone else; reload it and edit again")
+		}
+		return warn(err)
+	}
+
+	alertType := action.AlertTypeInfo
+	message := fmt.Sprintf("Updated labels and annotations of %s %q", key.Kind, key.Name)
+	if len(lost) > 0 {
+		alertType = action.AlertTypeWarning
+		message = fmt.Sprintf("%s. It is no longer selected by %s.", message, describeSelectors(lost))
+	}
+
+	alerter.SendAlert(action.CreateAlert(alertType, message, action.DefaultAlertExpiration))
+	return nil
+}
+
+func describeSelectors(selectors []metadataedit.Selector) string {
+	var descriptions []string
+	for _, selector := range selectors {
+		descriptions = append(descriptions, selector.String())
+	}
+	return strings.Join(descriptions, ", ")
+}
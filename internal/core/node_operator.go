@@ -0,0 +1,212 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubenext/kubeon/internal/cluster"
+	"github.com/kubenext/kubeon/internal/drain"
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/pkg/action"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// NodeOperatorConfig is configuration for NodeOperator.
+type NodeOperatorConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// NodeOperator cordons, uncordons and drains nodes. Drain progress and
+// blocked evictions are sent as alerts.
+type NodeOperator struct {
+	config NodeOperatorConfig
+}
+
+var _ action.Dispatcher = (*NodeOperator)(nil)
+
+// NewNodeOperator creates an instance of NodeOperator.
+func NewNodeOperator(config NodeOperatorConfig) *NodeOperator {
+	return &NodeOperator{
+		config: config,
+	}
+}
+
+// ActionName returns name of this action.
+func (n *NodeOperator) ActionName() string {
+	return "overview/nodeMaintenance"
+}
+
+// Handle runs the node operation in the payload.
+func (n *NodeOperator) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", n.ActionName())
+	logger.With("payload", payload).Debugf("received action payload")
+
+	key, err := store.KeyFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	operation, err := payload.String("operation")
+	if err != nil {
+		return err
+	}
+
+	if err := n.checkAccess(ctx, key); err != nil {
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+			fmt.Sprintf("Unable to %s node %q: %s", operation, key.Name, err), action.DefaultAlertExpiration))
+		return nil
+	}
+
+	kubeClient, err := n.config.ClusterClient().KubernetesClient()
+	if err != nil {
+		return errors.Wrap(err, "create kubernetes client")
+	}
+
+	switch operation {
+	case drain.OperationCordon, drain.OperationUncordon:
+		alertType := action.AlertTypeInfo
+		message := fmt.Sprintf("Node %q is now unschedulable", key.Name)
+		if operation == drain.OperationUncordon {
+			message = fmt.Sprintf("Node %q is now schedulable", key.Name)
+		}
+
+		if err := drain.Cordon(kubeClient, key.Name, operation == drain.OperationCordon); err != nil {
+			alertType = action.AlertTypeWarning
+			message = fmt.Sprintf("Unable to %s node %q: %s", operation, key.Name, err)
+		}
+
+		alerter.SendAlert(action.CreateAlert(alertType, message, action.DefaultAlertExpiration))
+		return nil
+	case drain.OperationDrain:
+	default:
+		return errors.Errorf("unknown operation %q", operation)
+	}
+
+	pods, options, err := n.drainPods(ctx, kubeClient, key, payload)
+	if err != nil {
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+			fmt.Sprintf("Unable to drain node %q: %s", key.Name, err), action.DefaultAlertExpiration))
+		return nil
+	}
+
+	alerter.SendAlert(action.CreateAlert(action.AlertTypeInfo,
+		fmt.Sprintf("Draining node %q", key.Name), action.DefaultAlertExpiration))
+
+	// evictions can take until the timeout, so the drain happens in the background
+	go func() {
+		ctx := log.WithLoggerContext(context.Background(), logger)
+
+		report := func(progress drain.Progress) {
+			alertType := action.AlertTypeInfo
+			if progress.Blocked {
+				alertType = action.AlertTypeWarning
+			}
+			alerter.SendAlert(action.CreateAlert(alertType,
+				fmt.Sprintf("Draining node %q: %s %s", key.Name, progress.Pod, progress.Message), action.DefaultAlertExpiration))
+		}
+
+		evicted, err := drain.Drain(ctx, kubeClient, key.Name, pods, options, report)
+		if err != nil {
+			logger.WithErr(err).Errorf("drain node")
+			alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+				fmt.Sprintf("Unable to drain node %q: %s", key.Name, err), action.DefaultAlertExpiration))
+			return
+		}
+
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeInfo,
+			fmt.Sprintf("Drained node %q: evicted %d pods", key.Name, evicted), action.DefaultAlertExpiration))
+	}()
+
+	return nil
+}
+
+func (n *NodeOperator) checkAccess(ctx context.Context, key store.Key) error {
+	if key.Kind != "Node" {
+		return errors.Errorf("%s is not a node", key.Kind)
+	}
+
+	return store.HasSubresourceAccess(ctx, n.config.ObjectStore(), key, "", "patch")
+}
+
+// drainPods returns the pods a drain evicts and the drain options. The current
+// user has to be able to evict pods in each namespace the pods are in.
+func (n *NodeOperator) drainPods(ctx context.Context, kubeClient kubernetes.Interface, key store.Key, payload action.Payload) ([]corev1.Pod, drain.Options, error) {
+	options, err := drainOptionsFromPayload(payload)
+	if err != nil {
+		return nil, options, err
+	}
+
+	pods, err := drain.PodsToEvict(kubeClient, key.Name, options)
+	if err != nil {
+		return nil, options, err
+	}
+
+	for _, namespace := range drain.Namespaces(pods) {
+		podKey := store.Key{Namespace: namespace, ApiVersion: "v1", Kind: "Pod"}
+		if err := store.HasSubresourceAccess(ctx, n.config.ObjectStore(), podKey, "eviction", "create"); err != nil {
+			return nil, options, errors.Wrapf(err, "evict pods in namespace %q", namespace)
+		}
+	}
+
+	return pods, options, nil
+}
+
+func drainOptionsFromPayload(payload action.Payload) (drain.Options, error) {
+	options := drain.Options{
+		GracePeriodSeconds: -1,
+		Timeout:            drain.DefaultTimeout,
+	}
+
+	// unchecked options aren't sent
+	if _, ok := payload["drainOptions"]; ok {
+		flags, err := payload.StringSlice("drainOptions")
+		if err != nil {
+			return options, err
+		}
+
+		for _, flag := range flags {
+			switch flag {
+			case drain.OptionIgnoreDaemonSets:
+				options.IgnoreDaemonSets = true
+			case drain.OptionDeleteEmptyDirData:
+				options.DeleteEmptyDirData = true
+			}
+		}
+	}
+
+	// a blank grace period uses each pod's own
+	if value, ok := payload["gracePeriod"]; ok && value != "" {
+		gracePeriod, err := payload.Float64("gracePeriod")
+		if err != nil {
+			return options, errors.Wrap(err, "grace period")
+		}
+		options.GracePeriodSeconds = roundToInt(gracePeriod)
+	}
+
+	timeout, err := payload.OptionalString("timeout")
+	if err != nil {
+		return options, err
+	}
+	if timeout != "" {
+		options.Timeout, err = time.ParseDuration(timeout)
+		if err != nil {
+			return options, errors.Wrapf(err, "timeout %q", timeout)
+		}
+		if options.Timeout <= 0 {
+			return options, errors.Errorf("timeout must be positive: %s", timeout)
+		}
+	}
+
+	return options, nil
+}
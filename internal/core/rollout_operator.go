@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubenext/kubeon/internal/cluster"
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/internal/queryer"
+	"github.com/kubenext/kubeon/internal/rollout"
+	"github.com/kubenext/kubeon/pkg/action"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// RolloutOperatorConfig is configuration for RolloutOperator.
+type RolloutOperatorConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// RolloutOperator restarts, pauses, resumes and undoes rollouts. Progress is
+// sent as alerts until the rollout completes or stalls.
+type RolloutOperator struct {
+	config RolloutOperatorConfig
+}
+
+var _ action.Dispatcher = (*RolloutOperator)(nil)
+
+// NewRolloutOperator creates an instance of RolloutOperator.
+func NewRolloutOperator(config RolloutOperatorConfig) *RolloutOperator {
+	return &RolloutOperator{
+		config: config,
+	}
+}
+
+// ActionName returns name of this action.
+func (r *RolloutOperator) ActionName() string {
+	return "overview/rollout"
+}
+
+// Handle runs the rollout operation in the payload. Undo requires the
+// revision to roll back to.
+func (r *RolloutOperator) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", r.ActionName())
+	logger.With("payload", payload).Debugf("received action payload")
+
+	key, err := store.KeyFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	operation, err := payload.String("operation")
+	if err != nil {
+		return err
+	}
+
+	client, err := r.operate(ctx, key, operation, payload)
+	if err != nil {
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+			fmt.Sprintf("Unable to %s rollout of %s %q: %s", operation, key.Kind, key.Name, err), action.DefaultAlertExpiration))
+		return nil
+	}
+
+	if operation == rollout.OperationPause {
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeInfo,
+			fmt.Sprintf("Paused rollout of %s %q", key.Kind, key.Name), action.DefaultAlertExpiration))
+		return nil
+	}
+
+	alerter.SendAlert(action.CreateAlert(action.AlertTypeInfo,
+		fmt.Sprintf("Started %s of %s %q", operation, key.Kind, key.Name), action.DefaultAlertExpiration))
+
+	// rollouts can take a while, so progress is reported in the background
+	go func() {
+		ctx := log.WithLoggerContext(context.Background(), logger)
+
+		report := func(progress rollout.Progress) {
+			alerter.SendAlert(action.CreateAlert(action.AlertTypeInfo,
+				fmt.Sprintf("Rollout of %s %q: %s", key.Kind, key.Name, progress.Message), action.DefaultAlertExpiration))
+		}
+
+		progress, err := rollout.Watch(ctx, client, key.Name, rollout.DefaultWatchTimeout, report)
+		if err != nil {
+			logger.WithErr(err).Errorf("watch rollout")
+			alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+				fmt.Sprintf("Unable to watch rollout of %s %q: %s", key.Kind, key.Name, err), action.DefaultAlertExpiration))
+			return
+		}
+
+		alertType := action.AlertTypeInfo
+		message := fmt.Sprintf("Rollout of %s %q %s", key.Kind, key.Name, progress.Message)
+		if progress.Stalled {
+			alertType = action.AlertTypeWarning
+			message = fmt.Sprintf("Rollout of %s %q stalled: %s", key.Kind, key.Name, progress.Message)
+		}
+
+		alerter.SendAlert(action.CreateAlert(alertType, message, action.DefaultAlertExpiration))
+	}()
+
+	return nil
+}
+
+func (r *RolloutOperator) operate(ctx context.Context, key store.Key, operation string, payload action.Payload) (dynamic.ResourceInterface, error) {
+	if !rollout.Supported(key.ApiVersion, key.Kind) {
+		return nil, errors.Errorf("%s rollouts are not supported", key.Kind)
+	}
+
+	if err := store.HasSubresourceAccess(ctx, r.config.ObjectStore(), key, "", "patch"); err != nil {
+		return nil, err
+	}
+
+	clusterClient := r.config.ClusterClient()
+
+	gvr, err := clusterClient.Resource(key.GroupVersionKind().GroupKind())
+	if err != nil {
+		return nil, errors.Wrap(err, "find resource")
+	}
+
+	dynamicClient, err := clusterClient.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	client := dynamicClient.Resource(gvr).Namespace(key.Namespace)
+
+	object, err := client.Get(key.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation {
+	case rollout.OperationRestart:
+		err = rollout.Restart(client, object, time.Now())
+	case rollout.OperationPause:
+		err = rollout.Pause(client, object, true)
+	case rollout.OperationResume:
+		err = rollout.Pause(client, object, false)
+	case rollout.OperationUndo:
+		err = r.undo(ctx, client, object, payload)
+	default:
+		err = errors.Errorf("unknown operation %q", operation)
+	}
+
+	return client, err
+}
+
+func (r *RolloutOperator) undo(ctx context.Context, client dynamic.ResourceInterface, object *unstructured.Unstructured, payload action.Payload) error {
+	value, err := payload.String("revision")
+	if err != nil {
+		return err
+	}
+
+	number, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return errors.Errorf("revision %q is not a number", value)
+	}
+
+	discoveryClient, err := r.config.ClusterClient().DiscoveryClient()
+	if err != nil {
+		return err
+	}
+
+	q := queryer.New(r.config.ObjectStore(), discoveryClient)
+
+	revisions, err := rollout.Revisions(ctx, q, object)
+	if err != nil {
+		return err
+	}
+
+	return rollout.Undo(client, object, revisions, number)
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubenext/kubeon/internal/cluster"
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/internal/scale"
+	"github.com/kubenext/kubeon/pkg/action"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// ScalerConfig is configuration for Scaler.
+type ScalerConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// Scaler scales objects which have the scale subresource.
+type Scaler struct {
+	config ScalerConfig
+}
+
+var _ action.Dispatcher = (*Scaler)(nil)
+
+// NewScaler creates an instance of Scaler.
+func NewScaler(config ScalerConfig) *Scaler {
+	return &Scaler{
+		config: config,
+	}
+}
+
+// ActionName returns name of this action.
+func (s *Scaler) ActionName() string {
+	return "overview/scale"
+}
+
+// Handle scales an object to the replicas in the payload. A warning is sent if
+// a horizontal pod autoscaler will override the change.
+func (s *Scaler) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", s.ActionName())
+	logger.With("payload", payload).Debugf("received action payload")
+
+	key, err := store.KeyFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	replicasFloat, err := payload.Float64("replicas")
+	if err != nil {
+		return err
+	}
+	replicas := int32(roundToInt(replicasFloat))
+
+	if err := s.scale(ctx, key, replicas); err != nil {
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+			fmt.Sprintf("Unable to scale %s %q: %s", key.Kind, key.Name, err), action.DefaultAlertExpiration))
+		return nil
+	}
+
+	alertType := action.AlertTypeInfo
+	message := fmt.Sprintf("Scaled %s %q to %d replicas", key.Kind, key.Name, replicas)
+
+	autoscalers, err := scale.Autoscalers(ctx, s.config.ObjectStore(), key)
+	if err != nil {
+		logger.WithErr(err).Errorf("finding autoscalers")
+	} else if warning := scale.AutoscalerWarning(autoscalers); warning != "" {
+		alertType = action.AlertTypeWarning
+		message = fmt.Sprintf("%s. %s.", message, warning)
+	}
+
+	alerter.SendAlert(action.CreateAlert(alertType, message, action.DefaultAlertExpiration))
+	return nil
+}
+
+func (s *Scaler) scale(ctx context.Context, key store.Key, replicas int32) error {
+	clusterClient := s.config.ClusterClient()
+
+	gvr, err := clusterClient.Resource(key.GroupVersionKind().GroupKind())
+	if err != nil {
+		return errors.Wrap(err, "find resource")
+	}
+
+	discoveryClient, err := clusterClient.DiscoveryClient()
+	if err != nil {
+		return err
+	}
+
+	ok, err := scale.HasSubresource(discoveryClient, gvr)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Errorf("%s can't be scaled", gvr.Resource)
+	}
+
+	if err := store.HasSubresourceAccess(ctx, s.config.ObjectStore(), key, scale.Subresource, "update"); err != nil {
+		return err
+	}
+
+	dynamicClient, err := clusterClient.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	return scale.Update(dynamicClient.Resource(gvr).Namespace(key.Namespace), key.Name, replicas)
+}
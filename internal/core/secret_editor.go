@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubenext/kubeon/internal/cluster"
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/internal/secretdata"
+	"github.com/kubenext/kubeon/pkg/action"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// SecretEditorConfig is configuration for SecretEditor.
+type SecretEditorConfig interface {
+	ClusterClient() cluster.ClientInterface
+	ObjectStore() store.Store
+}
+
+// SecretEditor adds, changes and deletes secret keys. Values are entered as
+// text or base64 and are encoded for the secret.
+type SecretEditor struct {
+	config SecretEditorConfig
+}
+
+var _ action.Dispatcher = (*SecretEditor)(nil)
+
+// NewSecretEditor creates an instance of SecretEditor.
+func NewSecretEditor(config SecretEditorConfig) *SecretEditor {
+	return &SecretEditor{
+		config: config,
+	}
+}
+
+// ActionName returns name of this action.
+func (s *SecretEditor) ActionName() string {
+	return "overview/editSecret"
+}
+
+// Handle sets or deletes the secret keys in the payload.
+func (s *SecretEditor) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", s.ActionName())
+	// the payload isn't logged because it can contain a secret value
+
+	key, err := store.KeyFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	warn := func(err error) error {
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning,
+			fmt.Sprintf("Unable to update secret %q: %s", key.Name, err), action.DefaultAlertExpiration))
+		return nil
+	}
+
+	operation, err := payload.String("operation")
+	if err != nil {
+		return err
+	}
+
+	resourceVersion, err := payload.OptionalString("resourceVersion")
+	if err != nil {
+		return err
+	}
+
+	set := map[string][]byte{}
+	var setKey string
+	var remove []string
+
+	switch operation {
+	case secretdata.OperationSet:
+		name, err := payload.String("key")
+		if err != nil {
+			return err
+		}
+		value, err := payload.OptionalString("value")
+		if err != nil {
+			return err
+		}
+		encoding, err := payload.OptionalString("encoding")
+		if err != nil {
+			return err
+		}
+
+		data, err := secretdata.Decode(value, encoding)
+		if err != nil {
+			return warn(err)
+		}
+		setKey = strings.TrimSpace(name)
+		set[setKey] = data
+	case secretdata.OperationDelete:
+		remove, err = payload.OptionalStringSlice("keys")
+		if err != nil {
+			return err
+		}
+		if len(remove) == 0 {
+			return warn(errors.New("no keys were selected"))
+		}
+	default:
+		return warn(errors.Errorf("unknown operation %q", operation))
+	}
+
+	if key.Kind != "Secret" {
+		return warn(errors.Errorf("%s is not a secret", key.Kind))
+	}
+
+	if err := store.HasSubresourceAccess(ctx, s.config.ObjectStore(), key, "", "patch"); err != nil {
+		return warn(err)
+	}
+
+	kubeClient, err := s.config.ClusterClient().KubernetesClient()
+	if err != nil {
+		return warn(errors.Wrap(err, "create kubernetes client"))
+	}
+
+	secrets := kubeClient.CoreV1().Secrets(key.Namespace)
+
+	secret, err := secrets.Get(key.Name, metav1.GetOptions{})
+	if err != nil {
+		return warn(err)
+	}
+
+	if _, err := secretdata.Edit(secret.Data, set, remove); err != nil {
+		return warn(err)
+	}
+
+	patch, err := secretdata.Patch(resourceVersion, set, remove)
+	if err != nil {
+		return err
+	}
+
+	if _, err := secrets.Patch(key.Name, types.MergePatchType, patch); err != nil {
+		if kerrors.IsConflict(err) {
+			err = errors.New("it was changed by someone else; reload it and edit again")
+		}
+		return warn(err)
+	}
+
+	message := fmt.Sprintf("Set key %q of secret %q", setKey, key.Name)
+	if operation == secretdata.OperationDelete {
+		message = fmt.Sprintf("Deleted keys %s of secret %q", strings.Join(remove, ", "), key.Name)
+	}
+
+	logger.With("namespace", key.Namespace, "name", key.Name, "operation", operation).Infof("updated secret data")
+	alerter.SendAlert(action.CreateAlert(action.AlertTypeInfo, message, action.DefaultAlertExpiration))
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/internal/terminal"
+	"github.com/kubenext/kubeon/pkg/action"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// TerminalStarter starts terminals in pod containers.
+type TerminalStarter struct {
+	store           store.Store
+	terminalManager terminal.Manager
+}
+
+var _ action.Dispatcher = (*TerminalStarter)(nil)
+
+// NewTerminalStarter creates an instance of TerminalStarter.
+func NewTerminalStarter(objectStore store.Store, terminalManager terminal.Manager) *TerminalStarter {
+	return &TerminalStarter{
+		store:           objectStore,
+		terminalManager: terminalManager,
+	}
+}
+
+// ActionName returns name of this action.
+func (s *TerminalStarter) ActionName() string {
+	return "overview/startTerminal"
+}
+
+// Handle starts a terminal. The command is split on whitespace; the default
+// shell is used if it is blank.
+func (s *TerminalStarter) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", s.ActionName())
+	logger.With("payload", payload).Debugf("received action payload")
+
+	key, err := store.KeyFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	containerName, err := payload.String("containerName")
+	if err != nil {
+		return err
+	}
+
+	command, err := payload.OptionalString("command")
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Started terminal in container %q", containerName)
+	alertType := action.AlertTypeInfo
+	if err := s.start(ctx, key, containerName, strings.Fields(command)); err != nil {
+		message = fmt.Sprintf("Unable to start terminal in container %q: %s", containerName, err)
+		alertType = action.AlertTypeWarning
+		logger.WithErr(err).Errorf("start terminal")
+	}
+	alert := action.CreateAlert(alertType, message, action.DefaultAlertExpiration)
+
+	alerter.SendAlert(alert)
+	return nil
+}
+
+func (s *TerminalStarter) start(ctx context.Context, key store.Key, containerName string, command []string) error {
+	if err := store.HasSubresourceAccess(ctx, s.store, key, "exec", "create"); err != nil {
+		return err
+	}
+
+	_, err := s.terminalManager.Create(ctx, key.Namespace, key.Name, containerName, command)
+	return err
+}
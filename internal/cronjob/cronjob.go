@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package cronjob runs cron jobs on demand, suspends them and describes their runs.
+package cronjob
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// OperationRun creates a job from a cron job now.
+	OperationRun = "run"
+	// OperationSuspend stops a cron job from scheduling jobs.
+	OperationSuspend = "suspend"
+	// OperationResume lets a suspended cron job schedule jobs again.
+	OperationResume = "resume"
+
+	// InstantiateAnnotation is set on jobs which were created from a cron job
+	// manually instead of by its schedule. kubectl create job --from sets it too.
+	InstantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+
+	// maxNameLength is the longest job name which is also a valid value for
+	// the job-name label of its pods.
+	maxNameLength = 63
+	// nameSuffixLength is the length of the random suffix of manual job
+	// names, so jobs created close together don't collide.
+	nameSuffixLength = 5
+)
+
+// NewJob creates a job from the job template of a cron job. The job is owned
+// by the cron job, so it is listed and cleaned up like scheduled jobs.
+func NewJob(cronJob *batchv1beta1.CronJob) *batchv1.Job {
+	template := cronJob.Spec.JobTemplate
+
+	annotations := map[string]string{}
+	for k, v := range template.Annotations {
+		annotations[k] = v
+	}
+	annotations[InstantiateAnnotation] = "manual"
+
+	labels := map[string]string{}
+	for k, v := range template.Labels {
+		labels[k] = v
+	}
+
+	controller := true
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        jobName(cronJob.Name, utilrand.String(nameSuffixLength)),
+			Namespace:   cronJob.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         "batch/v1beta1",
+					Kind:               "CronJob",
+					Name:               cronJob.Name,
+					UID:                cronJob.UID,
+					Controller:         &controller,
+					BlockOwnerDeletion: &controller,
+				},
+			},
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+}
+
+// jobName names a manual job like kubectl create job --from does, with a
+// random suffix.
+func jobName(cronJobName, random string) string {
+	suffix := "-manual-" + random
+	if len(cronJobName)+len(suffix) > maxNameLength {
+		cronJobName = cronJobName[:maxNameLength-len(suffix)]
+	}
+	return cronJobName + suffix
+}
+
+// Run creates a job from a cron job now.
+func Run(kubeClient kubernetes.Interface, cronJob *batchv1beta1.CronJob) (*batchv1.Job, error) {
+	job, err := kubeClient.BatchV1().Jobs(cronJob.Namespace).Create(NewJob(cronJob))
+	if err != nil {
+		return nil, errors.Wrapf(err, "create job for cron job %s", cronJob.Name)
+	}
+
+	return job, nil
+}
+
+// Suspend suspends a cron job, or resumes it if suspend is false. Jobs which
+// already started are not affected.
+func Suspend(kubeClient kubernetes.Interface, namespace, name string, suspend bool) error {
+	patch := fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend)
+
+	_, err := kubeClient.BatchV1beta1().CronJobs(namespace).Patch(name, types.MergePatchType, []byte(patch))
+	return errors.Wrapf(err, "update cron job %s", name)
+}
+
+// IsSuspended returns true if a cron job is suspended.
+func IsSuspended(cronJob *batchv1beta1.CronJob) bool {
+	return cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend
+}
+
+// Outcomes of a run.
+const (
+	OutcomePending   = "Pending"
+	OutcomeRunning   = "Running"
+	OutcomeSucceeded = "Succeeded"
+	OutcomeFailed    = "Failed"
+)
+
+// RunInfo describes a job run by a cron job.
+type RunInfo struct {
+	Job     *batchv1.Job
+	Pods    []*corev1.Pod
+	Manual  bool
+	Outcome string
+	// Started is zero if the job hasn't started.
+	Started time.Time
+	// Duration is how long the job ran, or has been running.
+	Duration time.Duration
+}
+
+// Runs describes the jobs of a cron job, newest first. pods are the pods of
+// any jobs; they are matched to their jobs by owner.
+func Runs(cronJob *batchv1beta1.CronJob, jobs []*batchv1.Job, pods []*corev1.Pod, now time.Time) []RunInfo {
+	var runs []RunInfo
+	for _, job := range jobs {
+		owner := metav1.GetControllerOf(job)
+		if owner == nil || owner.UID != cronJob.UID {
+			continue
+		}
+
+		runs = append(runs, runInfo(job, pods, now))
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[j].Job.CreationTimestamp.Before(&runs[i].Job.CreationTimestamp)
+	})
+
+	return runs
+}
+
+func runInfo(job *batchv1.Job, pods []*corev1.Pod, now time.Time) RunInfo {
+	run := RunInfo{
+		Job:     job,
+		Outcome: OutcomePending,
+	}
+
+	_, run.Manual = job.Annotations[InstantiateAnnotation]
+
+	for _, pod := range pods {
+		if owner := metav1.GetControllerOf(pod); owner != nil && owner.UID == job.UID {
+			run.Pods = append(run.Pods, pod)
+		}
+	}
+
+	if job.Status.StartTime != nil {
+		run.Started = job.Status.StartTime.Time
+	}
+
+	end := now
+	switch {
+	case hasCondition(job, batchv1.JobComplete):
+		run.Outcome = OutcomeSucceeded
+		if job.Status.CompletionTime != nil {
+			end = job.Status.CompletionTime.Time
+		}
+	case hasCondition(job, batchv1.JobFailed):
+		run.Outcome = OutcomeFailed
+		end = conditionTime(job, batchv1.JobFailed)
+	case job.Status.Active > 0:
+		run.Outcome = OutcomeRunning
+	}
+
+	if !run.Started.IsZero() && end.After(run.Started) {
+		run.Duration = end.Sub(run.Started).Round(time.Second)
+	}
+
+	return run
+}
+
+func hasCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func conditionTime(job *batchv1.Job, conditionType batchv1.JobConditionType) time.Time {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return time.Time{}
+}
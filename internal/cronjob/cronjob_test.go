@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package cronjob
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func cronJob(name string) *batchv1beta1.CronJob {
+	return &batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name + "-uid"),
+		},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule: "*/5 * * * *",
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": name},
+					Annotations: map[string]string{"team": "a"},
+				},
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "main", Image: "busybox"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNewJob(t *testing.T) {
+	cj := cronJob("backup")
+
+	job := NewJob(cj)
+
+	assert.True(t, strings.HasPrefix(job.Name, "backup-manual-"))
+	assert.Len(t, job.Name, len("backup-manual-")+nameSuffixLength)
+	assert.Equal(t, "default", job.Namespace)
+	assert.Equal(t, map[string]string{"app": "backup"}, job.Labels)
+	assert.Equal(t, map[string]string{"team": "a", InstantiateAnnotation: "manual"}, job.Annotations)
+	assert.Equal(t, cj.Spec.JobTemplate.Spec, job.Spec)
+
+	owner := metav1.GetControllerOf(job)
+	require.NotNil(t, owner)
+	assert.Equal(t, "CronJob", owner.Kind)
+	assert.Equal(t, cj.UID, owner.UID)
+
+	// the template is copied, not shared
+	job.Labels["changed"] = "true"
+	assert.NotContains(t, cj.Spec.JobTemplate.Labels, "changed")
+
+	assert.NotEqual(t, job.Name, NewJob(cj).Name)
+}
+
+func TestJobName(t *testing.T) {
+	assert.Equal(t, "backup-manual-abcde", jobName("backup", "abcde"))
+
+	long := strings.Repeat("a", 70)
+	name := jobName(long, "abcde")
+	assert.Len(t, name, maxNameLength)
+	assert.True(t, strings.HasSuffix(name, "-manual-abcde"))
+}
+
+func TestRun(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	cj := cronJob("backup")
+
+	first, err := Run(kubeClient, cj)
+	require.NoError(t, err)
+	second, err := Run(kubeClient, cj)
+	require.NoError(t, err)
+
+	jobs, err := kubeClient.BatchV1().Jobs("default").List(metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, jobs.Items, 2)
+	assert.NotEqual(t, first.Name, second.Name)
+}
+
+func TestSuspend(t *testing.T) {
+	cj := cronJob("backup")
+	kubeClient := kubefake.NewSimpleClientset(cj)
+
+	require.NoError(t, Suspend(kubeClient, "default", "backup", true))
+
+	got, err := kubeClient.BatchV1beta1().CronJobs("default").Get("backup", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, IsSuspended(got))
+
+	require.NoError(t, Suspend(kubeClient, "default", "backup", false))
+
+	got, err = kubeClient.BatchV1beta1().CronJobs("default").Get("backup", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.False(t, IsSuspended(got))
+
+	assert.Error(t, Suspend(kubeClient, "default", "missing", true))
+}
+
+func TestRuns(t *testing.T) {
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	cj := cronJob("backup")
+
+	controller := true
+	ownedBy := func(uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: "CronJob", UID: uid, Controller: &controller}}
+	}
+	at := func(minutes int) *metav1.Time {
+		timestamp := metav1.NewTime(now.Add(time.Duration(minutes) * time.Minute))
+		return &timestamp
+	}
+
+	succeeded := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "succeeded", UID: "succeeded", CreationTimestamp: *at(-30), OwnerReferences: ownedBy(cj.UID)},
+		Status: batchv1.JobStatus{
+			StartTime:      at(-30),
+			CompletionTime: at(-28),
+			Conditions:     []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+		},
+	}
+	failed := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "failed", UID: "failed", CreationTimestamp: *at(-20), OwnerReferences: ownedBy(cj.UID)},
+		Status: batchv1.JobStatus{
+			StartTime:  at(-20),
+			Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: *at(-15)}},
+		},
+	}
+	running := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "running", UID: "running", CreationTimestamp: *at(-10), OwnerReferences: ownedBy(cj.UID),
+			Annotations: map[string]string{InstantiateAnnotation: "manual"},
+		},
+		Status: batchv1.JobStatus{StartTime: at(-10), Active: 1},
+	}
+	pending := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", UID: "pending", CreationTimestamp: *at(-1), OwnerReferences: ownedBy(cj.UID)},
+	}
+	other := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", UID: "other", CreationTimestamp: *at(-5), OwnerReferences: ownedBy("other")},
+	}
+
+	runningPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "running-pod", OwnerReferences: []metav1.OwnerReference{{Kind: "Job", UID: "running", Controller: &controller}}},
+	}
+	otherPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-pod", OwnerReferences: []metav1.OwnerReference{{Kind: "Job", UID: "other", Controller: &controller}}},
+	}
+
+	runs := Runs(cj, []*batchv1.Job{succeeded, other, failed, pending, running}, []*corev1.Pod{runningPod, otherPod}, now)
+	require.Len(t, runs, 4)
+
+	var names []string
+	for _, run := range runs {
+		names = append(names, run.Job.Name)
+	}
+	assert.Equal(t, []string{"pending", "running", "failed", "succeeded"}, names)
+
+	assert.Equal(t, OutcomePending, runs[0].Outcome)
+	assert.True(t, runs[0].Started.IsZero())
+	assert.Zero(t, runs[0].Duration)
+
+	assert.Equal(t, OutcomeRunning, runs[1].Outcome)
+	assert.True(t, runs[1].Manual)
+	assert.Equal(t, 10*time.Minute, runs[1].Duration)
+	assert.Equal(t, []*corev1.Pod{runningPod}, runs[1].Pods)
+
+	assert.Equal(t, OutcomeFailed, runs[2].Outcome)
+	assert.False(t, runs[2].Manual)
+	assert.Equal(t, 5*time.Minute, runs[2].Duration)
+
+	assert.Equal(t, OutcomeSucceeded, runs[3].Outcome)
+	assert.Equal(t, 2*time.Minute, runs[3].Duration)
+	assert.Empty(t, runs[3].Pods)
+}
@@ -23,6 +23,8 @@ import (
 	"github.com/skratchdot/open-golang/open"
 	"go.opencensus.io/trace"
 
+	"github.com/kubenext/kubeon/internal/modules/search"
+	"github.com/kubenext/kubeon/internal/terminal"
 	"github.com/vmware/octant/internal/api"
 	"github.com/vmware/octant/internal/cluster"
 	"github.com/vmware/octant/internal/config"
@@ -105,6 +107,11 @@ func Run(ctx context.Context, logger log.Logger, shutdownCh chan bool, options O
 		return errors.Wrap(err, "initializing port forwarder")
 	}
 
+	terminalManager, err := terminal.NewTerminalManager(ctx, clusterClient)
+	if err != nil {
+		return errors.Wrap(err, "initializing terminal manager")
+	}
+
 	actionManger := action.NewManager(logger)
 
 	mo := &moduleOptions{
@@ -140,6 +147,7 @@ func Run(ctx context.Context, logger log.Logger, shutdownCh chan bool, options O
 		appObjectStore,
 		pluginManager,
 		portForwarder,
+		terminalManager,
 		options.Context,
 		restConfigOptions)
 
@@ -189,6 +197,7 @@ func Run(ctx context.Context, logger log.Logger, shutdownCh chan bool, options O
 
 	moduleManager.Unload()
 	pluginManager.Stop(shutdownCtx)
+	terminalManager.StopAll()
 
 	shutdownCh <- true
 
@@ -266,6 +275,16 @@ func initModules(ctx context.Context, dashConfig config.Dash, namespace string)
 
 	list = append(list, configurationModule)
 
+	searchOptions := search.Options{
+		DashConfig: dashConfig,
+	}
+	searchModule, err := search.New(ctx, searchOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "create search module")
+	}
+
+	list = append(list, searchModule)
+
 	localContentPath := os.Getenv("OCTANT_LOCAL_CONTENT")
 	if localContentPath != "" {
 		localContentModule := localcontent.New(localContentPath)
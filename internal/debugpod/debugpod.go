@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package debugpod creates short-lived pods for debugging other pods and nodes.
+package debugpod
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultImage is the image used when no image is given.
+	DefaultImage = "busybox"
+	// ContainerName is the name of the debug container.
+	ContainerName = "debug"
+
+	// LabelDebugPod is set on all debug pods.
+	LabelDebugPod = "kubeon.dev/debug-pod"
+	// AnnotationTarget is the kind and name of the debugged object.
+	AnnotationTarget = "kubeon.dev/debug-target"
+
+	// maxLifetime limits how long a debug pod runs if it is never deleted.
+	maxLifetime = 4 * time.Hour
+	// startTimeout is how long to wait for a debug pod to start running.
+	startTimeout = 2 * time.Minute
+)
+
+// ForPod creates a debug pod which runs on the target pod's node with the
+// target's network and DNS settings.
+func ForPod(target *corev1.Pod, image string) *corev1.Pod {
+	pod := newPod(target.Namespace, target.Name+"-debug-", fmt.Sprintf("Pod/%s", target.Name), image)
+
+	spec := &pod.Spec
+	spec.NodeName = target.Spec.NodeName
+	spec.HostNetwork = target.Spec.HostNetwork
+	spec.DNSPolicy = target.Spec.DNSPolicy
+	spec.DNSConfig = target.Spec.DNSConfig
+	spec.HostAliases = target.Spec.HostAliases
+	spec.Tolerations = target.Spec.Tolerations
+	spec.ImagePullSecrets = target.Spec.ImagePullSecrets
+
+	return pod
+}
+
+// ForNode creates a privileged debug pod on a node. It shares the node's PID,
+// IPC and network namespaces, and the node's root file system is mounted at /host.
+func ForNode(namespace, nodeName, image string) *corev1.Pod {
+	pod := newPod(namespace, fmt.Sprintf("node-debug-%s-", nodeName), fmt.Sprintf("Node/%s", nodeName), image)
+
+	privileged := true
+
+	spec := &pod.Spec
+	spec.NodeName = nodeName
+	spec.HostPID = true
+	spec.HostIPC = true
+	spec.HostNetwork = true
+	spec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+	spec.Tolerations = []corev1.Toleration{{Operator: corev1.TolerationOpExists}}
+	spec.Volumes = []corev1.Volume{
+		{
+			Name: "host-root",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{Path: "/"},
+			},
+		},
+	}
+
+	container := &spec.Containers[0]
+	container.SecurityContext = &corev1.SecurityContext{Privileged: &privileged}
+	container.VolumeMounts = []corev1.VolumeMount{{Name: "host-root", MountPath: "/host"}}
+
+	return pod
+}
+
+// newPod creates a pod which runs image's default command with a TTY so shells
+// keep running until the pod is deleted.
+func newPod(namespace, generateName, target, image string) *corev1.Pod {
+	if image == "" {
+		image = DefaultImage
+	}
+
+	deadline := int64(maxLifetime.Seconds())
+	var gracePeriod int64
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    namespace,
+			GenerateName: generateName,
+			Labels: map[string]string{
+				LabelDebugPod: "true",
+			},
+			Annotations: map[string]string{
+				AnnotationTarget: target,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  ContainerName,
+					Image: image,
+					Stdin: true,
+					TTY:   true,
+				},
+			},
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds:         &deadline,
+			TerminationGracePeriodSeconds: &gracePeriod,
+		},
+	}
+}
+
+// Run creates pod and waits for it to run. The pod is deleted if it doesn't start.
+func Run(ctx context.Context, kubeClient kubernetes.Interface, pod *corev1.Pod) (*corev1.Pod, error) {
+	pods := kubeClient.CoreV1().Pods(pod.Namespace)
+
+	created, err := pods.Create(pod)
+	if err != nil {
+		return nil, errors.Wrap(err, "create debug pod")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, startTimeout)
+	defer cancel()
+
+	var current *corev1.Pod
+	err = wait.PollImmediateUntil(time.Second, func() (bool, error) {
+		p, err := pods.Get(created.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		current = p
+
+		switch current.Status.Phase {
+		case corev1.PodRunning:
+			return true, nil
+		case corev1.PodSucceeded, corev1.PodFailed:
+			return false, errors.Errorf("debug pod exited: %s", current.Status.Phase)
+		}
+
+		return false, nil
+	}, ctx.Done())
+
+	if err != nil {
+		if deleteErr := Delete(kubeClient, created.Namespace, created.Name); deleteErr != nil {
+			err = errors.Wrapf(err, "delete debug pod: %v", deleteErr)
+		}
+		return nil, errors.Wrapf(err, "wait for debug pod %s", created.Name)
+	}
+
+	return current, nil
+}
+
+// Delete deletes a debug pod immediately.
+func Delete(kubeClient kubernetes.Interface, namespace, name string) error {
+	var gracePeriod int64
+	err := kubeClient.CoreV1().Pods(namespace).Delete(name, &metav1.DeleteOptions{
+		GracePeriodSeconds: &gracePeriod,
+	})
+
+	return errors.Wrapf(err, "delete debug pod %s", name)
+}
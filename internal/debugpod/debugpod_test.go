@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package debugpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestForPod(t *testing.T) {
+	target := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Spec: corev1.PodSpec{
+			NodeName:         "node-1",
+			DNSPolicy:        corev1.DNSClusterFirst,
+			Tolerations:      []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+			Containers:       []corev1.Container{{Name: "web", Image: "nginx"}},
+		},
+	}
+
+	pod := ForPod(target, "")
+
+	assert.Equal(t, "default", pod.Namespace)
+	assert.Equal(t, "web-debug-", pod.GenerateName)
+	assert.Equal(t, "true", pod.Labels[LabelDebugPod])
+	assert.Equal(t, "Pod/web", pod.Annotations[AnnotationTarget])
+
+	assert.Equal(t, "node-1", pod.Spec.NodeName)
+	assert.Equal(t, corev1.DNSClusterFirst, pod.Spec.DNSPolicy)
+	assert.Equal(t, target.Spec.Tolerations, pod.Spec.Tolerations)
+	assert.Equal(t, target.Spec.ImagePullSecrets, pod.Spec.ImagePullSecrets)
+	assert.False(t, pod.Spec.HostPID)
+
+	require.Len(t, pod.Spec.Containers, 1)
+	container := pod.Spec.Containers[0]
+	assert.Equal(t, ContainerName, container.Name)
+	assert.Equal(t, DefaultImage, container.Image)
+	assert.True(t, container.Stdin)
+	assert.True(t, container.TTY)
+	assert.Nil(t, container.SecurityContext)
+
+	assert.Equal(t, corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+	require.NotNil(t, pod.Spec.ActiveDeadlineSeconds)
+	assert.Equal(t, int64(maxLifetime.Seconds()), *pod.Spec.ActiveDeadlineSeconds)
+}
+
+func TestForNode(t *testing.T) {
+	pod := ForNode("kube-system", "node-1", "alpine")
+
+	assert.Equal(t, "kube-system", pod.Namespace)
+	assert.Equal(t, "node-debug-node-1-", pod.GenerateName)
+	assert.Equal(t, "Node/node-1", pod.Annotations[AnnotationTarget])
+
+	spec := pod.Spec
+	assert.Equal(t, "node-1", spec.NodeName)
+	assert.True(t, spec.HostPID)
+	assert.True(t, spec.HostIPC)
+	assert.True(t, spec.HostNetwork)
+	assert.Equal(t, corev1.DNSClusterFirstWithHostNet, spec.DNSPolicy)
+	assert.Equal(t, []corev1.Toleration{{Operator: corev1.TolerationOpExists}}, spec.Tolerations)
+
+	require.Len(t, spec.Volumes, 1)
+	require.NotNil(t, spec.Volumes[0].HostPath)
+	assert.Equal(t, "/", spec.Volumes[0].HostPath.Path)
+
+	require.Len(t, spec.Containers, 1)
+	container := spec.Containers[0]
+	assert.Equal(t, "alpine", container.Image)
+	require.NotNil(t, container.SecurityContext)
+	require.NotNil(t, container.SecurityContext.Privileged)
+	assert.True(t, *container.SecurityContext.Privileged)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "host-root", MountPath: "/host"}}, container.VolumeMounts)
+}
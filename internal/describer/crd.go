@@ -132,6 +132,10 @@ func (c *crd) Describe(ctx context.Context, namespace string, options Options) (
 		return component.EmptyContentResponse, err
 	}
 
+	if err := addYAMLEditAction(ctx, yvComponent, object, options); err != nil {
+		return component.EmptyContentResponse, err
+	}
+
 	yvComponent.SetAccessor("yaml")
 	cr.Add(yvComponent)
 
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package describer
+
+import (
+	"context"
+
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+const (
+	// PreviewManifestActionName is the name of the action for previewing
+	// manifests. It is handled by the manifest manager, which runs a
+	// server-side dry run of each object.
+	PreviewManifestActionName = "previewManifest"
+	// ApplyManifestActionName is the name of the action for applying the
+	// manifests which were last previewed.
+	ApplyManifestActionName = "applyManifest"
+)
+
+// Manifest describes a page for applying manifests of one or more objects.
+type Manifest struct {
+	*base
+
+	path  string
+	title string
+}
+
+var _ Describer = (*Manifest)(nil)
+
+// NewManifest creates an instance of Manifest.
+func NewManifest(p, title string) *Manifest {
+	return &Manifest{
+		base:  newBaseDescriber(),
+		path:  p,
+		title: title,
+	}
+}
+
+// Describe creates a card with actions which preview pasted or uploaded
+// manifests and apply the preview. Objects without a namespace are applied
+// in the current namespace.
+func (m *Manifest) Describe(ctx context.Context, namespace string, options Options) (component.ContentResponse, error) {
+	preview := component.Form{
+		Fields: []component.FormField{
+			component.NewFormFieldTextarea("YAML or JSON", "manifest", ""),
+			component.NewFormFieldFile("Files", "files", ".yaml,.yml,.json", true),
+			component.NewFormFieldText("Namespace for objects without one", "namespace", namespace),
+			component.NewFormFieldHidden("action", PreviewManifestActionName),
+		},
+	}
+
+	apply := component.Form{
+		Fields: []component.FormField{
+			component.NewFormFieldCheckBox("Confirm", "confirm", []component.InputChoice{
+				{Label: "Apply the previewed manifests", Value: "true"},
+			}),
+			component.NewFormFieldHidden("action", ApplyManifestActionName),
+		},
+	}
+
+	card := component.NewCard(m.title)
+	card.SetBody(component.NewText("Paste or upload manifests of one or more objects. " +
+		"Each object is checked with a server-side dry run and shown as created, updated or unchanged; " +
+		"nothing is changed until the preview is confirmed."))
+	card.AddAction(component.Action{
+		Name:  PreviewManifestActionName,
+		Title: m.title,
+		Form:  preview,
+	})
+	card.AddAction(component.Action{
+		Name:  ApplyManifestActionName,
+		Title: "Apply Preview",
+		Form:  apply,
+	})
+
+	return component.ContentResponse{
+		Title:      component.Title(component.NewText(m.title)),
+		Components: []component.Component{card},
+	}, nil
+}
+
+// PathFilters returns path filters for the manifest page.
+func (m *Manifest) PathFilters() []PathFilter {
+	return []PathFilter{
+		*NewPathFilter(m.path, m),
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 
+	"github.com/kubenext/kubeon/internal/modules/overview/revisionviewer"
 	"github.com/vmware/octant/internal/api"
 	"github.com/vmware/octant/internal/log"
 	"github.com/vmware/octant/internal/modules/overview/logviewer"
@@ -64,6 +65,7 @@ func NewObject(c ObjectConfig) *Object {
 		{name: "resource viewer", tabFunc: o.addResourceViewerTab},
 		{name: "yaml", tabFunc: o.addYAMLViewerTab},
 		{name: "logs", tabFunc: o.addLogsTab},
+		{name: "revisions", tabFunc: o.addRevisionsTab},
 	}
 
 	return o
@@ -214,12 +216,39 @@ func (d *Object) addYAMLViewerTab(ctx context.Context, object runtime.Object, cr
 		return nil
 	}
 
+	if err := addYAMLEditAction(ctx, yvComponent, object, options); err != nil {
+		return err
+	}
+
 	yvComponent.SetAccessor("yaml")
 	cr.Add(yvComponent)
 	return nil
 
 }
 
+// updateAccess is implemented by object stores which can check if the
+// current user can update an object.
+type updateAccess interface {
+	HasAccess(ctx context.Context, key store.Key, verb string) error
+}
+
+// addYAMLEditAction adds an Edit YAML action to a YAML component if the
+// current user can update the object.
+func addYAMLEditAction(ctx context.Context, yv *component.YAML, object runtime.Object, options Options) error {
+	if access, ok := options.ObjectStore().(updateAccess); ok {
+		key, err := store.KeyFromObject(object)
+		if err != nil {
+			return err
+		}
+
+		if err := access.HasAccess(ctx, key, "update"); err != nil {
+			return nil
+		}
+	}
+
+	return yamlviewer.AddEditAction(yv, object)
+}
+
 func (d *Object) addLogsTab(ctx context.Context, object runtime.Object, cr *component.ContentResponse, options Options) error {
 	if isPod(object) {
 		logsComponent, err := logviewer.ToComponent(object)
@@ -239,3 +268,25 @@ func (d *Object) addLogsTab(ctx context.Context, object runtime.Object, cr *comp
 
 	return nil
 }
+
+func (d *Object) addRevisionsTab(ctx context.Context, object runtime.Object, cr *component.ContentResponse, options Options) error {
+	if !revisionviewer.Supports(object) {
+		return nil
+	}
+
+	revisionsComponent, err := revisionviewer.ToComponent(ctx, object, options.ObjectStore(), options.Queryer)
+	if err != nil {
+		errComponent := component.NewError(component.TitleFromString("Revisions"), err)
+		cr.Add(errComponent)
+
+		logger := log.From(ctx)
+		logger.Errorf("generating revision history: %s", err)
+
+		return nil
+	}
+
+	revisionsComponent.SetAccessor("revisions")
+	cr.Add(revisionsComponent)
+
+	return nil
+}
@@ -249,6 +249,7 @@ func initNamespacedOverview() *Section {
 		NamespacedCRD(),
 		rbacDescriber,
 		eventsDescriber,
+		NewManifest("/apply-yaml", "Apply YAML"),
 	)
 
 	return rootDescriber
@@ -0,0 +1,192 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sJSON "k8s.io/apimachinery/pkg/runtime/serializer/json"
+)
+
+const (
+	// contextLines is the number of unchanged lines shown around changes.
+	contextLines = 3
+)
+
+// Text returns a unified diff of two texts. It is blank if they are the same.
+func Text(fromName, toName, from, to string) (string, error) {
+	if from == to {
+		return "", nil
+	}
+
+	d := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  contextLines,
+	}
+
+	s, err := difflib.GetUnifiedDiffString(d)
+	if err != nil {
+		return "", errors.Wrap(err, "diff")
+	}
+
+	return s, nil
+}
+
+// Objects returns a unified diff of two objects encoded as YAML. Server
+// managed metadata which changes on every update is left out.
+func Objects(fromName, toName string, from, to runtime.Object) (string, error) {
+	a, err := toYAML(from)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := toYAML(to)
+	if err != nil {
+		return "", err
+	}
+
+	return Text(fromName, toName, a, b)
+}
+
+// Maps returns a unified diff of two maps encoded as YAML.
+func Maps(fromName, toName string, from, to map[string]interface{}) (string, error) {
+	return Objects(fromName, toName, &unstructured.Unstructured{Object: from}, &unstructured.Unstructured{Object: to})
+}
+
+// Change is a field which differs between two maps. From is nil if the field
+// was added, and To is nil if it was removed.
+type Change struct {
+	Path string      `json:"path"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+// Fields returns the fields which differ between two maps, sorted by path.
+// Items in lists of named objects, such as containers, are matched by name,
+// so their paths look like "spec.containers[nginx].image".
+func Fields(from, to map[string]interface{}) []Change {
+	var changes []Change
+	compareFields("", from, to, &changes)
+	return changes
+}
+
+func compareFields(path string, from, to interface{}, changes *[]Change) {
+	switch a := from.(type) {
+	case map[string]interface{}:
+		if b, ok := to.(map[string]interface{}); ok {
+			for _, key := range mapKeys(a, b) {
+				compareFields(joinPath(path, key), a[key], b[key], changes)
+			}
+			return
+		}
+	case []interface{}:
+		if b, ok := to.([]interface{}); ok {
+			aNamed, aOK := namedItems(a)
+			bNamed, bOK := namedItems(b)
+
+			switch {
+			case aOK && bOK:
+				for _, name := range mapKeys(aNamed, bNamed) {
+					compareFields(fmt.Sprintf("%s[%s]", path, name), aNamed[name], bNamed[name], changes)
+				}
+				return
+			case len(a) == len(b):
+				for i := range a {
+					compareFields(fmt.Sprintf("%s[%d]", path, i), a[i], b[i], changes)
+				}
+				return
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, Change{Path: path, From: from, To: to})
+	}
+}
+
+// namedItems indexes a list by the name of its items. It returns false if an
+// item isn't an object with a unique name.
+func namedItems(list []interface{}) (map[string]interface{}, bool) {
+	items := make(map[string]interface{})
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		name, ok := m["name"].(string)
+		if !ok || name == "" {
+			return nil, false
+		}
+		if _, ok := items[name]; ok {
+			return nil, false
+		}
+
+		items[name] = m
+	}
+
+	return items, true
+}
+
+func mapKeys(a, b map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []map[string]interface{}{a, b} {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// toYAML encodes an object as YAML without fields that change on every update.
+func toYAML(object runtime.Object) (string, error) {
+	if object == nil {
+		return "", nil
+	}
+
+	// unstructured objects are converted without a copy
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object.DeepCopyObject())
+	if err != nil {
+		return "", errors.Wrap(err, "convert object")
+	}
+
+	u := &unstructured.Unstructured{Object: m}
+	unstructured.RemoveNestedField(u.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(u.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(u.Object, "metadata", "generation")
+
+	serializer := k8sJSON.NewYAMLSerializer(k8sJSON.DefaultMetaFactory, nil, nil)
+
+	var sb strings.Builder
+	if err := serializer.Encode(u, &sb); err != nil {
+		return "", errors.Wrap(err, "encode object as YAML")
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestText(t *testing.T) {
+	got, err := Text("live", "edited", "a\nb\n", "a\nb\n")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = Text("live", "edited", "a\nb\n", "a\nc\n")
+	require.NoError(t, err)
+
+	expected := "--- live\n+++ edited\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n"
+	assert.Equal(t, expected, got)
+}
+
+func TestObjects(t *testing.T) {
+	from := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":            "settings",
+			"resourceVersion": "1",
+			"generation":      int64(1),
+		},
+		"data": map[string]interface{}{"key": "old"},
+	}}
+
+	to := from.DeepCopy()
+	to.SetResourceVersion("2")
+	to.SetGeneration(2)
+
+	// server managed metadata isn't part of the diff
+	got, err := Objects("live", "edited", from, to)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	require.NoError(t, unstructured.SetNestedField(to.Object, "new", "data", "key"))
+
+	got, err = Objects("live", "edited", from, to)
+	require.NoError(t, err)
+	assert.Contains(t, got, "-  key: old\n+  key: new\n")
+	assert.NotContains(t, got, "resourceVersion")
+
+	// from and to aren't changed
+	assert.Equal(t, "1", from.GetResourceVersion())
+}
+
+func TestFields(t *testing.T) {
+	from := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "web", "tier": "frontend"},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "web", "image": "web:1"},
+				map[string]interface{}{"name": "sidecar", "image": "proxy:1"},
+			},
+			"args": []interface{}{"a", "b"},
+		},
+	}
+	to := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "web", "track": "stable"},
+		},
+		"spec": map[string]interface{}{
+			// named items are matched by name rather than position
+			"containers": []interface{}{
+				map[string]interface{}{"name": "sidecar", "image": "proxy:1"},
+				map[string]interface{}{"name": "web", "image": "web:2"},
+			},
+			"args": []interface{}{"a", "c", "d"},
+		},
+	}
+
+	expected := []Change{
+		{Path: "metadata.labels.tier", From: "frontend"},
+		{Path: "metadata.labels.track", To: "stable"},
+		{Path: "spec.args", From: []interface{}{"a", "b"}, To: []interface{}{"a", "c", "d"}},
+		{Path: "spec.containers[web].image", From: "web:1", To: "web:2"},
+	}
+	assert.Equal(t, expected, Fields(from, to))
+
+	assert.Empty(t, Fields(from, from))
+}
+
+func TestMaps(t *testing.T) {
+	from := map[string]interface{}{"spec": map[string]interface{}{"image": "web:1"}}
+	to := map[string]interface{}{"spec": map[string]interface{}{"image": "web:2"}}
+
+	got, err := Maps("revision 1", "revision 2", from, to)
+	require.NoError(t, err)
+	assert.Contains(t, got, "--- revision 1\n+++ revision 2\n")
+	assert.Contains(t, got, "-  image: web:1\n+  image: web:2\n")
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package drain cordons nodes and drains them by evicting their pods.
+package drain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// OperationCordon marks a node as unschedulable.
+	OperationCordon = "cordon"
+	// OperationUncordon marks a node as schedulable.
+	OperationUncordon = "uncordon"
+	// OperationDrain cordons a node and evicts its pods.
+	OperationDrain = "drain"
+
+	// OptionIgnoreDaemonSets is the drain option for skipping daemon set pods.
+	OptionIgnoreDaemonSets = "ignoreDaemonSets"
+	// OptionDeleteEmptyDirData is the drain option for evicting pods with emptyDir volumes.
+	OptionDeleteEmptyDirData = "deleteEmptyDirData"
+
+	// DefaultTimeout is how long a drain waits for pods to be evicted.
+	DefaultTimeout = 5 * time.Minute
+
+	// mirrorPodAnnotation is set on pods created by the kubelet from static
+	// manifests. They can't be evicted.
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+	// evictionRetryInterval is how often an eviction blocked by a pod
+	// disruption budget is retried.
+	evictionRetryInterval = 5 * time.Second
+	// deletePollInterval is how often an evicted pod is checked for deletion.
+	deletePollInterval = time.Second
+)
+
+// Options are options for draining a node.
+type Options struct {
+	// IgnoreDaemonSets skips pods managed by daemon sets instead of failing.
+	IgnoreDaemonSets bool
+	// DeleteEmptyDirData evicts pods with emptyDir volumes, whose data is lost.
+	DeleteEmptyDirData bool
+	// GracePeriodSeconds overrides the termination grace period of pods. Pods
+	// use their own grace period if it is negative.
+	GracePeriodSeconds int64
+	// Timeout is how long to wait for pods to be evicted.
+	Timeout time.Duration
+}
+
+// Progress is reported for each pod while a node drains.
+type Progress struct {
+	Pod     string
+	Message string
+	// Blocked is true if a pod disruption budget blocks the eviction.
+	Blocked bool
+}
+
+// Cordon marks a node as unschedulable, or schedulable if unschedulable is false.
+func Cordon(kubeClient kubernetes.Interface, name string, unschedulable bool) error {
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+
+	_, err := kubeClient.CoreV1().Nodes().Patch(name, types.MergePatchType, []byte(patch))
+	return errors.Wrapf(err, "update node %s", name)
+}
+
+// Drain cordons a node and evicts pods, which are the pods returned by
+// PodsToEvict. Evictions go through the eviction API so pod disruption budgets
+// are respected; blocked evictions are retried until the timeout. report is
+// called as each pod is blocked or evicted.
+func Drain(ctx context.Context, kubeClient kubernetes.Interface, name string, pods []corev1.Pod, options Options, report func(Progress)) (int, error) {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultTimeout
+	}
+
+	if err := Cordon(kubeClient, name, true); err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	syncReport := func(progress Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		report(progress)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(pods))
+	for i := range pods {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = evict(ctx, kubeClient, pods[i], options, syncReport)
+		}(i)
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+
+	if len(failed) > 0 {
+		return len(pods) - len(failed), errors.Errorf("%d of %d pods were not evicted: %s",
+			len(failed), len(pods), strings.Join(failed, "; "))
+	}
+
+	return len(pods), nil
+}
+
+// PodsToEvict returns the pods on a node which have to be evicted. It fails
+// if a pod can't be evicted with the options.
+func PodsToEvict(kubeClient kubernetes.Interface, name string, options Options) ([]corev1.Pod, error) {
+	list, err := kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list pods on node %s", name)
+	}
+
+	var pods []corev1.Pod
+	var problems []string
+
+	for _, pod := range list.Items {
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			continue
+		}
+
+		podName := podName(pod)
+		finished := pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+		controller := metav1.GetControllerOf(&pod)
+
+		if !finished {
+			switch {
+			case controller != nil && controller.Kind == "DaemonSet":
+				if !options.IgnoreDaemonSets {
+					problems = append(problems, fmt.Sprintf("%s is managed by DaemonSet %s", podName, controller.Name))
+				}
+				continue
+			case controller == nil:
+				problems = append(problems, fmt.Sprintf("%s is not managed by a controller and would not be recreated", podName))
+				continue
+			case hasEmptyDir(pod) && !options.DeleteEmptyDirData:
+				problems = append(problems, fmt.Sprintf("%s has emptyDir data which would be lost", podName))
+				continue
+			}
+		}
+
+		pods = append(pods, pod)
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.Errorf("can't drain node %s: %s", name, strings.Join(problems, "; "))
+	}
+
+	return pods, nil
+}
+
+// evict evicts a pod and waits for it to be deleted.
+func evict(ctx context.Context, kubeClient kubernetes.Interface, pod corev1.Pod, options Options, report func(Progress)) error {
+	podName := podName(pod)
+
+	eviction := &policyv1beta1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		},
+	}
+	if options.GracePeriodSeconds >= 0 {
+		gracePeriod := options.GracePeriodSeconds
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod}
+	}
+
+	blocked := false
+	err := wait.PollImmediateUntil(evictionRetryInterval, func() (bool, error) {
+		err := kubeClient.PolicyV1beta1().Evictions(pod.Namespace).Evict(eviction)
+		switch {
+		case err == nil, kerrors.IsNotFound(err):
+			return true, nil
+		case kerrors.IsTooManyRequests(err):
+			if !blocked {
+				blocked = true
+				report(Progress{Pod: podName, Message: "eviction is blocked by a pod disruption budget; retrying", Blocked: true})
+			}
+			return false, nil
+		default:
+			return false, err
+		}
+	}, ctx.Done())
+	if err == wait.ErrWaitTimeout {
+		return errors.Errorf("%s: eviction was still blocked by a pod disruption budget at the timeout", podName)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "evict %s", podName)
+	}
+
+	err = wait.PollImmediateUntil(deletePollInterval, func() (bool, error) {
+		current, err := kubeClient.CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return current.UID != pod.UID, nil
+	}, ctx.Done())
+	if err == wait.ErrWaitTimeout {
+		return errors.Errorf("%s was evicted but not deleted before the timeout", podName)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "wait for %s to be deleted", podName)
+	}
+
+	report(Progress{Pod: podName, Message: "evicted"})
+	return nil
+}
+
+// Namespaces returns the namespaces pods are in, sorted by name.
+func Namespaces(pods []corev1.Pod) []string {
+	seen := make(map[string]bool)
+	var namespaces []string
+	for _, pod := range pods {
+		if !seen[pod.Namespace] {
+			seen[pod.Namespace] = true
+			namespaces = append(namespaces, pod.Namespace)
+		}
+	}
+
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+func hasEmptyDir(pod corev1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func podName(pod corev1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
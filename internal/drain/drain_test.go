@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package drain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func pod(namespace, name, controllerKind string) *corev1.Pod {
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+
+	if controllerKind != "" {
+		controller := true
+		p.OwnerReferences = []metav1.OwnerReference{
+			{APIVersion: "apps/v1", Kind: controllerKind, Name: "owner", Controller: &controller},
+		}
+	}
+
+	return p
+}
+
+func withEmptyDir(p *corev1.Pod) *corev1.Pod {
+	p.Spec.Volumes = []corev1.Volume{
+		{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}
+	return p
+}
+
+func withPhase(p *corev1.Pod, phase corev1.PodPhase) *corev1.Pod {
+	p.Status.Phase = phase
+	return p
+}
+
+func mirror(p *corev1.Pod) *corev1.Pod {
+	p.Annotations = map[string]string{mirrorPodAnnotation: "hash"}
+	return p
+}
+
+func TestPodsToEvict(t *testing.T) {
+	tests := []struct {
+		name     string
+		pods     []runtime.Object
+		options  Options
+		expected []string
+		isErr    bool
+	}{
+		{
+			name: "managed pods",
+			pods: []runtime.Object{
+				pod("a", "web", "ReplicaSet"),
+				pod("b", "db", "StatefulSet"),
+			},
+			expected: []string{"a/web", "b/db"},
+		},
+		{
+			name: "daemon set pods fail",
+			pods: []runtime.Object{
+				pod("a", "web", "ReplicaSet"),
+				pod("kube-system", "proxy", "DaemonSet"),
+			},
+			isErr: true,
+		},
+		{
+			name: "daemon set pods are skipped when ignored",
+			pods: []runtime.Object{
+				pod("a", "web", "ReplicaSet"),
+				pod("kube-system", "proxy", "DaemonSet"),
+			},
+			options:  Options{IgnoreDaemonSets: true},
+			expected: []string{"a/web"},
+		},
+		{
+			name: "mirror pods are skipped",
+			pods: []runtime.Object{
+				pod("a", "web", "ReplicaSet"),
+				mirror(pod("kube-system", "apiserver", "")),
+			},
+			expected: []string{"a/web"},
+		},
+		{
+			name: "unmanaged pods fail",
+			pods: []runtime.Object{
+				pod("a", "web", "ReplicaSet"),
+				pod("a", "scratch", ""),
+			},
+			isErr: true,
+		},
+		{
+			name: "emptyDir pods fail",
+			pods: []runtime.Object{
+				withEmptyDir(pod("a", "web", "ReplicaSet")),
+			},
+			isErr: true,
+		},
+		{
+			name: "emptyDir pods are evicted when their data can be deleted",
+			pods: []runtime.Object{
+				withEmptyDir(pod("a", "web", "ReplicaSet")),
+			},
+			options:  Options{DeleteEmptyDirData: true},
+			expected: []string{"a/web"},
+		},
+		{
+			name: "finished pods are evicted",
+			pods: []runtime.Object{
+				withPhase(pod("a", "job", ""), corev1.PodSucceeded),
+				withPhase(withEmptyDir(pod("a", "failed", "")), corev1.PodFailed),
+			},
+			expected: []string{"a/failed", "a/job"},
+		},
+		{
+			name: "no pods",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset(test.pods...)
+
+			pods, err := PodsToEvict(kubeClient, "node", test.options)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var got []string
+			for _, p := range pods {
+				got = append(got, podName(p))
+			}
+			assert.ElementsMatch(t, test.expected, got)
+		})
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	pods := []corev1.Pod{
+		*pod("b", "web", "ReplicaSet"),
+		*pod("a", "web", "ReplicaSet"),
+		*pod("b", "db", "StatefulSet"),
+	}
+
+	assert.Equal(t, []string{"a", "b"}, Namespaces(pods))
+	assert.Empty(t, Namespaces(nil))
+}
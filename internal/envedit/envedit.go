@@ -0,0 +1,287 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package envedit edits the environment variables and environment sources of containers.
+package envedit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// KindConfigMap is the kind of references to config maps.
+	KindConfigMap = "ConfigMap"
+	// KindSecret is the kind of references to secrets.
+	KindSecret = "Secret"
+)
+
+// Reference refers to a config map or secret, or one of their keys if Key is set.
+type Reference struct {
+	Kind string
+	Name string
+	Key  string
+}
+
+// Value encodes a reference as a form choice value.
+func (r Reference) Value() string {
+	if r.Key == "" {
+		return r.Kind + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Name + "/" + r.Key
+}
+
+// Label describes a reference for a form choice.
+func (r Reference) Label() string {
+	if r.Key == "" {
+		return fmt.Sprintf("%s %s", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s %s: %s", r.Kind, r.Name, r.Key)
+}
+
+// ParseReference decodes a form choice value created by Value.
+func ParseReference(value string) (Reference, error) {
+	parts := strings.SplitN(value, "/", 3)
+	if len(parts) < 2 || (parts[0] != KindConfigMap && parts[0] != KindSecret) || parts[1] == "" {
+		return Reference{}, errors.Errorf("invalid reference %q", value)
+	}
+
+	r := Reference{Kind: parts[0], Name: parts[1]}
+	if len(parts) == 3 {
+		r.Key = parts[2]
+	}
+	return r, nil
+}
+
+// Edit describes changes to the environment of a container.
+type Edit struct {
+	// Variables are the variables with plain values. Plain variables which
+	// aren't included are removed.
+	Variables []corev1.EnvVar
+	// Keep are the names of variables with values from references which are
+	// kept. Other variables with references are removed.
+	Keep []string
+	// Reference adds a variable with a value from a config map or secret
+	// key. It replaces a variable with the same name.
+	Reference *corev1.EnvVar
+	// Sources are the config maps and secrets all keys are loaded from.
+	Sources []Reference
+}
+
+// FormatVariables formats the variables with plain values for editing as
+// one NAME=value per line. Values with line breaks, a leading quote or
+// leading or trailing whitespace are quoted so they survive editing.
+func FormatVariables(env []corev1.EnvVar) string {
+	var sb strings.Builder
+	for _, envVar := range env {
+		if envVar.ValueFrom == nil {
+			value := envVar.Value
+			if needsQuote(value) {
+				value = strconv.Quote(value)
+			}
+			fmt.Fprintf(&sb, "%s=%s\n", envVar.Name, value)
+		}
+	}
+	return sb.String()
+}
+
+func needsQuote(value string) bool {
+	return strings.ContainsAny(value, "\n\r") ||
+		strings.HasPrefix(value, `"`) ||
+		strings.TrimSpace(value) != value
+}
+
+// ParseVariables parses variables in the format written by FormatVariables.
+// Blank lines are ignored, and values which start with a quote are unquoted.
+func ParseVariables(s string) ([]corev1.EnvVar, error) {
+	var env []corev1.EnvVar
+	seen := make(map[string]bool)
+
+	for i, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(strings.TrimRight(line, "\r"), "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("line %d: expected NAME=value: %q", i+1, line)
+		}
+
+		name := strings.TrimSpace(parts[0])
+		if err := ValidateName(name); err != nil {
+			return nil, errors.Wrapf(err, "line %d", i+1)
+		}
+
+		if seen[name] {
+			return nil, errors.Errorf("line %d: %q is set more than once", i+1, name)
+		}
+		seen[name] = true
+
+		value := parts[1]
+		if strings.HasPrefix(value, `"`) {
+			unquoted, err := strconv.Unquote(strings.TrimSpace(value))
+			if err != nil {
+				return nil, errors.Errorf("line %d: invalid quoted value for %q", i+1, name)
+			}
+			value = unquoted
+		}
+
+		env = append(env, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	return env, nil
+}
+
+// ValidateName validates an environment variable name.
+func ValidateName(name string) error {
+	if errs := validation.IsEnvVarName(name); len(errs) > 0 {
+		return errors.Errorf("invalid variable name %q: %s", name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// NewReference creates a variable with its value from a config map or secret key.
+func NewReference(name string, reference Reference) (corev1.EnvVar, error) {
+	if err := ValidateName(name); err != nil {
+		return corev1.EnvVar{}, err
+	}
+	if reference.Key == "" {
+		return corev1.EnvVar{}, errors.Errorf("%s %s: no key", reference.Kind, reference.Name)
+	}
+
+	envVar := corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{}}
+	selector := corev1.LocalObjectReference{Name: reference.Name}
+
+	if reference.Kind == KindSecret {
+		envVar.ValueFrom.SecretKeyRef = &corev1.SecretKeySelector{LocalObjectReference: selector, Key: reference.Key}
+	} else {
+		envVar.ValueFrom.ConfigMapKeyRef = &corev1.ConfigMapKeySelector{LocalObjectReference: selector, Key: reference.Key}
+	}
+
+	return envVar, nil
+}
+
+// DescribeValueFrom describes where the value of a variable comes from.
+func DescribeValueFrom(source *corev1.EnvVarSource) string {
+	switch {
+	case source == nil:
+		return ""
+	case source.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("ConfigMap %s: %s", source.ConfigMapKeyRef.Name, source.ConfigMapKeyRef.Key)
+	case source.SecretKeyRef != nil:
+		return fmt.Sprintf("Secret %s: %s", source.SecretKeyRef.Name, source.SecretKeyRef.Key)
+	case source.FieldRef != nil:
+		return fmt.Sprintf("field %s", source.FieldRef.FieldPath)
+	case source.ResourceFieldRef != nil:
+		return fmt.Sprintf("resource %s", source.ResourceFieldRef.Resource)
+	default:
+		return "reference"
+	}
+}
+
+// SourceReference returns the config map or secret of an environment source.
+func SourceReference(source corev1.EnvFromSource) (Reference, bool) {
+	switch {
+	case source.ConfigMapRef != nil:
+		return Reference{Kind: KindConfigMap, Name: source.ConfigMapRef.Name}, true
+	case source.SecretRef != nil:
+		return Reference{Kind: KindSecret, Name: source.SecretRef.Name}, true
+	default:
+		return Reference{}, false
+	}
+}
+
+// Apply applies an edit to a container. Variables keep their order so
+// $(NAME) expansions still refer to earlier variables; new variables are
+// added at the end.
+func Apply(container *corev1.Container, edit Edit) {
+	plain := make(map[string]corev1.EnvVar)
+	for _, envVar := range edit.Variables {
+		plain[envVar.Name] = envVar
+	}
+
+	keep := make(map[string]bool)
+	for _, name := range edit.Keep {
+		keep[name] = true
+	}
+
+	var env []corev1.EnvVar
+	added := make(map[string]bool)
+
+	for _, envVar := range container.Env {
+		switch {
+		case edit.Reference != nil && envVar.Name == edit.Reference.Name:
+			env = append(env, *edit.Reference)
+		case hasName(plain, envVar.Name):
+			env = append(env, plain[envVar.Name])
+		case envVar.ValueFrom == nil || !keep[envVar.Name]:
+			continue
+		default:
+			env = append(env, envVar)
+		}
+		added[envVar.Name] = true
+	}
+
+	for _, envVar := range edit.Variables {
+		if !added[envVar.Name] && (edit.Reference == nil || envVar.Name != edit.Reference.Name) {
+			env = append(env, envVar)
+			added[envVar.Name] = true
+		}
+	}
+
+	if edit.Reference != nil && !added[edit.Reference.Name] {
+		env = append(env, *edit.Reference)
+	}
+
+	container.Env = env
+	container.EnvFrom = applySources(container.EnvFrom, edit.Sources)
+}
+
+func hasName(env map[string]corev1.EnvVar, name string) bool {
+	_, ok := env[name]
+	return ok
+}
+
+// applySources sets the environment sources of a container. Existing
+// sources keep their prefix and options.
+func applySources(current []corev1.EnvFromSource, sources []Reference) []corev1.EnvFromSource {
+	selected := make(map[Reference]bool)
+	for _, source := range sources {
+		selected[source] = true
+	}
+
+	var envFrom []corev1.EnvFromSource
+	kept := make(map[Reference]bool)
+
+	for _, source := range current {
+		reference, ok := SourceReference(source)
+		if ok && !selected[reference] {
+			continue
+		}
+		envFrom = append(envFrom, source)
+		kept[reference] = true
+	}
+
+	for _, source := range sources {
+		if kept[source] {
+			continue
+		}
+
+		selector := corev1.LocalObjectReference{Name: source.Name}
+		if source.Kind == KindSecret {
+			envFrom = append(envFrom, corev1.EnvFromSource{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: selector}})
+		} else {
+			envFrom = append(envFrom, corev1.EnvFromSource{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: selector}})
+		}
+		kept[source] = true
+	}
+
+	return envFrom
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package envedit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestFormatParseVariables(t *testing.T) {
+	env := []corev1.EnvVar{
+		{Name: "PLAIN", Value: "value"},
+		{Name: "EMPTY", Value: ""},
+		{Name: "EQUALS", Value: "a=b"},
+		{Name: "MULTILINE", Value: "line one\nline two\n"},
+		{Name: "CRLF", Value: "one\r\ntwo"},
+		{Name: "QUOTED", Value: `"quoted"`},
+		{Name: "PADDED", Value: "  padded "},
+		{Name: "CERT", Value: "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"},
+	}
+
+	got, err := ParseVariables(FormatVariables(env))
+	require.NoError(t, err)
+
+	assert.Equal(t, env, got)
+}
+
+func TestFormatVariables_skipsReferences(t *testing.T) {
+	env := []corev1.EnvVar{
+		{Name: "PLAIN", Value: "value"},
+		{Name: "FROM_SECRET", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{Key: "password"},
+		}},
+	}
+
+	assert.Equal(t, "PLAIN=value\n", FormatVariables(env))
+}
+
+func TestParseVariables_invalidQuote(t *testing.T) {
+	_, err := ParseVariables("NAME=\"unterminated\n")
+	require.Error(t, err)
+}
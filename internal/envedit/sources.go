@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package envedit
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// Available lists the config maps and secrets in a namespace, and their keys.
+// Only key names are read; secret values are not.
+func Available(ctx context.Context, objectStore store.Store, namespace string) (sources []Reference, keys []Reference, err error) {
+	for _, kind := range []string{KindConfigMap, KindSecret} {
+		list, _, err := objectStore.List(ctx, store.Key{Namespace: namespace, ApiVersion: "v1", Kind: kind})
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "list %s", kind)
+		}
+
+		for i := range list.Items {
+			name := list.Items[i].GetName()
+			sources = append(sources, Reference{Kind: kind, Name: name})
+
+			for _, key := range dataKeys(&list.Items[i]) {
+				keys = append(keys, Reference{Kind: kind, Name: name, Key: key})
+			}
+		}
+	}
+
+	return sources, keys, nil
+}
+
+func dataKeys(object *unstructured.Unstructured) []string {
+	var keys []string
+	for _, field := range []string{"data", "binaryData"} {
+		m, _, _ := unstructured.NestedMap(object.Object, field)
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
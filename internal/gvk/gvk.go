@@ -14,6 +14,7 @@ var (
 	ClusterRoleBinding       = schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRoleBinding"}
 	ClusterRole              = schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}
 	ConfigMap                = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	ControllerRevision       = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ControllerRevision"}
 	CronJob                  = schema.GroupVersionKind{Group: "batch", Version: "v1beta1", Kind: "CronJob"}
 	CustomResourceDefinition = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition"}
 	DaemonSet                = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DaemonSet"}
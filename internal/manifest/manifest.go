@@ -0,0 +1,263 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package manifest applies manifests of one or more objects with server-side
+// apply.
+package manifest
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubenext/kubeon/internal/diff"
+	"github.com/kubenext/kubeon/internal/objectedit"
+)
+
+const (
+	// ActionCreate is the action for an object which doesn't exist yet.
+	ActionCreate = "create"
+	// ActionUpdate is the action for an object which is changed.
+	ActionUpdate = "update"
+	// ActionUnchanged is the action for an object which is already up to date.
+	ActionUnchanged = "unchanged"
+
+	// FieldManager is the manager of the fields set by applied manifests.
+	FieldManager = "kubeon"
+)
+
+// Client finds resources and creates clients for them. It is satisfied by
+// cluster.ClientInterface.
+type Client interface {
+	Resource(gk schema.GroupKind) (schema.GroupVersionResource, error)
+	DynamicClient() (dynamic.Interface, error)
+	DiscoveryClient() (discovery.DiscoveryInterface, error)
+}
+
+// Result is the result of applying an object. Diff is a diff from the live
+// object to the applied object, and is blank if the object is unchanged.
+type Result struct {
+	Target objectedit.Target
+	Action string
+	Diff   string
+	Err    error
+}
+
+// Parse parses manifests. Each manifest contains YAML documents separated by
+// "---" or JSON objects. Empty documents are skipped and lists are expanded
+// into their items.
+func Parse(manifests []string) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+
+	for i, manifest := range manifests {
+		decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+
+		for {
+			var m map[string]interface{}
+			err := decoder.Decode(&m)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, "parse manifest %d", i+1)
+			}
+			if len(m) == 0 {
+				continue
+			}
+
+			object := &unstructured.Unstructured{Object: m}
+			if !object.IsList() {
+				objects = append(objects, object)
+				continue
+			}
+
+			list, err := object.ToList()
+			if err != nil {
+				return nil, errors.Wrapf(err, "parse manifest %d", i+1)
+			}
+			for j := range list.Items {
+				objects = append(objects, &list.Items[j])
+			}
+		}
+	}
+
+	if len(objects) == 0 {
+		return nil, errors.New("manifest is empty")
+	}
+
+	for i, object := range objects {
+		switch {
+		case object.GetAPIVersion() == "":
+			return nil, errors.Errorf("object %d: apiVersion is required", i+1)
+		case object.GetKind() == "":
+			return nil, errors.Errorf("object %d: kind is required", i+1)
+		case object.GetName() == "":
+			return nil, errors.Errorf("object %d (%s): metadata.name is required", i+1, object.GetKind())
+		}
+	}
+
+	return objects, nil
+}
+
+// Apply applies objects in order. Objects of namespaced resources without a
+// namespace are applied in namespace. With dryRun, the cluster validates
+// and defaults the objects without persisting them. An object which fails
+// doesn't stop the others.
+func Apply(client Client, objects []*unstructured.Unstructured, namespace string, dryRun bool) []Result {
+	r := &resolver{
+		client:    client,
+		resources: make(map[schema.GroupVersion]*metav1.APIResourceList),
+	}
+
+	var results []Result
+	for _, object := range objects {
+		results = append(results, r.apply(object, namespace, dryRun))
+	}
+
+	return results
+}
+
+// resolver resolves resources, and caches which resources are namespaced.
+type resolver struct {
+	client    Client
+	resources map[schema.GroupVersion]*metav1.APIResourceList
+}
+
+func (r *resolver) apply(object *unstructured.Unstructured, namespace string, dryRun bool) Result {
+	result := Result{
+		Target: objectedit.Target{
+			APIVersion: object.GetAPIVersion(),
+			Kind:       object.GetKind(),
+			Namespace:  object.GetNamespace(),
+			Name:       object.GetName(),
+		},
+	}
+
+	client, objectNamespace, err := r.resourceClient(object.GroupVersionKind(), object.GetNamespace(), namespace)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	object = object.DeepCopy()
+	object.SetNamespace(objectNamespace)
+	result.Target.Namespace = objectNamespace
+
+	var live runtime.Object
+	current, err := client.Get(object.GetName(), metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+	case err != nil:
+		result.Err = errors.Wrapf(err, "get %s", result.Target)
+		return result
+	default:
+		live = current
+	}
+
+	data, err := object.MarshalJSON()
+	if err != nil {
+		result.Err = errors.Wrapf(err, "encode %s", result.Target)
+		return result
+	}
+
+	options := metav1.PatchOptions{FieldManager: FieldManager}
+	if dryRun {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+
+	applied, err := client.Patch(object.GetName(), types.ApplyPatchType, data, options)
+	if err != nil {
+		result.Err = errors.Wrapf(err, "apply %s", result.Target)
+		return result
+	}
+
+	result.Diff, err = diff.Objects("live", "applied", live, applied)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	switch {
+	case live == nil:
+		result.Action = ActionCreate
+	case result.Diff == "":
+		result.Action = ActionUnchanged
+	default:
+		result.Action = ActionUpdate
+	}
+
+	return result
+}
+
+// resourceClient returns a client for the resource of gvk, and the namespace
+// an object is applied in. Cluster scoped objects have no namespace.
+func (r *resolver) resourceClient(gvk schema.GroupVersionKind, objectNamespace, namespace string) (dynamic.ResourceInterface, string, error) {
+	mapped, err := r.client.Resource(gvk.GroupKind())
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "find resource for %s", gvk.Kind)
+	}
+
+	// the object is applied at its own version rather than the preferred one
+	gvr := gvk.GroupVersion().WithResource(mapped.Resource)
+
+	namespaced, err := r.isNamespaced(gvr)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dynamicClient, err := r.client.DynamicClient()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !namespaced {
+		return dynamicClient.Resource(gvr), "", nil
+	}
+
+	if objectNamespace == "" {
+		objectNamespace = namespace
+	}
+	if objectNamespace == "" {
+		return nil, "", errors.Errorf("%s is namespaced and no namespace was given", gvk.Kind)
+	}
+
+	return dynamicClient.Resource(gvr).Namespace(objectNamespace), objectNamespace, nil
+}
+
+func (r *resolver) isNamespaced(gvr schema.GroupVersionResource) (bool, error) {
+	gv := gvr.GroupVersion()
+
+	list, ok := r.resources[gv]
+	if !ok {
+		discoveryClient, err := r.client.DiscoveryClient()
+		if err != nil {
+			return false, err
+		}
+
+		list, err = discoveryClient.ServerResourcesForGroupVersion(gv.String())
+		if err != nil {
+			return false, errors.Wrapf(err, "discover resources for %s", gv)
+		}
+		r.resources[gv] = list
+	}
+
+	for _, resource := range list.APIResources {
+		if resource.Name == gvr.Resource {
+			return resource.Namespaced, nil
+		}
+	}
+
+	return false, errors.Errorf("resource %s is not served at %s", gvr.Resource, gv)
+}
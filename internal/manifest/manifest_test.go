@@ -0,0 +1,255 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifests []string
+		expected  []string
+		isErr     bool
+	}{
+		{
+			name: "multiple documents",
+			manifests: []string{`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: prod
+`},
+			expected: []string{"ConfigMap /settings", "Deployment prod/web"},
+		},
+		{
+			name: "json and yaml manifests",
+			manifests: []string{
+				`{"apiVersion":"v1","kind":"Service","metadata":{"name":"web"}}`,
+				"apiVersion: v1\nkind: Namespace\nmetadata:\n  name: prod\n",
+			},
+			expected: []string{"Service /web", "Namespace /prod"},
+		},
+		{
+			name: "lists are expanded",
+			manifests: []string{`
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: a
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: b
+`},
+			expected: []string{"ConfigMap /a", "Secret /b"},
+		},
+		{
+			name: "empty documents are skipped",
+			manifests: []string{`---
+# only a comment
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+`},
+			expected: []string{"ConfigMap /settings"},
+		},
+		{
+			name:      "empty manifests",
+			manifests: []string{"---\n", ""},
+			isErr:     true,
+		},
+		{
+			name:      "missing apiVersion",
+			manifests: []string{"kind: ConfigMap\nmetadata:\n  name: settings\n"},
+			isErr:     true,
+		},
+		{
+			name:      "missing kind",
+			manifests: []string{"apiVersion: v1\nmetadata:\n  name: settings\n"},
+			isErr:     true,
+		},
+		{
+			name:      "missing name",
+			manifests: []string{"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  generateName: settings-\n"},
+			isErr:     true,
+		},
+		{
+			name:      "invalid yaml",
+			manifests: []string{"apiVersion: v1\nkind: [\n"},
+			isErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objects, err := Parse(test.manifests)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var got []string
+			for _, object := range objects {
+				got = append(got, object.GetKind()+" "+object.GetNamespace()+"/"+object.GetName())
+			}
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+type fakeClient struct {
+	resources       map[schema.GroupKind]schema.GroupVersionResource
+	dynamicClient   dynamic.Interface
+	discoveryClient *fakediscovery.FakeDiscovery
+}
+
+var _ Client = (*fakeClient)(nil)
+
+func newFakeClient() *fakeClient {
+	discoveryClient := kubefake.NewSimpleClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	discoveryClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+				{Name: "namespaces", Kind: "Namespace"},
+			},
+		},
+	}
+
+	return &fakeClient{
+		resources: map[schema.GroupKind]schema.GroupVersionResource{
+			{Kind: "ConfigMap"}: {Version: "v1", Resource: "configmaps"},
+			{Kind: "Namespace"}: {Version: "v1", Resource: "namespaces"},
+		},
+		dynamicClient:   dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()),
+		discoveryClient: discoveryClient,
+	}
+}
+
+func (c *fakeClient) Resource(gk schema.GroupKind) (schema.GroupVersionResource, error) {
+	gvr, ok := c.resources[gk]
+	if !ok {
+		return schema.GroupVersionResource{}, errors.Errorf("no resource for %s", gk)
+	}
+	return gvr, nil
+}
+
+func (c *fakeClient) DynamicClient() (dynamic.Interface, error) {
+	return c.dynamicClient, nil
+}
+
+func (c *fakeClient) DiscoveryClient() (discovery.DiscoveryInterface, error) {
+	return c.discoveryClient, nil
+}
+
+func TestResolver_resourceClient(t *testing.T) {
+	configMap := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	namespace := schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}
+
+	tests := []struct {
+		name              string
+		gvk               schema.GroupVersionKind
+		objectNamespace   string
+		namespace         string
+		expectedNamespace string
+		isErr             bool
+	}{
+		{
+			name:              "object namespace",
+			gvk:               configMap,
+			objectNamespace:   "prod",
+			namespace:         "default",
+			expectedNamespace: "prod",
+		},
+		{
+			name:              "default namespace",
+			gvk:               configMap,
+			namespace:         "default",
+			expectedNamespace: "default",
+		},
+		{
+			name:            "cluster scoped",
+			gvk:             namespace,
+			objectNamespace: "prod",
+			namespace:       "default",
+		},
+		{
+			name:  "namespaced without a namespace",
+			gvk:   configMap,
+			isErr: true,
+		},
+		{
+			name:      "unknown kind",
+			gvk:       schema.GroupVersionKind{Version: "v1", Kind: "Widget"},
+			namespace: "default",
+			isErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &resolver{
+				client:    newFakeClient(),
+				resources: make(map[schema.GroupVersion]*metav1.APIResourceList),
+			}
+
+			client, got, err := r.resourceClient(test.gvk, test.objectNamespace, test.namespace)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.NotNil(t, client)
+			assert.Equal(t, test.expectedNamespace, got)
+		})
+	}
+}
+
+func TestResolver_isNamespaced_cachesDiscovery(t *testing.T) {
+	client := newFakeClient()
+	r := &resolver{
+		client:    client,
+		resources: make(map[schema.GroupVersion]*metav1.APIResourceList),
+	}
+
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	for i := 0; i < 2; i++ {
+		namespaced, err := r.isNamespaced(gvr)
+		require.NoError(t, err)
+		assert.True(t, namespaced)
+	}
+
+	assert.Len(t, client.discoveryClient.Actions(), 1)
+}
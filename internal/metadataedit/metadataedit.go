@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package metadataedit edits the labels and annotations of objects.
+package metadataedit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Format formats labels or annotations for editing as one key=value per
+// line. Values which Parse would change, such as values with line breaks or
+// leading or trailing whitespace, are quoted.
+func Format(m map[string]string) string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		value := m[k]
+		if needsQuote(value) {
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(&sb, "%s=%s\n", k, value)
+	}
+
+	return sb.String()
+}
+
+// needsQuote returns true if a value has to be quoted to be parsed back as it
+// is. Parse splits lines and trims whitespace around values, and unquotes
+// values which start with a quote.
+func needsQuote(value string) bool {
+	return strings.ContainsAny(value, "\n\r") ||
+		strings.HasPrefix(value, `"`) ||
+		strings.TrimSpace(value) != value
+}
+
+// Parse parses labels or annotations in the format written by Format.
+// Blank lines are ignored. Keys and values are validated with the
+// Kubernetes syntax rules for labels if labels is true, or annotations.
+func Parse(s string, labels bool) (map[string]string, error) {
+	m := make(map[string]string)
+
+	for i, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("line %d: expected key=value: %q", i+1, line)
+		}
+
+		key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if strings.HasPrefix(value, `"`) {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, errors.Errorf("line %d: invalid quoted value for %q", i+1, key)
+			}
+			value = unquoted
+		}
+
+		if _, ok := m[key]; ok {
+			return nil, errors.Errorf("line %d: %q is set more than once", i+1, key)
+		}
+
+		var err error
+		if labels {
+			err = ValidateLabel(key, value)
+		} else {
+			err = ValidateAnnotationKey(key)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", i+1)
+		}
+
+		m[key] = value
+	}
+
+	if !labels {
+		if err := validateAnnotationSize(m); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// ValidateLabel validates a label key and value.
+func ValidateLabel(key, value string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return errors.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return errors.Errorf("invalid value %q for label %q: %s", value, key, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ValidateAnnotationKey validates an annotation key. Annotation values can
+// be any string.
+func ValidateAnnotationKey(key string) error {
+	if errs := validation.IsQualifiedName(strings.ToLower(key)); len(errs) > 0 {
+		return errors.Errorf("invalid annotation key %q: %s", key, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func validateAnnotationSize(annotations map[string]string) error {
+	var size int
+	for k, v := range annotations {
+		size += len(k) + len(v)
+	}
+
+	if size > apivalidation.TotalAnnotationSizeLimitB {
+		return errors.Errorf("annotations are %d bytes, which is more than the limit of %d bytes",
+			size, apivalidation.TotalAnnotationSizeLimitB)
+	}
+	return nil
+}
+
+// Changes returns the keys which differ between current and edited. Removed
+// keys have a nil value, as they are removed by a JSON merge patch.
+func Changes(current, edited map[string]string) map[string]interface{} {
+	changes := make(map[string]interface{})
+
+	for k, v := range edited {
+		if currentValue, ok := current[k]; !ok || currentValue != v {
+			changes[k] = v
+		}
+	}
+
+	for k := range current {
+		if _, ok := edited[k]; !ok {
+			changes[k] = nil
+		}
+	}
+
+	return changes
+}
+
+// Patch creates a JSON merge patch for label and annotation changes. The
+// patch fails if the object no longer has resourceVersion, so changes made
+// since the object was read are not overwritten.
+func Patch(resourceVersion string, labels, annotations map[string]interface{}) ([]byte, error) {
+	metadata := map[string]interface{}{}
+	if resourceVersion != "" {
+		metadata["resourceVersion"] = resourceVersion
+	}
+	if len(labels) > 0 {
+		metadata["labels"] = labels
+	}
+	if len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": metadata,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal patch")
+	}
+
+	return data, nil
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package metadataedit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		m      map[string]string
+		labels bool
+	}{
+		{
+			name:   "labels",
+			m:      map[string]string{"app": "web", "example.com/tier": "frontend", "empty": ""},
+			labels: true,
+		},
+		{
+			name: "annotations with surrounding whitespace",
+			m: map[string]string{
+				"leading":  "  indented",
+				"trailing": "value\t",
+				"spaces":   " ",
+			},
+		},
+		{
+			name: "annotations with line breaks and quotes",
+			m: map[string]string{
+				"config":  "line one\nline two\r\n",
+				"quoted":  `"already quoted"`,
+				"equals":  "a=b",
+				"unicode": "café",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse(Format(test.m), test.labels)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.m, got)
+		})
+	}
+}
+
+func TestParse_unquoted(t *testing.T) {
+	got, err := Parse("  app = web  \n\nowner=team a\n", false)
+	require.NoError(t, err)
+
+	expected := map[string]string{"app": "web", "owner": "team a"}
+	assert.Equal(t, expected, got)
+}
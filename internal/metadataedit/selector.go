@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package metadataedit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubenext/kubeon/internal/gvk"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// podSelectors are the kinds which select pods by label.
+var podSelectors = []schema.GroupVersionKind{
+	gvk.Service,
+	gvk.AppReplicaSet,
+	gvk.ReplicationController,
+	gvk.Deployment,
+	gvk.StatefulSet,
+	gvk.DaemonSet,
+	gvk.Job,
+}
+
+// Selector is an object which selects pods by label.
+type Selector struct {
+	Kind     string
+	Name     string
+	Selector labels.Selector
+}
+
+func (s Selector) String() string {
+	return fmt.Sprintf("%s %q (%s)", s.Kind, s.Name, s.Selector)
+}
+
+// LostSelectors returns the Services and controllers in a namespace which
+// select a pod with labels from, but not with labels to. Only pods are
+// selected by label, so nothing is returned for other kinds.
+func LostSelectors(ctx context.Context, objectStore store.Store, key store.Key, from, to map[string]string) ([]Selector, error) {
+	if key.ApiVersion != "v1" || key.Kind != "Pod" {
+		return nil, nil
+	}
+
+	var lost []Selector
+	for _, selectorGVK := range podSelectors {
+		apiVersion, kind := selectorGVK.ToAPIVersionAndKind()
+		list, _, err := objectStore.List(ctx, store.Key{
+			Namespace:  key.Namespace,
+			ApiVersion: apiVersion,
+			Kind:       kind,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "list %s", kind)
+		}
+
+		for i := range list.Items {
+			selector, err := podSelector(&list.Items[i])
+			if err != nil {
+				return nil, errors.Wrapf(err, "selector for %s %q", kind, list.Items[i].GetName())
+			}
+
+			if selector.Empty() {
+				continue
+			}
+
+			if selector.Matches(labels.Set(from)) && !selector.Matches(labels.Set(to)) {
+				lost = append(lost, Selector{
+					Kind:     kind,
+					Name:     list.Items[i].GetName(),
+					Selector: selector,
+				})
+			}
+		}
+	}
+
+	return lost, nil
+}
+
+func podSelector(object *unstructured.Unstructured) (labels.Selector, error) {
+	if object.GetKind() == "Service" || object.GetKind() == "ReplicationController" {
+		m, _, err := unstructured.NestedStringMap(object.Object, "spec", "selector")
+		if err != nil {
+			return nil, err
+		}
+		if len(m) == 0 {
+			return labels.Nothing(), nil
+		}
+		return labels.SelectorFromSet(m), nil
+	}
+
+	m, found, err := unstructured.NestedMap(object.Object, "spec", "selector")
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return labels.Nothing(), nil
+	}
+
+	var labelSelector metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &labelSelector); err != nil {
+		return nil, err
+	}
+
+	return metav1.LabelSelectorAsSelector(&labelSelector)
+}
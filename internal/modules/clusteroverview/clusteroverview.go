@@ -130,7 +130,7 @@ func (co *ClusterOverview) ClientRequestHandlers() []octant.ClientRequestHandler
 					return errors.Wrap(err, "convert payload to port forward request")
 				}
 
-				_, err = co.DashConfig.PortForwarder().Create(context.TODO(), req.gvk(), req.Name, req.Namespace, req.Port)
+				_, err = co.DashConfig.PortForwarder().Create(context.TODO(), req.gvk(), req.Name, req.Namespace, req.Port, req.options()...)
 				return err
 			},
 		},
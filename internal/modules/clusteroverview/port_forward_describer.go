@@ -7,6 +7,7 @@ package clusteroverview
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/vmware/octant/internal/describer"
 	"github.com/vmware/octant/internal/portforward"
@@ -29,7 +30,7 @@ func (d *PortForwardListDescriber) Describe(ctx context.Context, namespace strin
 
 	list := component.NewList("Port Forwards", nil)
 
-	tblCols := component.NewTableCols("Name", "Namespace", "Ports", "Age")
+	tblCols := component.NewTableCols("Name", "Namespace", "Ports", "Pod", "Status", "Connections", "Traffic", "Last Error", "Age")
 	tbl := component.NewTable("Port Forwards", "There are no port forwards!", tblCols)
 	list.Add(tbl)
 
@@ -42,10 +43,15 @@ func (d *PortForwardListDescriber) Describe(ctx context.Context, namespace strin
 		}
 
 		pfRow := component.TableRow{
-			"Name":      nameLink,
-			"Namespace": component.NewText(t.Namespace),
-			"Ports":     component.NewPorts(describePortForwardPorts(pf)),
-			"Age":       component.NewTimestamp(pf.CreatedAt),
+			"Name":        nameLink,
+			"Namespace":   component.NewText(t.Namespace),
+			"Ports":       component.NewPorts(describePortForwardPorts(pf)),
+			"Pod":         component.NewText(pf.Pod.Name),
+			"Status":      component.NewText(describePortForwardStatus(pf)),
+			"Connections": component.NewText(describePortForwardConnections(pf)),
+			"Traffic":     component.NewText(describePortForwardTraffic(pf)),
+			"Last Error":  component.NewText(describePortForwardLastError(pf)),
+			"Age":         component.NewTimestamp(pf.CreatedAt),
 		}
 		tbl.Add(pfRow)
 	}
@@ -64,6 +70,71 @@ func (d *PortForwardListDescriber) Reset(ctx context.Context) error {
 	return nil
 }
 
+func describePortForwardStatus(pf portforward.State) string {
+	status := string(pf.Status)
+	if pf.Message != "" {
+		status = fmt.Sprintf("%s: %s", status, pf.Message)
+	}
+	if pf.Reconnects > 0 {
+		status = fmt.Sprintf("%s (reconnected %d times)", status, pf.Reconnects)
+	}
+	return status
+}
+
+// describePortForwardConnections describes the active and total connections
+// of all of a port forward's ports.
+func describePortForwardConnections(pf portforward.State) string {
+	active, total := 0, 0
+	for _, stats := range pf.Stats {
+		active += stats.ActiveConnections
+		total += stats.TotalConnections
+	}
+	return fmt.Sprintf("%d active, %d total", active, total)
+}
+
+// describePortForwardTraffic describes the bytes received from and sent to the pod.
+func describePortForwardTraffic(pf portforward.State) string {
+	var in, out uint64
+	for _, stats := range pf.Stats {
+		in += stats.BytesIn
+		out += stats.BytesOut
+	}
+	return fmt.Sprintf("%s in, %s out", formatBytes(in), formatBytes(out))
+}
+
+// describePortForwardLastError describes the most recent connection error.
+func describePortForwardLastError(pf portforward.State) string {
+	var last portforward.PortStats
+	for _, stats := range pf.Stats {
+		if stats.LastError != "" && stats.LastErrorAt.After(last.LastErrorAt) {
+			last = stats
+		}
+	}
+
+	if last.LastError == "" {
+		return "<none>"
+	}
+
+	if len(pf.Stats) > 1 {
+		return fmt.Sprintf("port %d: %s", last.Remote, last.LastError)
+	}
+	return last.LastError
+}
+
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := uint64(unit), 0
+	for i := n / unit; i >= unit; i /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 func describePortForwardPorts(pf portforward.State) []component.Port {
 	var list []component.Port
 	apiVersion, kind := pf.Target.GVK.ToAPIVersionAndKind()
@@ -73,6 +144,9 @@ func describePortForwardPorts(pf portforward.State) []component.Port {
 		pfs.ID = pf.ID
 		pfs.Port = int(p.Local)
 		pfs.IsForwarded = true
+		pfs.Address = pf.Address
+		pfs.Status = string(pf.Status)
+		pfs.Message = pf.Message
 
 		port := component.NewPort(
 			pf.Target.Namespace,
@@ -23,12 +23,37 @@ var (
 	durContainerUpWait = 1 * time.Second
 )
 
+// LogOptions are options for reading container logs.
+type LogOptions struct {
+	// Follow streams new log lines until the context is cancelled.
+	Follow bool
+	// TailLines is the number of lines from the end of the log to show. All lines
+	// are shown if it is nil.
+	TailLines *int64
+	// SinceTime only shows lines newer than a time. It takes precedence over SinceDuration.
+	SinceTime *time.Time
+	// SinceDuration only shows lines newer than a relative duration.
+	SinceDuration time.Duration
+	// Timestamps prefixes each line with its RFC3339 timestamp.
+	Timestamps bool
+	// Previous shows the logs of the previous instance of the container.
+	Previous bool
+}
+
+// Logs sends a container's logs with timestamps to logCh.
 func Logs(ctx context.Context, client kubernetes.Interface, namespace, podName, container string, logCh chan<- string) error {
+	return LogsWithOptions(ctx, client, namespace, podName, container, LogOptions{Timestamps: true}, logCh)
+}
+
+// LogsWithOptions sends a container's logs to logCh. logCh is closed when the logs
+// have been read or ctx is cancelled. Cancelling ctx closes the upstream stream.
+func LogsWithOptions(ctx context.Context, client kubernetes.Interface, namespace, podName, container string, options LogOptions, logCh chan<- string) error {
 	lp := logPrinter{
 		client:    client,
 		namespace: namespace,
 		podName:   podName,
 		container: container,
+		options:   options,
 	}
 
 	return lp.logs(ctx, logCh)
@@ -40,6 +65,7 @@ type logPrinter struct {
 	namespace string
 	podName   string
 	container string
+	options   LogOptions
 }
 
 func (lp *logPrinter) logs(ctx context.Context, ch chan<- string) error {
@@ -49,7 +75,8 @@ func (lp *logPrinter) logs(ctx context.Context, ch chan<- string) error {
 
 	defer close(ch)
 
-	for ctx.Err() == nil {
+	// the previous instance has terminated, so there is nothing to wait for
+	for !lp.options.Previous && ctx.Err() == nil {
 		hasStarted, err := lp.containerHasStarted()
 		if err != nil {
 			return errors.Wrap(err, "check if container has started")
@@ -59,21 +86,42 @@ func (lp *logPrinter) logs(ctx context.Context, ch chan<- string) error {
 			break
 		}
 
-		time.Sleep(durContainerUpWait)
+		select {
+		case <-ctx.Done():
+		case <-time.After(durContainerUpWait):
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
 	}
 
 	stream, err := lp.stream()
 	if err != nil {
 		return errors.Wrap(err, "stream container logs")
 	}
-	defer stream.Close()
+
+	// closing the stream unblocks the scanner when following logs
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		stream.Close()
+	}()
 
 	scanner := bufio.NewScanner(stream)
-	for ctx.Err() == nil && scanner.Scan() {
-		ch <- scanner.Text()
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ch <- scanner.Text():
+		}
 	}
 
-	if scanner.Err() != nil {
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
 		return errors.Wrap(err, "scanner error")
 	}
 
@@ -92,13 +140,38 @@ func (lp *logPrinter) containerHasStarted() (bool, error) {
 		}
 	}
 
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.Name == lp.container && status.State.Waiting == nil {
+			return true, nil
+		}
+	}
+
 	return false, nil
 }
 
 func (lp *logPrinter) stream() (io.ReadCloser, error) {
-	return lp.client.CoreV1().Pods(lp.namespace).GetLogs(lp.podName, &corev1.PodLogOptions{
-		Container:  lp.container,
-		Follow:     false,
-		Timestamps: true,
-	}).Stream()
+	return lp.client.CoreV1().Pods(lp.namespace).GetLogs(lp.podName, podLogOptions(lp.container, lp.options)).Stream()
+}
+
+func podLogOptions(container string, options LogOptions) *corev1.PodLogOptions {
+	podLogOptions := &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     options.Follow,
+		Timestamps: options.Timestamps,
+		Previous:   options.Previous,
+		TailLines:  options.TailLines,
+	}
+
+	if options.SinceTime != nil {
+		sinceTime := metav1.NewTime(*options.SinceTime)
+		podLogOptions.SinceTime = &sinceTime
+	} else if options.SinceDuration > 0 {
+		sinceSeconds := int64(options.SinceDuration.Round(time.Second).Seconds())
+		if sinceSeconds < 1 {
+			sinceSeconds = 1
+		}
+		podLogOptions.SinceSeconds = &sinceSeconds
+	}
+
+	return podLogOptions
 }
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package container
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPodLogOptions(t *testing.T) {
+	tailLines := int64(50)
+	sinceTime := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	got := podLogOptions("app", LogOptions{
+		Follow:     true,
+		Timestamps: true,
+		Previous:   true,
+		TailLines:  &tailLines,
+	})
+	assert.Equal(t, "app", got.Container)
+	assert.True(t, got.Follow)
+	assert.True(t, got.Timestamps)
+	assert.True(t, got.Previous)
+	assert.Equal(t, &tailLines, got.TailLines)
+	assert.Nil(t, got.SinceTime)
+	assert.Nil(t, got.SinceSeconds)
+
+	// since time takes precedence over since duration
+	got = podLogOptions("app", LogOptions{SinceTime: &sinceTime, SinceDuration: time.Hour})
+	require.NotNil(t, got.SinceTime)
+	assert.True(t, sinceTime.Equal(got.SinceTime.Time))
+	assert.Nil(t, got.SinceSeconds)
+
+	got = podLogOptions("app", LogOptions{SinceDuration: 90 * time.Second})
+	require.NotNil(t, got.SinceSeconds)
+	assert.Equal(t, int64(90), *got.SinceSeconds)
+
+	// durations shorter than a second are rounded up
+	got = podLogOptions("app", LogOptions{SinceDuration: 100 * time.Millisecond})
+	require.NotNil(t, got.SinceSeconds)
+	assert.Equal(t, int64(1), *got.SinceSeconds)
+}
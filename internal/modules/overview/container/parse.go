@@ -0,0 +1,220 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LogFormat is the format of a structured log line.
+type LogFormat string
+
+const (
+	// LogFormatJSON is a JSON object log line.
+	LogFormatJSON LogFormat = "json"
+	// LogFormatLogfmt is a logfmt log line.
+	LogFormatLogfmt LogFormat = "logfmt"
+)
+
+var (
+	levelKeys   = []string{"level", "lvl", "severity", "loglevel", "log.level"}
+	messageKeys = []string{"msg", "message"}
+
+	levelAliases = map[string]string{
+		"trace":       "trace",
+		"debug":       "debug",
+		"dbug":        "debug",
+		"info":        "info",
+		"information": "info",
+		"notice":      "info",
+		"warn":        "warn",
+		"warning":     "warn",
+		"error":       "error",
+		"err":         "error",
+		"eror":        "error",
+		"critical":    "fatal",
+		"crit":        "fatal",
+		"fatal":       "fatal",
+		"panic":       "fatal",
+	}
+)
+
+// StructuredLog is a log line which has been parsed into fields.
+type StructuredLog struct {
+	Format LogFormat
+	Fields map[string]string
+	// Level is the normalized level: trace, debug, info, warn, error or fatal.
+	Level string
+	// Message is the value of the msg or message field.
+	Message string
+}
+
+// ParseStructuredLog parses a JSON or logfmt log line. It returns false if the
+// line is neither. Nested JSON objects are flattened with dotted keys.
+func ParseStructuredLog(line string) (StructuredLog, bool) {
+	line = strings.TrimSpace(line)
+
+	var fields map[string]string
+	var format LogFormat
+
+	if strings.HasPrefix(line, "{") {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			return StructuredLog{}, false
+		}
+		fields = make(map[string]string)
+		flattenJSON("", m, fields)
+		format = LogFormatJSON
+	} else {
+		var ok bool
+		if fields, ok = parseLogfmt(line); !ok {
+			return StructuredLog{}, false
+		}
+		format = LogFormatLogfmt
+	}
+
+	sl := StructuredLog{
+		Format: format,
+		Fields: fields,
+	}
+
+	for _, key := range levelKeys {
+		if v, ok := fields[key]; ok {
+			sl.Level = normalizeLevel(v)
+			break
+		}
+	}
+
+	for _, key := range messageKeys {
+		if v, ok := fields[key]; ok {
+			sl.Message = v
+			break
+		}
+	}
+
+	return sl, true
+}
+
+func flattenJSON(prefix string, m map[string]interface{}, out map[string]string) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		switch t := v.(type) {
+		case map[string]interface{}:
+			flattenJSON(key, t, out)
+		case string:
+			out[key] = t
+		case nil:
+			out[key] = ""
+		case float64:
+			out[key] = strconv.FormatFloat(t, 'f', -1, 64)
+		case bool:
+			out[key] = strconv.FormatBool(t)
+		default:
+			data, err := json.Marshal(t)
+			if err != nil {
+				out[key] = fmt.Sprintf("%v", t)
+				continue
+			}
+			out[key] = string(data)
+		}
+	}
+}
+
+// parseLogfmt parses `key=value key2="quoted value"` pairs. A line is only treated
+// as logfmt if every token is a pair and there are at least two pairs, so prose
+// containing a single `=` is not mistaken for structured output.
+func parseLogfmt(line string) (map[string]string, bool) {
+	fields := make(map[string]string)
+
+	for i := 0; i < len(line); {
+		for i < len(line) && line[i] == ' ' {
+			i++
+		}
+		if i >= len(line) {
+			break
+		}
+
+		start := i
+		for i < len(line) && line[i] != '=' && line[i] != ' ' && line[i] != '"' {
+			i++
+		}
+		if i == start || i >= len(line) || line[i] != '=' {
+			return nil, false
+		}
+		key := line[start:i]
+		i++
+
+		var value string
+		if i < len(line) && line[i] == '"' {
+			end := i + 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return nil, false
+			}
+
+			unquoted, err := strconv.Unquote(line[i : end+1])
+			if err != nil {
+				return nil, false
+			}
+			value = unquoted
+			i = end + 1
+		} else {
+			start = i
+			for i < len(line) && line[i] != ' ' {
+				i++
+			}
+			value = line[start:i]
+		}
+
+		fields[key] = value
+	}
+
+	if len(fields) < 2 {
+		return nil, false
+	}
+
+	return fields, true
+}
+
+// normalizeLevel normalizes level names and numeric bunyan/pino levels.
+func normalizeLevel(level string) string {
+	level = strings.ToLower(strings.TrimSpace(level))
+
+	if n, err := strconv.Atoi(level); err == nil {
+		switch {
+		case n >= 60:
+			return "fatal"
+		case n >= 50:
+			return "error"
+		case n >= 40:
+			return "warn"
+		case n >= 30:
+			return "info"
+		case n >= 20:
+			return "debug"
+		default:
+			return "trace"
+		}
+	}
+
+	if normalized, ok := levelAliases[level]; ok {
+		return normalized
+	}
+
+	return level
+}
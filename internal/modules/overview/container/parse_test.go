@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStructuredLog(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected StructuredLog
+		isOK     bool
+	}{
+		{
+			name: "json",
+			line: `{"level":"INFO","msg":"started","port":8080,"ok":true,"err":null}`,
+			expected: StructuredLog{
+				Format: LogFormatJSON,
+				Fields: map[string]string{
+					"level": "INFO",
+					"msg":   "started",
+					"port":  "8080",
+					"ok":    "true",
+					"err":   "",
+				},
+				Level:   "info",
+				Message: "started",
+			},
+			isOK: true,
+		},
+		{
+			name: "json with nested objects and arrays",
+			line: `  {"log":{"level":"warning"},"message":"slow","tags":["a","b"]}  `,
+			expected: StructuredLog{
+				Format: LogFormatJSON,
+				Fields: map[string]string{
+					"log.level": "warning",
+					"message":   "slow",
+					"tags":      `["a","b"]`,
+				},
+				Level:   "warn",
+				Message: "slow",
+			},
+			isOK: true,
+		},
+		{
+			name: "logfmt",
+			line: `lvl=eror msg="request failed" trace_id=abc123 status=500`,
+			expected: StructuredLog{
+				Format: LogFormatLogfmt,
+				Fields: map[string]string{
+					"lvl":      "eror",
+					"msg":      "request failed",
+					"trace_id": "abc123",
+					"status":   "500",
+				},
+				Level:   "error",
+				Message: "request failed",
+			},
+			isOK: true,
+		},
+		{
+			name: "logfmt with escaped quotes",
+			line: `level=debug msg="said \"hi\""`,
+			expected: StructuredLog{
+				Format: LogFormatLogfmt,
+				Fields: map[string]string{
+					"level": "debug",
+					"msg":   `said "hi"`,
+				},
+				Level:   "debug",
+				Message: `said "hi"`,
+			},
+			isOK: true,
+		},
+		{
+			name: "malformed json",
+			line: `{"level":"info","msg":`,
+		},
+		{
+			name: "prose with a single pair",
+			line: `retrying with timeout=5s`,
+		},
+		{
+			name: "logfmt with an unterminated quote",
+			line: `level=info msg="unterminated`,
+		},
+		{
+			name: "logfmt with a bare word",
+			line: `level=info started msg=ok`,
+		},
+		{
+			name: "plain text",
+			line: `Starting server on :8080`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := ParseStructuredLog(test.line)
+			require.Equal(t, test.isOK, ok)
+			if !test.isOK {
+				return
+			}
+
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestNormalizeLevel(t *testing.T) {
+	tests := []struct {
+		level    string
+		expected string
+	}{
+		{level: "TRACE", expected: "trace"},
+		{level: "dbug", expected: "debug"},
+		{level: " Information ", expected: "info"},
+		{level: "notice", expected: "info"},
+		{level: "WARNING", expected: "warn"},
+		{level: "err", expected: "error"},
+		{level: "crit", expected: "fatal"},
+		{level: "panic", expected: "fatal"},
+		{level: "10", expected: "trace"},
+		{level: "20", expected: "debug"},
+		{level: "30", expected: "info"},
+		{level: "40", expected: "warn"},
+		{level: "50", expected: "error"},
+		{level: "60", expected: "fatal"},
+		{level: "custom", expected: "custom"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.level, func(t *testing.T) {
+			assert.Equal(t, test.expected, normalizeLevel(test.level))
+		})
+	}
+}
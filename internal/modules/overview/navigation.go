@@ -23,6 +23,7 @@ var (
 		"Custom Resources":             "custom-resources",
 		"RBAC":                         "rbac",
 		"Events":                       "events",
+		"Apply YAML":                   "apply-yaml",
 	}
 )
 
@@ -204,6 +204,7 @@ func (co *Overview) Navigation(ctx context.Context, namespace, root string) ([]n
 			"Custom Resources":             navigation.CRDEntries,
 			"RBAC":                         rbacEntries,
 			"Events":                       nil,
+			"Apply YAML":                   nil,
 		},
 		Order: []string{
 			"Workloads",
@@ -212,6 +213,7 @@ func (co *Overview) Navigation(ctx context.Context, namespace, root string) ([]n
 			"Custom Resources",
 			"RBAC",
 			"Events",
+			"Apply YAML",
 		},
 	}
 
@@ -330,6 +332,16 @@ func (co *Overview) ActionPaths() map[string]action.DispatcherFunc {
 		octant.NewDeploymentConfigurationEditor(co.logger, co.dashConfig.ObjectStore()),
 		octant.NewContainerEditor(co.dashConfig.ObjectStore()),
 		octant.NewServiceConfigurationEditor(co.dashConfig.ObjectStore()),
+		octant.NewTerminalStarter(co.dashConfig.ObjectStore(), co.dashConfig.TerminalManager()),
+		octant.NewDebugPodStarter(co.dashConfig),
+		octant.NewScaler(co.dashConfig),
+		octant.NewRolloutOperator(co.dashConfig),
+		octant.NewNodeOperator(co.dashConfig),
+		octant.NewBulkOperator(co.dashConfig),
+		octant.NewMetadataEditor(co.dashConfig),
+		octant.NewCronJobOperator(co.dashConfig),
+		octant.NewSecretEditor(co.dashConfig),
+		octant.NewConfigMapEditor(co.dashConfig),
 	}
 
 	return dispatchers.ToActionPaths()
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -27,6 +28,11 @@ type portForwardCreateRequest struct {
 	Name       string `json:"name,omitempty"`
 	Namespace  string `json:"namespace,omitempty"`
 	Port       uint16 `json:"port,omitempty"`
+	LocalPort  uint16 `json:"localPort,omitempty"`
+	Address    string `json:"address,omitempty"`
+	// IdleTimeout is a duration such as 30m. The port forward is stopped
+	// once it has had no connections for this long.
+	IdleTimeout string `json:"idleTimeout,omitempty"`
 }
 
 func (req *portForwardCreateRequest) Validate() error {
@@ -46,6 +52,10 @@ func (req *portForwardCreateRequest) Validate() error {
 		return errors.New("port must be greater than 0")
 	}
 
+	if _, err := req.idleTimeout(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -53,6 +63,30 @@ func (req *portForwardCreateRequest) gvk() schema.GroupVersionKind {
 	return schema.FromAPIVersionAndKind(req.APIVersion, req.Kind)
 }
 
+func (req *portForwardCreateRequest) idleTimeout() (time.Duration, error) {
+	if req.IdleTimeout == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(req.IdleTimeout)
+	if err != nil {
+		return 0, errors.Wrap(err, "parse idle timeout")
+	}
+
+	return timeout, nil
+}
+
+func (req *portForwardCreateRequest) options() []portforward.CreateOption {
+	// the idle timeout was checked by Validate
+	idleTimeout, _ := req.idleTimeout()
+
+	return []portforward.CreateOption{
+		portforward.WithLocalPort(req.LocalPort),
+		portforward.WithAddress(req.Address),
+		portforward.WithIdleTimeout(idleTimeout),
+	}
+}
+
 type portForwardError struct {
 	code     int
 	message  string
@@ -84,7 +118,14 @@ func createPortForward(ctx context.Context, body io.Reader, pfs portforward.Port
 		}
 	}
 
-	resp, err := pfs.Create(ctx, req.gvk(), req.Name, req.Namespace, req.Port)
+	resp, err := pfs.Create(ctx, req.gvk(), req.Name, req.Namespace, req.Port, req.options()...)
+	if inUse, ok := err.(*portforward.PortInUseError); ok {
+		return &portForwardError{
+			code:     http.StatusConflict,
+			message:  inUse.Error(),
+			extraErr: err,
+		}
+	}
 	if err != nil {
 		return &portForwardError{
 			code:     http.StatusInternalServerError,
@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package revisionviewer shows the revision history of deployments, daemon
+// sets and stateful sets.
+package revisionviewer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubenext/kubeon/internal/queryer"
+	"github.com/kubenext/kubeon/internal/rollout"
+	"github.com/kubenext/kubeon/pkg/store"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+// CompareActionName is the name of the action for comparing two revisions.
+// It is handled by the revision manager, which sends the comparison as an
+// event and summarizes it in an alert.
+const CompareActionName = "compareRevisions"
+
+var (
+	revisionColumns = component.NewTableCols("Revision", "Name", "Change Cause", "Pods", "Images", "Age")
+)
+
+// Supports returns true if an object has a revision history.
+func Supports(object runtime.Object) bool {
+	key, err := store.KeyFromObject(object)
+	if err != nil {
+		return false
+	}
+
+	return rollout.Supported(key.ApiVersion, key.Kind)
+}
+
+// ToComponent creates a revision history component for an object. Any two
+// revisions can be compared with the compare action.
+func ToComponent(ctx context.Context, object runtime.Object, objectStore store.Store, q queryer.Queryer) (component.Component, error) {
+	if object == nil {
+		return nil, errors.New("object is nil")
+	}
+
+	key, err := store.KeyFromObject(object)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert object to unstructured")
+	}
+	u := &unstructured.Unstructured{Object: m}
+	u.SetAPIVersion(key.ApiVersion)
+	u.SetKind(key.Kind)
+
+	revisions, err := rollout.Revisions(ctx, q, u)
+	if err != nil {
+		return nil, errors.Wrap(err, "find revisions")
+	}
+
+	table := component.NewTable("Revision History", "There are no revisions", revisionColumns)
+
+	for _, revision := range revisions {
+		pods, err := rollout.Pods(ctx, objectStore, u, revision)
+		if err != nil {
+			return nil, errors.Wrapf(err, "count pods for revision %d", revision.Number)
+		}
+
+		number := fmt.Sprintf("%d", revision.Number)
+		if revision.Current {
+			number += " (current)"
+		}
+
+		table.Add(component.TableRow{
+			"Revision":     component.NewText(number),
+			"Name":         component.NewText(revision.Name),
+			"Change Cause": component.NewText(revision.ChangeCause),
+			"Pods":         component.NewText(pods.String()),
+			"Images":       component.NewText(strings.Join(revision.Images, ", ")),
+			"Age":          component.NewTimestamp(revision.Created),
+		})
+	}
+
+	card := component.NewCard("Revisions")
+	card.SetBody(table)
+
+	if len(revisions) > 1 {
+		action, err := compareAction(object, revisions)
+		if err != nil {
+			return nil, err
+		}
+		card.AddAction(action)
+	}
+
+	return card, nil
+}
+
+// compareAction creates an action which compares two revisions. It compares
+// the current revision with the one before it by default.
+func compareAction(object runtime.Object, revisions []rollout.Revision) (component.Action, error) {
+	var fromChoices, toChoices []component.InputChoice
+	for i, revision := range revisions {
+		value := fmt.Sprintf("%d", revision.Number)
+		fromChoices = append(fromChoices, component.InputChoice{
+			Label:   revision.Description(),
+			Value:   value,
+			Checked: i == 1,
+		})
+		toChoices = append(toChoices, component.InputChoice{
+			Label:   revision.Description(),
+			Value:   value,
+			Checked: i == 0,
+		})
+	}
+
+	form, err := component.CreateFormForObject(CompareActionName, object,
+		component.NewFormFieldRadio("From", "from", fromChoices),
+		component.NewFormFieldRadio("To", "to", toChoices),
+	)
+	if err != nil {
+		return component.Action{}, errors.Wrap(err, "create compare action")
+	}
+
+	return component.Action{
+		Name:  "Compare",
+		Title: "Compare revisions",
+		Form:  form,
+	}, nil
+}
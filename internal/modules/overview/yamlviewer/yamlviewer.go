@@ -21,6 +21,50 @@ func ToComponent(object runtime.Object) (*component.YAML, error) {
 	return yv.ToComponent()
 }
 
+const (
+	// EditActionName is the name of the action for previewing an edit of an
+	// object's YAML. It is handled by the object edit manager, which shows a
+	// diff from a server-side dry run.
+	EditActionName = "previewObjectEdit"
+	// ApplyEditActionName is the name of the action for applying the edit
+	// which was last previewed for an object.
+	ApplyEditActionName = "applyObjectEdit"
+)
+
+// AddEditAction adds Edit YAML and Apply Edit actions to a YAML component for
+// an object. An edit has to be previewed before it can be applied.
+func AddEditAction(y *component.YAML, object runtime.Object) error {
+	edit, err := component.CreateFormForObject(EditActionName, object,
+		component.NewFormFieldTextarea("YAML", "yaml", y.Config.Data),
+	)
+	if err != nil {
+		return errors.Wrap(err, "create edit YAML form")
+	}
+
+	y.AddAction(component.Action{
+		Name:  EditActionName,
+		Title: "Edit YAML",
+		Form:  edit,
+	})
+
+	apply, err := component.CreateFormForObject(ApplyEditActionName, object,
+		component.NewFormFieldCheckBox("Confirm", "confirm", []component.InputChoice{
+			{Label: "Apply the previewed edit", Value: "true"},
+		}),
+	)
+	if err != nil {
+		return errors.Wrap(err, "create apply edit form")
+	}
+
+	y.AddAction(component.Action{
+		Name:  ApplyEditActionName,
+		Title: "Apply Edit",
+		Form:  apply,
+	})
+
+	return nil
+}
+
 // YAMLViewer is a YAML viewer for objects.
 type yamlViewer struct {
 	object runtime.Object
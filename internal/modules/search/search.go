@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubenext/kubeon/internal/config"
+	"github.com/kubenext/kubeon/internal/core"
+	"github.com/kubenext/kubeon/internal/link"
+	"github.com/kubenext/kubeon/internal/module"
+	objectsearch "github.com/kubenext/kubeon/internal/search"
+	"github.com/kubenext/kubeon/pkg/navigation"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+type Options struct {
+	DashConfig config.Dash
+}
+
+// Search is a module which searches objects in the object store.
+type Search struct {
+	Options
+}
+
+var _ module.Module = (*Search)(nil)
+
+// New creates an instance of Search.
+func New(ctx context.Context, options Options) (*Search, error) {
+	if options.DashConfig == nil {
+		return nil, errors.New("dash configuration is nil")
+	}
+
+	return &Search{
+		Options: options,
+	}, nil
+}
+
+func (s *Search) Name() string {
+	return "search"
+}
+
+func (s *Search) ClientRequestHandlers() []core.ClientRequestHandler {
+	return nil
+}
+
+// Content generates search results. The content path is the url escaped query.
+func (s *Search) Content(ctx context.Context, contentPath string, opts module.ContentOptions) (component.ContentResponse, error) {
+	raw, err := url.PathUnescape(strings.Trim(contentPath, "/"))
+	if err != nil {
+		return component.EmptyContentResponse, errors.Wrap(err, "unescape search query")
+	}
+
+	query := objectsearch.ParseQuery(raw)
+
+	var out component.ContentResponse
+	out.Title = component.Title(component.NewText("Search"))
+
+	if query.IsEmpty() {
+		text := component.NewMarkdownText("Search by name, or use `label:key=value`, `annotation:key=value`, " +
+			"`uid:<uid>`, `image:<image>`, `kind:<kind>` and `namespace:<namespace>`.")
+		out.Components = []component.Component{text}
+		return out, nil
+	}
+
+	source := objectsearch.SourceFor(s.DashConfig.ObjectStore())
+	searcher := objectsearch.NewSearcher(source, s.DashConfig.ClusterClient())
+	results, err := searcher.Search(ctx, query)
+	if err != nil {
+		return component.EmptyContentResponse, errors.Wrap(err, "search objects")
+	}
+
+	linkGenerator, err := link.NewFromDashConfig(s.DashConfig)
+	if err != nil {
+		return component.EmptyContentResponse, err
+	}
+
+	out.Title = component.Title(component.NewText("Search"), component.NewText(query.String()))
+	out.Components = []component.Component{
+		objectsearch.ResultsTable(fmt.Sprintf("Results for %q", query.String()), results, linkGenerator),
+	}
+
+	return out, nil
+}
+
+func (s *Search) ContentPath() string {
+	return s.Name()
+}
+
+func (s *Search) Navigation(ctx context.Context, namespace, root string) ([]navigation.Navigation, error) {
+	return []navigation.Navigation{
+		{
+			Title: "Search",
+			Path:  root,
+		},
+	}, nil
+}
+
+func (s *Search) SetNamespace(namespace string) error {
+	return nil
+}
+
+func (s *Search) Start() error {
+	return nil
+}
+
+func (s *Search) Stop() {
+}
+
+func (s *Search) SetContext(ctx context.Context, contextName string) error {
+	return nil
+}
+
+func (s *Search) Generators() []core.Generator {
+	return []core.Generator{}
+}
+
+func (s *Search) SupportedGroupVersionKind() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{}
+}
+
+func (s *Search) GroupVersionKindPath(namespace, apiVersion, kind, name string) (string, error) {
+	return "", errors.Errorf("search can't create paths for %s %s", apiVersion, kind)
+}
+
+func (s *Search) AddCRD(ctx context.Context, crd *unstructured.Unstructured) error {
+	return nil
+}
+
+func (s *Search) RemoveCRD(ctx context.Context, crd *unstructured.Unstructured) error {
+	return nil
+}
+
+func (s *Search) ResetCRDs(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package objectedit
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubenext/kubeon/internal/diff"
+)
+
+// Target is the object being edited.
+type Target struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+func (t Target) String() string {
+	if t.Namespace == "" {
+		return fmt.Sprintf("%s %q", t.Kind, t.Name)
+	}
+	return fmt.Sprintf("%s %q in %q", t.Kind, t.Name, t.Namespace)
+}
+
+// ConflictError is returned when the object changed after the edited
+// document was read from it.
+type ConflictError struct {
+	Target Target
+	err    error
+}
+
+var _ error = (*ConflictError)(nil)
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s was changed by someone else; reload it and edit again: %v", e.Target, e.err)
+}
+
+// IsConflict returns true if err is a ConflictError.
+func IsConflict(err error) bool {
+	_, ok := errors.Cause(err).(*ConflictError)
+	return ok
+}
+
+// Parse parses an edited YAML document for target. The document must
+// describe target and include the resource version it was read at, so
+// changes made since then are detected instead of overwritten.
+func Parse(data string, target Target) (*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(data), len(data))
+
+	var m map[string]interface{}
+	if err := decoder.Decode(&m); err != nil {
+		if err == io.EOF {
+			return nil, errors.New("document is empty")
+		}
+		return nil, errors.Wrap(err, "parse YAML")
+	}
+
+	for {
+		var extra map[string]interface{}
+		err := decoder.Decode(&extra)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "parse YAML")
+		}
+		if len(extra) > 0 {
+			return nil, errors.New("document contains more than one object")
+		}
+	}
+
+	object := &unstructured.Unstructured{Object: m}
+
+	switch {
+	case object.GetAPIVersion() != target.APIVersion:
+		return nil, errors.Errorf("apiVersion can't be changed from %q", target.APIVersion)
+	case object.GetKind() != target.Kind:
+		return nil, errors.Errorf("kind can't be changed from %q", target.Kind)
+	case object.GetName() != target.Name:
+		return nil, errors.Errorf("name can't be changed from %q", target.Name)
+	case object.GetNamespace() != target.Namespace:
+		return nil, errors.Errorf("namespace can't be changed from %q", target.Namespace)
+	case object.GetResourceVersion() == "":
+		return nil, errors.New("metadata.resourceVersion is required")
+	}
+
+	return object, nil
+}
+
+// Preview runs a server-side dry run update of an edited object and returns
+// a diff from the live object to the result.
+func Preview(client dynamic.ResourceInterface, target Target, edited *unstructured.Unstructured) (string, error) {
+	live, err := client.Get(target.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "get %s", target)
+	}
+
+	result, err := update(client, target, edited, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}})
+	if err != nil {
+		return "", err
+	}
+
+	return diff.Objects("live", "edited", live, result)
+}
+
+// Apply updates an object with an edited object. A ConflictError is returned if
+// the object changed after the edited document was read.
+func Apply(client dynamic.ResourceInterface, target Target, edited *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return update(client, target, edited, metav1.UpdateOptions{})
+}
+
+func update(client dynamic.ResourceInterface, target Target, edited *unstructured.Unstructured, options metav1.UpdateOptions) (*unstructured.Unstructured, error) {
+	result, err := client.Update(edited, options)
+	if err != nil {
+		if kerrors.IsConflict(err) {
+			return nil, &ConflictError{Target: target, err: err}
+		}
+		return nil, errors.Wrapf(err, "update %s", target)
+	}
+
+	return result, nil
+}
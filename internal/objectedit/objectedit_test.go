@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package objectedit
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParse(t *testing.T) {
+	target := Target{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web"}
+
+	tests := []struct {
+		name  string
+		data  string
+		isErr bool
+	}{
+		{
+			name: "yaml",
+			data: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  namespace: default
+  name: web
+  resourceVersion: "42"
+spec:
+  replicas: 2
+`,
+		},
+		{
+			name: "json",
+			data: `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"namespace":"default","name":"web","resourceVersion":"42"}}`,
+		},
+		{
+			name: "trailing empty document",
+			data: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  namespace: default
+  name: web
+  resourceVersion: "42"
+---
+`,
+		},
+		{
+			name:  "empty",
+			data:  "",
+			isErr: true,
+		},
+		{
+			name:  "invalid yaml",
+			data:  "kind: [",
+			isErr: true,
+		},
+		{
+			name: "more than one object",
+			data: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  namespace: default
+  name: web
+  resourceVersion: "42"
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`,
+			isErr: true,
+		},
+		{
+			name:  "kind changed",
+			data:  `{"apiVersion":"apps/v1","kind":"StatefulSet","metadata":{"namespace":"default","name":"web","resourceVersion":"42"}}`,
+			isErr: true,
+		},
+		{
+			name:  "name changed",
+			data:  `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"namespace":"default","name":"api","resourceVersion":"42"}}`,
+			isErr: true,
+		},
+		{
+			name:  "namespace changed",
+			data:  `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"namespace":"other","name":"web","resourceVersion":"42"}}`,
+			isErr: true,
+		},
+		{
+			name:  "no resource version",
+			data:  `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"namespace":"default","name":"web"}}`,
+			isErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse(test.data, target)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, "web", got.GetName())
+			assert.Equal(t, "42", got.GetResourceVersion())
+		})
+	}
+}
+
+func TestIsConflict(t *testing.T) {
+	target := Target{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "settings"}
+	conflict := kerrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "settings", errors.New("changed"))
+
+	err := errors.Wrap(&ConflictError{Target: target, err: conflict}, "apply")
+	assert.True(t, IsConflict(err))
+	assert.Contains(t, err.Error(), `ConfigMap "settings" in "default" was changed by someone else`)
+
+	assert.False(t, IsConflict(errors.New("update failed")))
+}
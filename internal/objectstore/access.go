@@ -11,10 +11,11 @@ import (
 
 // AccessKey is used at a key in an access map, It is made up of a namespace, Group, Resource and Verb.
 type AccessKey struct {
-	Namespace string
-	Group     string
-	Resource  string
-	Verb      string
+	Namespace   string
+	Group       string
+	Resource    string
+	Subresource string
+	Verb        string
 }
 
 type accessMap map[AccessKey]bool
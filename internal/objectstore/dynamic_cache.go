@@ -19,6 +19,7 @@ import (
 	kLabels "k8s.io/apimachinery/pkg/labels"
 	kruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/informers"
 	kcache "k8s.io/client-go/tools/cache"
 	"sync"
@@ -463,6 +464,61 @@ func (dc *DynamicCache) Update(ctx context.Context, key store.Key, updater func(
 	return err
 }
 
+// HasAccess returns an error if the current user is not allowed to perform verb on key.
+func (dc *DynamicCache) HasAccess(ctx context.Context, key store.Key, verb string) error {
+	return dc.access.HasAccess(ctx, key, verb)
+}
+
+// HasSubresourceAccess returns an error if the current user is not allowed to perform
+// verb on a subresource of key.
+func (dc *DynamicCache) HasSubresourceAccess(ctx context.Context, key store.Key, subresource, verb string) error {
+	return dc.access.HasSubresourceAccess(ctx, key, subresource, verb)
+}
+
+// SyncedObjects returns the objects held by every informer that has completed its
+// initial sync. Objects are grouped by the resource the informer watches. Informers
+// which are still syncing are skipped so callers never block on the API server.
+func (dc *DynamicCache) SyncedObjects(ctx context.Context) (map[schema.GroupVersionResource][]*unstructured.Unstructured, error) {
+	_, span := trace.StartSpan(ctx, "dynamicCache:syncedObjects")
+	defer span.End()
+
+	objects := make(map[schema.GroupVersionResource][]*unstructured.Unstructured)
+	seen := make(map[types.UID]bool)
+
+	for _, namespace := range dc.factories.keys() {
+		factory, ok := dc.factories.get(namespace)
+		if !ok || factory == nil {
+			continue
+		}
+
+		for gvr, informer := range factory.Informers() {
+			if !informer.Informer().HasSynced() {
+				continue
+			}
+
+			list, err := informer.Lister().List(kLabels.Everything())
+			if err != nil {
+				return nil, errors.Wrapf(err, "list objects for %s", gvr)
+			}
+
+			for i := range list {
+				object, ok := list[i].(*unstructured.Unstructured)
+				if !ok || seen[object.GetUID()] {
+					continue
+				}
+				seen[object.GetUID()] = true
+				objects[gvr] = append(objects[gvr], object)
+			}
+
+			if _, ok := objects[gvr]; !ok {
+				objects[gvr] = []*unstructured.Unstructured{}
+			}
+		}
+	}
+
+	return objects, nil
+}
+
 func (dc *DynamicCache) IsLoading(ctx context.Context, key store.Key) bool {
 	return !dc.informerSynced.hasSynced(key)
 }
@@ -12,5 +12,9 @@ type AccessError struct {
 }
 
 func (ae *AccessError) Error() string {
-	return fmt.Sprintf("access denied: no %s access in %s to %s/%s", ae.Key.Verb, ae.Key.Namespace, ae.Key.Group, ae.Key.Resource)
+	resource := ae.Key.Resource
+	if ae.Key.Subresource != "" {
+		resource = resource + "/" + ae.Key.Subresource
+	}
+	return fmt.Sprintf("access denied: no %s access in %s to %s/%s", ae.Key.Verb, ae.Key.Namespace, ae.Key.Group, resource)
 }
@@ -18,6 +18,7 @@ import (
 // InformerFactory creates informers.
 type InformerFactory interface {
 	ForResource(gvr schema.GroupVersionResource) informers.GenericInformer
+	Informers() map[schema.GroupVersionResource]informers.GenericInformer
 	Delete(gvr schema.GroupVersionResource)
 	WaitForCacheSync(stopCh <-chan struct{}) map[schema.GroupVersionResource]bool
 }
@@ -68,11 +69,31 @@ func (f *informerFactory) ForResource(gvr schema.GroupVersionResource) informers
 		f.tweakListOptions,
 	)
 
+	// started informers are kept so they are shared, and so their objects
+	// can be read without starting them again
+	f.informers[key] = informer
+
 	stopCh := f.informerContextCache.addChild(gvr)
 	go informer.Informer().Run(stopCh)
 	return informer
 }
 
+// Informers returns the informers which have been started by the factory.
+func (f *informerFactory) Informers() map[schema.GroupVersionResource]informers.GenericInformer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	list := make(map[schema.GroupVersionResource]informers.GenericInformer)
+	for gvr, informer := range f.informers {
+		if informer == nil {
+			continue
+		}
+		list[gvr] = informer
+	}
+
+	return list
+}
+
 // Delete deletes an informer given a a group/version/resource.
 func (f *informerFactory) Delete(gvr schema.GroupVersionResource) {
 	f.lock.Lock()
@@ -81,7 +102,6 @@ func (f *informerFactory) Delete(gvr schema.GroupVersionResource) {
 	if _, ok := f.informers[gvr]; ok {
 		f.informerContextCache.delete(gvr)
 		delete(f.informers, gvr)
-		f.informers[gvr] = nil
 	}
 }
 
@@ -93,6 +113,9 @@ func (f *informerFactory) WaitForCacheSync(stopCh <-chan struct{}) map[schema.Gr
 
 		shared := map[schema.GroupVersionResource]cache.SharedIndexInformer{}
 		for gvr, informer := range f.informers {
+			if informer == nil {
+				continue
+			}
 			shared[gvr] = informer.Informer()
 		}
 		return shared
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package objectstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestInformerFactory(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	factory := newInformerFactory(stopCh, client, time.Minute, "default")
+
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+	informer := factory.ForResource(gvr)
+	require.NotNil(t, informer)
+	assert.Equal(t, informer, factory.ForResource(gvr), "informers are shared")
+
+	informers := factory.Informers()
+	require.Len(t, informers, 1)
+	assert.Equal(t, informer, informers[gvr])
+
+	factory.Delete(gvr)
+	assert.Empty(t, factory.Informers())
+
+	// deleted informers are not waited for
+	closed := make(chan struct{})
+	close(closed)
+	assert.Empty(t, factory.WaitForCacheSync(closed))
+}
@@ -17,6 +17,7 @@ import (
 
 type ResourceAccess interface {
 	HasAccess(ctx context.Context, key store.Key, verb string) error
+	HasSubresourceAccess(ctx context.Context, key store.Key, subresource, verb string) error
 	Reset()
 	Get(key AccessKey) (bool, bool)
 	Set(key AccessKey, value bool)
@@ -39,6 +40,12 @@ func NewResourceAccess(client cluster.ClientInterface) ResourceAccess {
 }
 
 func (r *resourceAccess) HasAccess(ctx context.Context, key store.Key, verb string) error {
+	return r.HasSubresourceAccess(ctx, key, "", verb)
+}
+
+// HasSubresourceAccess returns an error if verb is not allowed on a subresource
+// of key's resource, e.g. create on pods/exec.
+func (r *resourceAccess) HasSubresourceAccess(ctx context.Context, key store.Key, subresource, verb string) error {
 	_, span := trace.StartSpan(ctx, "resourceAccessHasAccess")
 	defer span.End()
 
@@ -46,6 +53,7 @@ func (r *resourceAccess) HasAccess(ctx context.Context, key store.Key, verb stri
 	if err != nil {
 		return err
 	}
+	ak.Subresource = subresource
 
 	access, ok := r.cache.get(ak)
 	if !ok {
@@ -121,10 +129,11 @@ func (r *resourceAccess) fetchAccess(key AccessKey, verb string) (bool, error) {
 	sar := &authorizationv1.SelfSubjectAccessReview{
 		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
 			ResourceAttributes: &authorizationv1.ResourceAttributes{
-				Namespace: key.Namespace,
-				Verb:      verb,
-				Group:     key.Group,
-				Resource:  key.Resource,
+				Namespace:   key.Namespace,
+				Verb:        verb,
+				Group:       key.Group,
+				Resource:    key.Resource,
+				Subresource: key.Subresource,
 			},
 		},
 	}
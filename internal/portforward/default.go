@@ -41,8 +41,22 @@ func Default(ctx context.Context, client cluster.ClientInterface, objectStore st
 		},
 	}
 
+	persistPath, err := DefaultPersistPath()
+	if err != nil {
+		logger.WithErr(err).Warnf("port forwards will not be saved")
+	} else if info, err := client.InfoClient(); err != nil {
+		logger.WithErr(err).Warnf("port forwards will not be saved")
+	} else {
+		pfOpts.Persister = NewFilePersister(persistPath)
+		pfOpts.ContextName = info.Context()
+	}
+
 	// FIXME: logger is in context
 	svc := New(ctx, pfOpts, logger)
 
+	if err := svc.Restore(); err != nil {
+		logger.WithErr(err).Errorf("restoring port forwards")
+	}
+
 	return svc, nil
 }
@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package portforward
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultAddress is the address port forwards listen on if none is requested.
+	DefaultAddress = "localhost"
+
+	// freePortSearchLimit is the number of ports checked when suggesting a free port.
+	freePortSearchLimit = 100
+)
+
+// CreateOption is an option for creating a port forward.
+type CreateOption func(r *CreateRequest)
+
+// WithLocalPort sets the local port of a port forward. A random port is
+// used if it is zero.
+func WithLocalPort(port uint16) CreateOption {
+	return func(r *CreateRequest) {
+		if len(r.Ports) > 0 {
+			r.Ports[0].Local = port
+		}
+	}
+}
+
+// WithAddress sets the address a port forward listens on. DefaultAddress is
+// used if it is blank.
+func WithAddress(address string) CreateOption {
+	return func(r *CreateRequest) {
+		r.Address = address
+	}
+}
+
+// WithIdleTimeout stops a port forward once it has had no connections for
+// timeout. Port forwards don't time out if it is zero.
+func WithIdleTimeout(timeout time.Duration) CreateOption {
+	return func(r *CreateRequest) {
+		r.IdleTimeout = timeout
+	}
+}
+
+// PortInUseError is returned when a requested local port is already in use.
+type PortInUseError struct {
+	Address string
+	Port    uint16
+	// ForwardID is the port forward using the port. It is blank if the port
+	// is used by another listener.
+	ForwardID string
+	// Suggested is the next free port. It is zero if no free port was found.
+	Suggested uint16
+}
+
+var _ error = (*PortInUseError)(nil)
+
+func (e *PortInUseError) Error() string {
+	message := fmt.Sprintf("local port %d on %s is in use", e.Port, e.Address)
+	if e.ForwardID != "" {
+		message = fmt.Sprintf("local port %d on %s is used by port forward %s", e.Port, e.Address, e.ForwardID)
+	}
+
+	if e.Suggested != 0 {
+		message = fmt.Sprintf("%s; port %d is free", message, e.Suggested)
+	}
+
+	return message
+}
+
+// requestAddress returns the address a request listens on.
+func requestAddress(r CreateRequest) string {
+	if r.Address == "" {
+		return DefaultAddress
+	}
+	return r.Address
+}
+
+// validateAddress returns an error if address isn't localhost or an IP address.
+func validateAddress(address string) error {
+	if address == "" || address == DefaultAddress {
+		return nil
+	}
+
+	if net.ParseIP(address) == nil {
+		return errors.Errorf("address %q must be localhost or an IP address", address)
+	}
+
+	return nil
+}
+
+// checkLocalPorts returns a PortInUseError if a requested local port is used
+// by another port forward or listener.
+func (s *Service) checkLocalPorts(r CreateRequest) error {
+	address := requestAddress(r)
+
+	for _, p := range r.Ports {
+		if p.Local == 0 {
+			continue
+		}
+
+		id := s.forwardUsingPort(address, p.Local)
+		if id == "" && portAvailable(address, p.Local) {
+			continue
+		}
+
+		return &PortInUseError{
+			Address:   address,
+			Port:      p.Local,
+			ForwardID: id,
+			Suggested: s.nextFreePort(address, p.Local),
+		}
+	}
+
+	return nil
+}
+
+// forwardUsingPort returns the id of the port forward listening on a port, or
+// a blank string if there isn't one.
+func (s *Service) forwardUsingPort(address string, port uint16) string {
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	for id, state := range s.state.portForwards {
+		if !addressesOverlap(address, requestAddress(state.request)) {
+			continue
+		}
+
+		for _, p := range state.request.Ports {
+			if p.Local == port {
+				return id
+			}
+		}
+	}
+
+	return ""
+}
+
+// nextFreePort returns the first free port after port, or zero if none of the
+// following ports are free.
+func (s *Service) nextFreePort(address string, port uint16) uint16 {
+	for i := int(port) + 1; i <= 65535 && i <= int(port)+freePortSearchLimit; i++ {
+		candidate := uint16(i)
+		if s.forwardUsingPort(address, candidate) == "" && portAvailable(address, candidate) {
+			return candidate
+		}
+	}
+
+	return 0
+}
+
+// portAvailable returns true if a listener can be opened on a port.
+func portAvailable(address string, port uint16) bool {
+	listener, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(int(port))))
+	if err != nil {
+		return false
+	}
+
+	_ = listener.Close()
+	return true
+}
+
+// addressesOverlap returns true if listeners on both addresses can't use the
+// same port.
+func addressesOverlap(a, b string) bool {
+	ipA, ipB := addressIP(a), addressIP(b)
+	if ipA == nil || ipB == nil {
+		return a == b
+	}
+
+	return ipA.Equal(ipB) ||
+		ipA.IsUnspecified() || ipB.IsUnspecified() ||
+		(ipA.IsLoopback() && ipB.IsLoopback())
+}
+
+func addressIP(address string) net.IP {
+	if address == DefaultAddress {
+		return net.IPv4(127, 0, 0, 1)
+	}
+	return net.ParseIP(address)
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package portforward
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		isErr   bool
+	}{
+		{address: ""},
+		{address: "localhost"},
+		{address: "127.0.0.1"},
+		{address: "0.0.0.0"},
+		{address: "::1"},
+		{address: "example.com", isErr: true},
+		{address: "127.0.0.1:8080", isErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.address, func(t *testing.T) {
+			err := validateAddress(test.address)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAddressesOverlap(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected bool
+	}{
+		{name: "same address", a: "10.0.0.1", b: "10.0.0.1", expected: true},
+		{name: "localhost and loopback", a: "localhost", b: "127.0.0.1", expected: true},
+		{name: "ipv4 and ipv6 loopback", a: "127.0.0.1", b: "::1", expected: true},
+		{name: "unspecified", a: "0.0.0.0", b: "10.0.0.1", expected: true},
+		{name: "different addresses", a: "10.0.0.1", b: "10.0.0.2"},
+		{name: "loopback and other address", a: "localhost", b: "10.0.0.1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, addressesOverlap(test.a, test.b))
+			assert.Equal(t, test.expected, addressesOverlap(test.b, test.a))
+		})
+	}
+}
+
+func TestPortInUseError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *PortInUseError
+		expected string
+	}{
+		{
+			name:     "other listener",
+			err:      &PortInUseError{Address: "localhost", Port: 8080},
+			expected: "local port 8080 on localhost is in use",
+		},
+		{
+			name:     "port forward with suggestion",
+			err:      &PortInUseError{Address: "localhost", Port: 8080, ForwardID: "abc", Suggested: 8081},
+			expected: "local port 8080 on localhost is used by port forward abc; port 8081 is free",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.err.Error())
+		})
+	}
+}
+
+func TestCreateOptions(t *testing.T) {
+	r := CreateRequest{Ports: []PortForwardPortSpec{{Remote: 80}, {Remote: 443}}}
+
+	WithLocalPort(8080)(&r)
+	WithAddress("0.0.0.0")(&r)
+
+	assert.Equal(t, []PortForwardPortSpec{{Remote: 80, Local: 8080}, {Remote: 443}}, r.Ports)
+	assert.Equal(t, "0.0.0.0", requestAddress(r))
+	assert.Equal(t, DefaultAddress, requestAddress(CreateRequest{}))
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package portforward
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// Persister saves and loads the port forward requests of kube contexts.
+type Persister interface {
+	// Load loads the requests saved for a context.
+	Load(contextName string) ([]CreateRequest, error)
+	// Save replaces the requests saved for a context.
+	Save(contextName string, requests []CreateRequest) error
+}
+
+// FilePersister saves port forward requests to a JSON file. Requests for
+// all contexts are saved in the same file.
+type FilePersister struct {
+	path string
+}
+
+// savedRequest is a request and the context it was created in.
+type savedRequest struct {
+	Context string `json:"context"`
+	CreateRequest
+}
+
+var _ Persister = (*FilePersister)(nil)
+
+// NewFilePersister creates an instance of FilePersister.
+func NewFilePersister(path string) *FilePersister {
+	return &FilePersister{path: path}
+}
+
+// DefaultPersistPath returns the path of the port forwards file in the user's
+// config directory.
+func DefaultPersistPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", errors.Wrap(err, "finding user config directory")
+	}
+
+	return filepath.Join(dir, "kubeon", "portforwards.json"), nil
+}
+
+// Load loads the port forward requests of a context. It returns no requests if
+// the file doesn't exist.
+func (p *FilePersister) Load(contextName string) ([]CreateRequest, error) {
+	saved, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var requests []CreateRequest
+	for _, s := range saved {
+		if s.Context == contextName {
+			requests = append(requests, s.CreateRequest)
+		}
+	}
+
+	return requests, nil
+}
+
+func (p *FilePersister) load() ([]savedRequest, error) {
+	data, err := ioutil.ReadFile(p.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "reading port forwards")
+	}
+
+	var saved []savedRequest
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, errors.Wrapf(err, "parsing port forwards in %s", p.path)
+	}
+
+	return saved, nil
+}
+
+// Save saves the port forward requests of a context. Requests saved for
+// other contexts are kept. The file is replaced atomically.
+func (p *FilePersister) Save(contextName string, requests []CreateRequest) error {
+	if err := os.MkdirAll(filepath.Dir(p.path), 0700); err != nil {
+		return errors.Wrap(err, "creating config directory")
+	}
+
+	current, err := p.load()
+	if err != nil {
+		return err
+	}
+
+	saved := []savedRequest{}
+	for _, s := range current {
+		if s.Context != contextName {
+			saved = append(saved, s)
+		}
+	}
+	for _, r := range requests {
+		saved = append(saved, savedRequest{Context: contextName, CreateRequest: r})
+	}
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding port forwards")
+	}
+
+	tmp := p.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrap(err, "writing port forwards")
+	}
+
+	return errors.Wrap(os.Rename(tmp, p.path), "replacing port forwards")
+}
+
+// persist saves the current port forwards.
+func (s *Service) persist() {
+	if s.opts.Persister == nil {
+		return
+	}
+
+	s.state.Lock()
+	states := make([]State, 0, len(s.state.portForwards))
+	for _, pf := range s.state.portForwards {
+		states = append(states, pf.Clone())
+	}
+	s.state.Unlock()
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].CreatedAt.Before(states[j].CreatedAt)
+	})
+
+	requests := make([]CreateRequest, 0, len(states))
+	for _, state := range states {
+		requests = append(requests, state.request)
+	}
+
+	if err := s.opts.Persister.Save(s.opts.ContextName, requests); err != nil {
+		s.logger.WithErr(err).Errorf("saving port forwards")
+	}
+}
+
+// Restore starts the port forwards saved for the current context. They connect
+// in the background and keep retrying until their target has a running pod.
+// A port forward which can't be created doesn't stop the others from being
+// restored; the errors are returned together.
+func (s *Service) Restore() error {
+	if s.opts.Persister == nil {
+		return nil
+	}
+
+	requests, err := s.opts.Persister.Load(s.opts.ContextName)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, r := range requests {
+		if err := s.validateCreateRequest(r); err != nil {
+			s.logger.WithErr(err).Warnf("skipping saved port forward for %s %q", r.Kind, r.Name)
+			continue
+		}
+
+		if _, err := s.createForwarder(r, ""); err != nil {
+			errs = append(errs, errors.Wrapf(err, "restoring port forward for %s %q", r.Kind, r.Name))
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package portforward
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilePersister(t *testing.T) {
+	dir, err := ioutil.TempDir("", "portforward")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p := NewFilePersister(filepath.Join(dir, "kubeon", "portforwards.json"))
+
+	requests, err := p.Load("dev")
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+
+	dev := []CreateRequest{
+		{
+			Namespace:  "default",
+			APIVersion: "v1",
+			Kind:       "Service",
+			Name:       "web",
+			Ports:      []PortForwardPortSpec{{Remote: 80, Local: 8080}},
+		},
+	}
+	prod := []CreateRequest{
+		{
+			Namespace:   "default",
+			APIVersion:  "v1",
+			Kind:        "Pod",
+			Name:        "db",
+			Ports:       []PortForwardPortSpec{{Remote: 5432}},
+			Address:     "0.0.0.0",
+			IdleTimeout: time.Minute,
+		},
+	}
+
+	require.NoError(t, p.Save("dev", dev))
+	require.NoError(t, p.Save("prod", prod))
+
+	got, err := p.Load("dev")
+	require.NoError(t, err)
+	assert.Equal(t, dev, got)
+
+	got, err = p.Load("prod")
+	require.NoError(t, err)
+	assert.Equal(t, prod, got)
+
+	// saving a context replaces only its requests
+	require.NoError(t, p.Save("dev", nil))
+
+	got, err = p.Load("dev")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = p.Load("prod")
+	require.NoError(t, err)
+	assert.Equal(t, prod, got)
+}
+
+func TestFilePersister_invalidFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "portforward")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "portforwards.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte("{"), 0600))
+
+	_, err = NewFilePersister(path).Load("dev")
+	require.Error(t, err)
+}
@@ -27,6 +27,8 @@ type Options struct {
 	StopChannel   <-chan struct{}
 	ReadyChannel  chan struct{}
 	PortsChannel  chan []ForwardedPort
+	// Traffic records the traffic of the forwarded ports if it is set.
+	Traffic *trafficRecorder
 }
 
 type portForwarder interface {
@@ -59,6 +61,9 @@ func (f *DefaultPortForwarder) ForwardPorts(method string, url *url.URL, opts Op
 		return err
 	}
 	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, method, url)
+	if opts.Traffic != nil {
+		dialer = opts.Traffic.dialer(dialer)
+	}
 	fw, err := portforward.NewOnAddresses(dialer, opts.Address, opts.Ports, opts.StopChannel, opts.ReadyChannel, f.Out, f.ErrOut)
 	if err != nil {
 		return err
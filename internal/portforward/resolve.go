@@ -0,0 +1,211 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package portforward
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/vmware/octant/pkg/store"
+)
+
+// isForwardable returns true if objects of a kind can be port forwarded.
+// Objects other than pods are forwarded to a pod matching their selector.
+func isForwardable(apiVersion, kind string) bool {
+	switch apiVersion {
+	case "v1":
+		return kind == "Pod" || kind == "Service"
+	case "apps/v1":
+		return kind == "Deployment" || kind == "StatefulSet" || kind == "DaemonSet" || kind == "ReplicaSet"
+	default:
+		return false
+	}
+}
+
+// resolvePod attempts to resolve a port forward request into an active pod we can
+// forward to. Service/deployments selectors will be resolved into pods and the
+// first running and ready pod by name will be chosen. A pod has to be active.
+// Returns: pod name or error.
+func (s *Service) resolvePod(ctx context.Context, r CreateRequest) (string, error) {
+	o := s.opts.ObjectStore
+	if o == nil {
+		return "", errors.New("nil objectstore")
+	}
+
+	if r.APIVersion == "v1" && r.Kind == "Pod" {
+		// Verify pod exists and status is running
+		if ok, err := s.verifyPod(ctx, r.Namespace, r.Name); !ok || err != nil {
+			return "", errors.Errorf("verifying pod %q: %v", r.Name, err)
+		}
+		return r.Name, nil
+	}
+
+	selector, err := s.targetSelector(ctx, r)
+	if err != nil {
+		return "", err
+	}
+
+	key := store.Key{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  r.Namespace,
+	}
+
+	list, _, err := o.List(ctx, key)
+	if err != nil {
+		return "", errors.Wrap(err, "listing pods")
+	}
+
+	var names []string
+	for i := range list.Items {
+		object := &list.Items[i]
+		if !selector.Matches(labels.Set(object.GetLabels())) {
+			continue
+		}
+
+		pod := &corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, pod); err != nil {
+			return "", errors.Wrap(err, "converting pod")
+		}
+
+		if pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning && isPodReady(pod) {
+			names = append(names, pod.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		return "", errors.Errorf("%s %q has no running pods", r.Kind, r.Name)
+	}
+
+	sort.Strings(names)
+	return names[0], nil
+}
+
+// targetSelector returns the pod selector of a service or workload.
+func (s *Service) targetSelector(ctx context.Context, r CreateRequest) (labels.Selector, error) {
+	key := store.Key{
+		APIVersion: r.APIVersion,
+		Kind:       r.Kind,
+		Namespace:  r.Namespace,
+		Name:       r.Name,
+	}
+
+	object, found, err := s.opts.ObjectStore.Get(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting %s %q", r.Kind, r.Name)
+	}
+	if !found {
+		return nil, errors.Errorf("%s %q not found", r.Kind, r.Name)
+	}
+
+	if r.Kind == "Service" {
+		selector, _, err := unstructured.NestedStringMap(object.Object, "spec", "selector")
+		if err != nil {
+			return nil, errors.Wrap(err, "reading service selector")
+		}
+		if len(selector) == 0 {
+			return nil, errors.Errorf("service %q has no selector", r.Name)
+		}
+		return labels.SelectorFromSet(selector), nil
+	}
+
+	m, found, err := unstructured.NestedMap(object.Object, "spec", "selector")
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s selector", r.Kind)
+	}
+	if !found {
+		return nil, errors.Errorf("%s %q has no selector", r.Kind, r.Name)
+	}
+
+	labelSelector := &metav1.LabelSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, labelSelector); err != nil {
+		return nil, errors.Wrapf(err, "converting %s selector", r.Kind)
+	}
+
+	return metav1.LabelSelectorAsSelector(labelSelector)
+}
+
+// remotePorts returns the pod ports for a request's ports. Service ports are
+// mapped to their target ports in the pod; other ports are used as is.
+func (s *Service) remotePorts(ctx context.Context, r CreateRequest, podName string) ([]uint16, error) {
+	ports := make([]uint16, len(r.Ports))
+	for i := range r.Ports {
+		ports[i] = r.Ports[i].Remote
+	}
+
+	if r.APIVersion != "v1" || r.Kind != "Service" {
+		return ports, nil
+	}
+
+	o := s.opts.ObjectStore
+
+	var service corev1.Service
+	if _, err := store.GetAs(ctx, o, store.Key{APIVersion: "v1", Kind: "Service", Namespace: r.Namespace, Name: r.Name}, &service); err != nil {
+		return nil, errors.Wrap(err, "getting service")
+	}
+
+	var pod corev1.Pod
+	if _, err := store.GetAs(ctx, o, store.Key{APIVersion: "v1", Kind: "Pod", Namespace: r.Namespace, Name: podName}, &pod); err != nil {
+		return nil, errors.Wrap(err, "getting pod")
+	}
+
+	for i := range ports {
+		targetPort, err := serviceTargetPort(&service, &pod, ports[i])
+		if err != nil {
+			return nil, err
+		}
+		ports[i] = targetPort
+	}
+
+	return ports, nil
+}
+
+// serviceTargetPort maps a service port to a container port in pod.
+func serviceTargetPort(service *corev1.Service, pod *corev1.Pod, port uint16) (uint16, error) {
+	for _, servicePort := range service.Spec.Ports {
+		if servicePort.Port != int32(port) {
+			continue
+		}
+
+		targetPort := servicePort.TargetPort
+		switch {
+		case targetPort.Type == intstr.Int && targetPort.IntVal == 0:
+			return port, nil
+		case targetPort.Type == intstr.Int:
+			return uint16(targetPort.IntVal), nil
+		}
+
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if containerPort.Name == targetPort.StrVal {
+					return uint16(containerPort.ContainerPort), nil
+				}
+			}
+		}
+
+		return 0, errors.Errorf("pod %q has no port named %q", pod.Name, targetPort.StrVal)
+	}
+
+	return 0, errors.Errorf("service %q has no port %d", service.Name, port)
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
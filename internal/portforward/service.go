@@ -33,7 +33,7 @@ var (
 type PortForwarder interface {
 	List(ctx context.Context) []State
 	Get(id string) (State, bool)
-	Create(ctx context.Context, gvk schema.GroupVersionKind, name string, namespace string, remotePort uint16) (CreateResponse, error)
+	Create(ctx context.Context, gvk schema.GroupVersionKind, name string, namespace string, remotePort uint16, options ...CreateOption) (CreateResponse, error)
 	Find(namespace string, gvk schema.GroupVersionKind, name string) (State, error)
 	Stop()
 	StopForwarder(id string)
@@ -61,6 +61,12 @@ type CreateRequest struct {
 	Kind       string                `json:"kind"`
 	Name       string                `json:"name"`
 	Ports      []PortForwardPortSpec `json:"ports"`
+	// Address is the address local ports listen on. DefaultAddress is used
+	// if it is blank.
+	Address string `json:"address,omitempty"`
+	// IdleTimeout stops the port forward once it has had no connections
+	// for this long. Port forwards don't time out if it is zero.
+	IdleTimeout time.Duration `json:"idleTimeout,omitempty"`
 }
 
 type CreateResponse PortForwardSpec
@@ -72,28 +78,65 @@ type Target struct {
 	Name      string
 }
 
+// Status is the status of a port forward.
+type Status string
+
+const (
+	// StatusActive means traffic is being forwarded to a pod.
+	StatusActive Status = "active"
+	// StatusConnecting means the forward is resolving a pod or connecting to it.
+	StatusConnecting Status = "connecting"
+	// StatusReconnecting means the forwarded pod went away and a new pod is being
+	// resolved from the target.
+	StatusReconnecting Status = "reconnecting"
+)
+
 // State describes a single port-forward's runtime state
 type State struct {
 	ID        string
 	CreatedAt time.Time
 	Ports     []ForwardedPort
-	Target    Target
-	Pod       Target
-
-	cancel context.CancelFunc
+	// Target is the object the forward was created for. It is resolved to
+	// a pod again if the forwarded pod goes away.
+	Target Target
+	// Pod is the pod traffic is forwarded to.
+	Pod Target
+	// Address is the address local ports listen on.
+	Address string
+	Status  Status
+	Message string
+	// Reconnects is the number of times the connection to a pod was lost.
+	Reconnects int
+	// Stats is the traffic of each port, in the order the ports were requested.
+	Stats []PortStats
+
+	// request is the original request. Local ports are filled in once they
+	// are known so reconnects use the same ports.
+	request CreateRequest
+	traffic *traffic
+	cancel  context.CancelFunc
 }
 
 // Clone clones a port forward state.
 func (pf *State) Clone() State {
 	pfCpy := State{
-		ID:        pf.ID,
-		CreatedAt: pf.CreatedAt,
-		Ports:     make([]ForwardedPort, len(pf.Ports)),
-		Target:    pf.Target,
-		Pod:       pf.Pod,
-		cancel:    pf.cancel,
+		ID:         pf.ID,
+		CreatedAt:  pf.CreatedAt,
+		Ports:      make([]ForwardedPort, len(pf.Ports)),
+		Target:     pf.Target,
+		Pod:        pf.Pod,
+		Address:    pf.Address,
+		Status:     pf.Status,
+		Message:    pf.Message,
+		Reconnects: pf.Reconnects,
+		Stats:      pf.traffic.snapshot(),
+		request:    pf.request,
+		traffic:    pf.traffic,
+		cancel:     pf.cancel,
 	}
 	copy(pfCpy.Ports, pf.Ports)
+	pfCpy.request.Ports = make([]PortForwardPortSpec, len(pf.request.Ports))
+	copy(pfCpy.request.Ports, pf.request.Ports)
 	return pfCpy
 }
 
@@ -109,6 +152,11 @@ type ServiceOptions struct {
 	Config        *restclient.Config
 	ObjectStore   store.Store
 	PortForwarder portForwarder
+	// Persister saves port forwards so they can be restored on startup. Port
+	// forwards aren't saved if it is nil.
+	Persister Persister
+	// ContextName is the kube context port forwards are saved and restored for.
+	ContextName string
 }
 
 type forwarderEvent struct {
@@ -116,6 +164,11 @@ type forwarderEvent struct {
 	err error
 }
 
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
 // Service is a port forwarding service.
 type Service struct {
 	logger   log.Logger
@@ -159,8 +212,8 @@ func (s *Service) validateCreateRequest(r CreateRequest) error {
 		return errors.New("name field required")
 	}
 
-	if r.APIVersion != "v1" || r.Kind != "Pod" {
-		return errors.Errorf("port forwards only work with pods")
+	if !isForwardable(r.APIVersion, r.Kind) {
+		return errors.Errorf("port forwards don't work with %s %s", r.APIVersion, r.Kind)
 	}
 
 	for _, p := range r.Ports {
@@ -169,30 +222,11 @@ func (s *Service) validateCreateRequest(r CreateRequest) error {
 		}
 	}
 
-	return nil
-}
-
-// resolvePod attempts to resolve a port forward request into an active pod we can
-// forward to. Service/deployments selectors will be resolved into pods and a random
-// one will be chosen. A pod has to be active.
-// Returns: pod name or error.
-func (s *Service) resolvePod(ctx context.Context, r CreateRequest) (string, error) {
-	o := s.opts.ObjectStore
-	if o == nil {
-		return "", errors.New("nil objectstore")
-	}
-
-	switch {
-	case r.APIVersion == "v1" && r.Kind == "Pod":
-		// Verify pod exists and status is running
-		if ok, err := s.verifyPod(ctx, r.Namespace, r.Name); !ok || err != nil {
-			return "", errors.Errorf("verifying pod %q: %v", r.Name, err)
-		}
-		return r.Name, nil
-	default:
-		return "", errors.New("not implemented")
+	if r.IdleTimeout < 0 {
+		return errors.Errorf("idle timeout can't be negative: %v", r.IdleTimeout)
 	}
 
+	return validateAddress(r.Address)
 }
 
 // verifyPod returns true if the specified pod can be found and is in the running phase.
@@ -229,10 +263,11 @@ func (s *Service) verifyPod(ctx context.Context, namespace, name string) (bool,
 	return true, nil
 }
 
-// createForwarder creates a port forwarder, forwards traffic, and blocks until
-// port state information is populated.
+// createForwarder creates a port forwarder for a request and keeps it running.
+// If podName is set, it blocks until the first connection to the pod is ready.
+// Otherwise the forwarder resolves a pod in the background.
 // Returns forwarder id.
-func (s *Service) createForwarder(r CreateRequest) (string, error) {
+func (s *Service) createForwarder(r CreateRequest, podName string) (string, error) {
 	logger := s.logger.With("context", "PortForwardService.createForwarder")
 
 	if s.opts.PortForwarder == nil {
@@ -246,11 +281,6 @@ func (s *Service) createForwarder(r CreateRequest) (string, error) {
 	forwarderID := randomUUID.String()
 	logger = logger.With("id", forwarderID)
 
-	var ports []string
-	for _, p := range r.Ports {
-		ports = append(ports, fmt.Sprintf("%d:%d", p.Local, p.Remote))
-	}
-
 	// Target coordinates to preserve in state
 	gv, err := schema.ParseGroupVersion(r.APIVersion)
 	if err != nil {
@@ -261,24 +291,11 @@ func (s *Service) createForwarder(r CreateRequest) (string, error) {
 	// This child context will be cancelled if our parent context is cancelled
 	ctx, cancel := context.WithCancel(s.ctx)
 
-	// Spawns goroutine to update state as ports become available
-	portsChannel, portsReady := s.localPortsHandler(ctx, forwarderID)
+	request := r
+	request.Ports = make([]PortForwardPortSpec, len(r.Ports))
+	copy(request.Ports, r.Ports)
 
-	// TODO resolve request gvk/name to pod name
-	o := &s.opts
-	opts := Options{
-		Config:        o.Config,
-		RESTClient:    o.RESTClient,
-		Address:       []string{"localhost"},
-		Ports:         ports,
-		PortForwarder: o.PortForwarder,
-		StopChannel:   ctx.Done(),
-		ReadyChannel:  make(chan struct{}),
-		PortsChannel:  portsChannel,
-	}
-
-	// NOTE: ports will be updated in the state struct by
-	// localPortsHandler when they become available.
+	// NOTE: ports will be updated in the state struct when they become available.
 	forwardState := State{
 		ID:        forwarderID,
 		CreatedAt: time.Now(),
@@ -287,54 +304,203 @@ func (s *Service) createForwarder(r CreateRequest) (string, error) {
 			Namespace: r.Namespace,
 			Name:      r.Name,
 		},
-		// TODO Target and Pod may be different
-		Pod: Target{
-			GVK:       gvk,
-			Namespace: r.Namespace,
-			Name:      r.Name,
-		},
-		cancel: cancel,
+		Address: requestAddress(r),
+		Status:  StatusConnecting,
+		request: request,
+		traffic: newTraffic(request.Ports),
+		cancel:  cancel,
 	}
 
 	s.state.Lock()
 	s.state.portForwards[forwarderID] = forwardState
 	s.state.Unlock()
 
-	req := o.RESTClient.Post().
-		Resource("pods").
-		Namespace(r.Namespace).
-		Name(r.Name).
-		SubResource("portforward")
+	if r.IdleTimeout > 0 {
+		go s.stopWhenIdle(ctx, forwarderID, forwardState.traffic, r.IdleTimeout)
+	}
 
-	go func() {
-		// Blocks until forwarder completes
-		logger.With("url", req.URL()).Debugf("starting port-forward")
-		err := s.opts.PortForwarder.ForwardPorts("POST", req.URL(), opts)
+	firstResult := make(chan error, 1)
+	go s.supervise(ctx, forwarderID, podName, firstResult)
+
+	if podName == "" {
+		return forwarderID, nil
+	}
+
+	// Block until ports state is ready
+	select {
+	case <-ctx.Done():
+		return "", errors.Errorf("portforward terminated due to parent context: %v", forwarderID)
+	case err := <-firstResult:
+		if err != nil {
+			s.StopForwarder(forwarderID)
+			return "", err
+		}
+	}
+
+	return forwarderID, nil
+}
+
+// supervise forwards to podName and keeps the forward running until ctx is
+// cancelled. When the connection to the pod is lost, the forward's target is
+// resolved to a pod again and the forward reconnects. The result of the first
+// connection is sent to firstResult; if it fails, supervise stops.
+func (s *Service) supervise(ctx context.Context, id, podName string, firstResult chan<- error) {
+	logger := s.logger.With("context", "PortForwardService.supervise", "id", id)
+
+	// restored forwards have no pod yet and keep retrying until they connect
+	first := podName != ""
+	delay := minReconnectDelay
+
+	for {
+		if podName == "" {
+			state, ok := s.Get(id)
+			if !ok {
+				return
+			}
+
+			resolved, err := s.resolvePod(ctx, state.request)
+			if err != nil {
+				logger.WithErr(err).Debugf("resolving pod for port-forward")
+				s.setStatus(id, StatusReconnecting, err.Error(), false)
+			}
+			podName = resolved
+		}
 
-		logger.Debugf("forwarding terminated: %v", err)
+		if podName != "" {
+			connected := false
+			err := s.forward(ctx, id, podName, func() {
+				connected = true
+				delay = minReconnectDelay
+				if first {
+					first = false
+					firstResult <- nil
+				}
+			})
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			if first {
+				if err == nil {
+					err = errors.New("port-forward stopped before it was ready")
+				}
+				firstResult <- err
+				return
+			}
+
+			message := "lost connection to pod"
+			if err != nil {
+				message = err.Error()
+			}
+			logger.With("pod", podName).Debugf("forwarding terminated: %s", message)
 
-		// Notify the main forwarder of the termination
-		event := forwarderEvent{
-			ID:  forwarderID,
-			err: err,
+			s.setStatus(id, StatusReconnecting, message, connected)
+
+			select {
+			case s.notifyCh <- forwarderEvent{ID: id, err: err}:
+			default:
+			}
+
+			podName = ""
 		}
+
 		select {
-		case s.notifyCh <- event:
-		default:
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxReconnectDelay {
+			delay = maxReconnectDelay
 		}
+	}
+}
 
-		// Cleanup state for terminated port-forward
-		s.StopForwarder(forwarderID)
+// forward forwards traffic to a pod and blocks until forwarding stops. onReady
+// is called once local ports are available, and always returns before forward.
+func (s *Service) forward(ctx context.Context, id, podName string, onReady func()) error {
+	state, ok := s.Get(id)
+	if !ok {
+		return errors.Errorf("port-forward %s was stopped", id)
+	}
+	r := state.request
+
+	remotePorts, err := s.remotePorts(ctx, r, podName)
+	if err != nil {
+		return errors.Wrap(err, "resolving remote ports")
+	}
+
+	var ports []string
+	for i, p := range r.Ports {
+		ports = append(ports, fmt.Sprintf("%d:%d", p.Local, remotePorts[i]))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	portsChannel := make(chan []ForwardedPort, 1)
+	portsDone := make(chan struct{})
+	go func() {
+		defer close(portsDone)
+		select {
+		case p := <-portsChannel:
+			if err := s.updatePorts(id, podName, p); err != nil {
+				s.logger.Warnf("%s", err.Error())
+				return
+			}
+			onReady()
+		case <-ctx.Done():
+		}
 	}()
 
-	// Block until ports state is ready
-	select {
-	case <-ctx.Done():
-		return "", errors.Errorf("portforward terminated due to parent context: %v", forwarderID)
-	case <-portsReady:
+	defer func() {
+		cancel()
+		<-portsDone
+	}()
+
+	o := &s.opts
+	opts := Options{
+		Config:        o.Config,
+		RESTClient:    o.RESTClient,
+		Address:       []string{requestAddress(r)},
+		Ports:         ports,
+		PortForwarder: o.PortForwarder,
+		StopChannel:   ctx.Done(),
+		ReadyChannel:  make(chan struct{}),
+		PortsChannel:  portsChannel,
+		Traffic:       state.traffic.recorder(remotePorts),
 	}
 
-	return forwarderID, nil
+	req := o.RESTClient.Post().
+		Resource("pods").
+		Namespace(r.Namespace).
+		Name(podName).
+		SubResource("portforward")
+
+	s.logger.With("id", id, "url", req.URL()).Debugf("starting port-forward")
+
+	// Blocks until forwarder completes
+	return o.PortForwarder.ForwardPorts("POST", req.URL(), opts)
+}
+
+// setStatus updates the status of a port forward. lostConnection is true if the
+// forward was connected before the status change.
+func (s *Service) setStatus(id string, status Status, message string, lostConnection bool) {
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	state, ok := s.state.portForwards[id]
+	if !ok {
+		return
+	}
+
+	state.Status = status
+	state.Message = message
+	if lostConnection {
+		state.Reconnects++
+	}
+	s.state.portForwards[id] = state
 }
 
 // responseForCreate creates a create response based on the state for the specified forward (by id)
@@ -360,39 +526,33 @@ func (s *Service) responseForCreate(id string) (CreateResponse, error) {
 	return response, nil
 }
 
-func (s *Service) localPortsHandler(ctx context.Context, id string) (portsChan chan []ForwardedPort, portsReady <-chan struct{}) {
-	logger := s.logger.With("context", "PortForwardService.localPortsHandler", "id", id)
-	portsChan = make(chan []ForwardedPort, 1)
-	readyChan := make(chan struct{})
-	portsReady = readyChan
-
-	go func() {
-		select {
-		case p := <-portsChan:
-			logger.With("ports", p).Debugf("received ports for port-forward")
-			if err := s.updatePorts(id, p); err != nil {
-				logger.Warnf("%s", err.Error())
-			}
-
-			close(readyChan)
-
-		case <-ctx.Done():
-			logger.Debugf("terminated")
-		}
-	}()
-
-	return
-}
-
-// updatePorts updates the ports list for an existing port forward, specified by id
-func (s *Service) updatePorts(id string, ports []ForwardedPort) error {
+// updatePorts updates the ports list for an existing port forward, specified by id,
+// and marks it as active. Ports are reported in the order they were requested.
+// Remote ports are reported as requested since they may have been mapped to
+// different pod ports, and local ports are pinned so reconnects reuse them.
+func (s *Service) updatePorts(id, podName string, ports []ForwardedPort) error {
 	s.state.Lock()
 	defer s.state.Unlock()
 	state, ok := s.state.portForwards[id]
 	if !ok {
 		return errors.New("updating ports for terminated port-forward")
 	}
+	for i := range ports {
+		if i < len(state.request.Ports) {
+			ports[i].Remote = state.request.Ports[i].Remote
+			state.request.Ports[i].Local = ports[i].Local
+		}
+	}
+
 	state.Ports = ports
+	state.Pod = Target{
+		GVK:       schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		Namespace: state.Target.Namespace,
+		Name:      podName,
+	}
+	state.Status = StatusActive
+	state.Message = ""
+
 	s.state.portForwards[id] = state
 	return nil
 }
@@ -403,12 +563,7 @@ func (s *Service) List(ctx context.Context) []State {
 	defer s.state.Unlock()
 
 	result := make([]State, 0, len(s.state.portForwards))
-	for i, pf := range s.state.portForwards {
-		targetPod := &pf.Pod
-		if verified, err := s.verifyPod(ctx, targetPod.Namespace, targetPod.Name); !verified || err != nil {
-			delete(s.state.portForwards, i)
-			continue
-		}
+	for _, pf := range s.state.portForwards {
 		result = append(result, pf.Clone())
 	}
 
@@ -431,15 +586,24 @@ func (s *Service) Get(id string) (State, bool) {
 }
 
 // Create creates a new port forward for the specified object and remote port.
+// A random local port on DefaultAddress is used unless options set them. A
+// PortInUseError is returned if the local port is already in use.
 // Implements PortForwardInterface.
-func (s *Service) Create(ctx context.Context, gvk schema.GroupVersionKind, name string, namespace string, remotePort uint16) (CreateResponse, error) {
+func (s *Service) Create(ctx context.Context, gvk schema.GroupVersionKind, name string, namespace string, remotePort uint16, options ...CreateOption) (CreateResponse, error) {
 	logger := s.logger.With("context", "PortForwardService.Create")
 	req := newForwardRequest(gvk, name, namespace, remotePort)
+	for _, option := range options {
+		option(&req)
+	}
 
 	if err := s.validateCreateRequest(req); err != nil {
 		return emptyPortForwardResponse, errors.Wrap(err, "invalid request")
 	}
 
+	if err := s.checkLocalPorts(req); err != nil {
+		return emptyPortForwardResponse, err
+	}
+
 	// Resolve the request into a pod, update the request
 	logger.With(
 		"apiVersion", req.APIVersion,
@@ -452,14 +616,14 @@ func (s *Service) Create(ctx context.Context, gvk schema.GroupVersionKind, name
 		return emptyPortForwardResponse, errors.Wrap(err, "resolving pod")
 	}
 	logger.Debugf("resolved to pod %q", podName)
-	podReq := req
-	podReq.Name = podName
 
-	id, err := s.createForwarder(req)
+	id, err := s.createForwarder(req, podName)
 	if err != nil {
 		return emptyPortForwardResponse, errors.Wrap(err, "creating forwarder")
 	}
 
+	s.persist()
+
 	// Compose response based on forwarder state
 	response, err := s.responseForCreate(id)
 	if err != nil {
@@ -473,10 +637,9 @@ func (s *Service) Create(ctx context.Context, gvk schema.GroupVersionKind, name
 // Implements PortForwardInterface.
 func (s *Service) StopForwarder(id string) {
 	s.state.Lock()
-	defer s.state.Unlock()
-
 	pf, ok := s.state.portForwards[id]
 	if !ok {
+		s.state.Unlock()
 		return
 	}
 	if pf.cancel != nil {
@@ -484,6 +647,9 @@ func (s *Service) StopForwarder(id string) {
 		pf.cancel()
 	}
 	delete(s.state.portForwards, id)
+	s.state.Unlock()
+
+	s.persist()
 }
 
 type notFound struct{}
@@ -507,7 +673,7 @@ func (s *Service) Find(namespace string, gvk schema.GroupVersionKind, name strin
 		if target.GVK.String() == gvk.String() &&
 			namespace == target.Namespace &&
 			name == target.Name {
-			return state, nil
+			return state.Clone(), nil
 		}
 	}
 
@@ -0,0 +1,285 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package portforward
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+// PortStats is traffic statistics for a forwarded port. Statistics are kept
+// across reconnects.
+type PortStats struct {
+	// Remote is the remote port as requested.
+	Remote uint16
+	// BytesIn is the number of bytes received from the pod.
+	BytesIn uint64
+	// BytesOut is the number of bytes sent to the pod.
+	BytesOut uint64
+	// ActiveConnections is the number of open local connections.
+	ActiveConnections int
+	// TotalConnections is the number of local connections made.
+	TotalConnections int
+	// LastError is the last error reported for a connection.
+	LastError   string
+	LastErrorAt time.Time
+}
+
+// traffic records the traffic of a port forward's ports.
+type traffic struct {
+	mu           sync.Mutex
+	ports        []PortStats
+	lastActivity time.Time
+}
+
+func newTraffic(ports []PortForwardPortSpec) *traffic {
+	t := &traffic{
+		ports:        make([]PortStats, len(ports)),
+		lastActivity: time.Now(),
+	}
+	for i := range ports {
+		t.ports[i].Remote = ports[i].Remote
+	}
+	return t
+}
+
+// snapshot returns a copy of the port statistics.
+func (t *traffic) snapshot() []PortStats {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make([]PortStats, len(t.ports))
+	copy(stats, t.ports)
+	return stats
+}
+
+// idleSince returns when the last connection was closed or data was sent. It
+// returns false if there are open connections.
+func (t *traffic) idleSince() (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, p := range t.ports {
+		if p.ActiveConnections > 0 {
+			return time.Time{}, false
+		}
+	}
+
+	return t.lastActivity, true
+}
+
+func (t *traffic) update(i int, fn func(p *PortStats)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if i < 0 || i >= len(t.ports) {
+		return
+	}
+
+	fn(&t.ports[i])
+	t.lastActivity = time.Now()
+}
+
+// recorder creates a recorder for a connection to a pod. podPorts are the pod
+// ports of the forward's ports, in the order they were requested.
+func (t *traffic) recorder(podPorts []uint16) *trafficRecorder {
+	index := make(map[string]int, len(podPorts))
+	for i, p := range podPorts {
+		index[strconv.Itoa(int(p))] = i
+	}
+
+	return &trafficRecorder{
+		traffic: t,
+		index:   index,
+	}
+}
+
+// trafficRecorder records traffic by wrapping the streams of a port forward
+// connection. Every local connection creates an error stream and a data
+// stream for its port.
+type trafficRecorder struct {
+	traffic *traffic
+	index   map[string]int
+}
+
+// dialer wraps a dialer so its connections record traffic.
+func (r *trafficRecorder) dialer(dialer httpstream.Dialer) httpstream.Dialer {
+	return &recordingDialer{Dialer: dialer, recorder: r}
+}
+
+func (r *trafficRecorder) port(headers http.Header) int {
+	i, ok := r.index[headers.Get(corev1.PortHeader)]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+func (r *trafficRecorder) wrap(stream httpstream.Stream) httpstream.Stream {
+	i := r.port(stream.Headers())
+
+	switch stream.Headers().Get(corev1.StreamType) {
+	case corev1.StreamTypeData:
+		r.traffic.update(i, func(p *PortStats) {
+			p.ActiveConnections++
+			p.TotalConnections++
+		})
+		return &dataStream{Stream: stream, traffic: r.traffic, port: i}
+	case corev1.StreamTypeError:
+		return &errorStream{Stream: stream, traffic: r.traffic, port: i}
+	default:
+		return stream
+	}
+}
+
+type recordingDialer struct {
+	httpstream.Dialer
+	recorder *trafficRecorder
+}
+
+func (d *recordingDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	conn, protocol, err := d.Dialer.Dial(protocols...)
+	if err != nil {
+		return nil, protocol, err
+	}
+
+	return &recordingConnection{Connection: conn, recorder: d.recorder}, protocol, nil
+}
+
+type recordingConnection struct {
+	httpstream.Connection
+	recorder *trafficRecorder
+}
+
+func (c *recordingConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	stream, err := c.Connection.CreateStream(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.recorder.wrap(stream), nil
+}
+
+// dataStream counts the bytes of a connection. The connection is closed
+// once the pod stops sending data or the stream is reset.
+type dataStream struct {
+	httpstream.Stream
+	traffic *traffic
+	port    int
+	once    sync.Once
+}
+
+func (s *dataStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	if n > 0 {
+		s.traffic.update(s.port, func(stats *PortStats) {
+			stats.BytesIn += uint64(n)
+		})
+	}
+	if err != nil {
+		s.closed()
+	}
+	return n, err
+}
+
+func (s *dataStream) Write(p []byte) (int, error) {
+	n, err := s.Stream.Write(p)
+	if n > 0 {
+		s.traffic.update(s.port, func(stats *PortStats) {
+			stats.BytesOut += uint64(n)
+		})
+	}
+	return n, err
+}
+
+func (s *dataStream) Reset() error {
+	s.closed()
+	return s.Stream.Reset()
+}
+
+func (s *dataStream) closed() {
+	s.once.Do(func() {
+		s.traffic.update(s.port, func(stats *PortStats) {
+			stats.ActiveConnections--
+		})
+	})
+}
+
+// errorStream records the error the pod reports for a connection.
+type errorStream struct {
+	httpstream.Stream
+	traffic *traffic
+	port    int
+	message strings.Builder
+}
+
+func (s *errorStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	s.message.Write(p[:n])
+
+	if err != nil {
+		message := strings.TrimSpace(s.message.String())
+		if err != io.EOF {
+			message = err.Error()
+		}
+
+		if message != "" {
+			s.traffic.update(s.port, func(stats *PortStats) {
+				stats.LastError = message
+				stats.LastErrorAt = time.Now()
+			})
+		}
+	}
+
+	return n, err
+}
+
+const (
+	minIdleCheckInterval = time.Second
+	maxIdleCheckInterval = time.Minute
+)
+
+// stopWhenIdle stops a port forward once it has had no connections for timeout.
+func (s *Service) stopWhenIdle(ctx context.Context, id string, t *traffic, timeout time.Duration) {
+	interval := timeout / 10
+	if interval < minIdleCheckInterval {
+		interval = minIdleCheckInterval
+	}
+	if interval > maxIdleCheckInterval {
+		interval = maxIdleCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			since, idle := t.idleSince()
+			if !idle || time.Since(since) < timeout {
+				continue
+			}
+
+			s.logger.With("id", id).Infof("stopping port-forward after being idle for %s", timeout)
+			s.StopForwarder(id)
+			return
+		}
+	}
+}
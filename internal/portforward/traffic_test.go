@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package portforward
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+type fakeStream struct {
+	io.Reader
+	headers http.Header
+	written bytes.Buffer
+}
+
+var _ httpstream.Stream = (*fakeStream)(nil)
+
+func (s *fakeStream) Write(p []byte) (int, error) { return s.written.Write(p) }
+func (s *fakeStream) Close() error                { return nil }
+func (s *fakeStream) Reset() error                { return nil }
+func (s *fakeStream) Headers() http.Header        { return s.headers }
+func (s *fakeStream) Identifier() uint32          { return 0 }
+
+func newFakeStream(streamType, port, data string) *fakeStream {
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, streamType)
+	headers.Set(corev1.PortHeader, port)
+
+	return &fakeStream{Reader: bytes.NewBufferString(data), headers: headers}
+}
+
+func TestTrafficRecorder(t *testing.T) {
+	tr := newTraffic([]PortForwardPortSpec{{Remote: 80}, {Remote: 443}})
+	// the service ports are forwarded to different pod ports
+	recorder := tr.recorder([]uint16{8080, 8443})
+
+	data := recorder.wrap(newFakeStream(corev1.StreamTypeData, "8443", "response"))
+
+	_, idle := tr.idleSince()
+	assert.False(t, idle, "open connections aren't idle")
+
+	_, err := data.Write([]byte("request"))
+	require.NoError(t, err)
+
+	got, err := ioutil.ReadAll(data)
+	require.NoError(t, err)
+	assert.Equal(t, "response", string(got))
+
+	errStream := recorder.wrap(newFakeStream(corev1.StreamTypeError, "8443", "connection refused\n"))
+	_, err = ioutil.ReadAll(errStream)
+	require.NoError(t, err)
+
+	stats := tr.snapshot()
+	require.Len(t, stats, 2)
+
+	assert.Equal(t, PortStats{Remote: 80}, stats[0])
+
+	assert.Equal(t, uint16(443), stats[1].Remote)
+	assert.Equal(t, uint64(len("response")), stats[1].BytesIn)
+	assert.Equal(t, uint64(len("request")), stats[1].BytesOut)
+	assert.Equal(t, 0, stats[1].ActiveConnections)
+	assert.Equal(t, 1, stats[1].TotalConnections)
+	assert.Equal(t, "connection refused", stats[1].LastError)
+	assert.False(t, stats[1].LastErrorAt.IsZero())
+
+	_, idle = tr.idleSince()
+	assert.True(t, idle)
+}
+
+func TestTrafficRecorder_unknownPort(t *testing.T) {
+	tr := newTraffic([]PortForwardPortSpec{{Remote: 80}})
+	recorder := tr.recorder([]uint16{8080})
+
+	data := recorder.wrap(newFakeStream(corev1.StreamTypeData, "9090", "data"))
+	_, err := ioutil.ReadAll(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []PortStats{{Remote: 80}}, tr.snapshot())
+}
@@ -0,0 +1,23 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package printer
+
+import (
+	"context"
+
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// hasAccess returns true if the current user can use verb on a subresource of
+// the object described by key. A blank subresource checks the object itself.
+// Access is denied if the object store can't check it.
+func hasAccess(ctx context.Context, key store.Key, subresource, verb string, options Options) bool {
+	if options.DashConfig == nil {
+		return false
+	}
+
+	return store.HasSubresourceAccess(ctx, options.DashConfig.ObjectStore(), key, subresource, verb) == nil
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package printer
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubenext/kubeon/internal/bulk"
+	"github.com/kubenext/kubeon/pkg/store"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+// setRowKey makes a table row selectable for bulk actions.
+func setRowKey(row component.TableRow, object runtime.Object) error {
+	key, err := store.KeyFromObject(object)
+	if err != nil {
+		return err
+	}
+
+	row.SetKey(key.RowKey())
+	return nil
+}
+
+// addBulkActions adds delete, label and annotate actions for the selected rows
+// of a table. restartable tables also get a restart action. Actions are only
+// added if the current user can patch, or for delete, delete the objects in
+// every namespace shown in the table.
+func addBulkActions(ctx context.Context, table *component.Table, restartable bool, options Options) {
+	canPatch := bulkAccess(ctx, table, "patch", options)
+	canDelete := bulkAccess(ctx, table, "delete", options)
+
+	if canDelete {
+		table.AddBulkAction(bulkAction("Delete", "Delete selected", bulk.OperationDelete))
+	}
+
+	if !canPatch {
+		return
+	}
+
+	table.AddBulkAction(bulkAction("Label", "Label selected", bulk.OperationLabel,
+		component.NewFormFieldTextarea("Labels (key=value to set, key- to remove)", "changes", "")))
+	table.AddBulkAction(bulkAction("Annotate", "Annotate selected", bulk.OperationAnnotate,
+		component.NewFormFieldTextarea("Annotations (one key=value to set or key- to remove per line)", "changes", "")))
+
+	if restartable {
+		table.AddBulkAction(bulkAction("Restart", "Restart selected", bulk.OperationRestart))
+	}
+}
+
+// bulkAccess returns true if the current user can use a verb for the kind of
+// objects in a table, in each namespace they are in.
+func bulkAccess(ctx context.Context, table *component.Table, verb string, options Options) bool {
+	checked := make(map[store.Key]bool)
+	for _, row := range table.Rows() {
+		rowKey, ok := row.Key()
+		if !ok {
+			continue
+		}
+
+		key, err := store.KeyFromRowKey(rowKey)
+		if err != nil {
+			return false
+		}
+
+		key.Name = ""
+		if checked[key] {
+			continue
+		}
+		checked[key] = true
+
+		if !hasAccess(ctx, key, "", verb, options) {
+			return false
+		}
+	}
+
+	return len(checked) > 0
+}
+
+func bulkAction(name, title, operation string, fields ...component.FormField) component.Action {
+	fields = append([]component.FormField{component.NewFormFieldHidden("operation", operation)}, fields...)
+
+	return component.Action{
+		Name:  name,
+		Title: title,
+		Form:  component.CreateBulkForm("overview/bulk", fields...),
+	}
+}
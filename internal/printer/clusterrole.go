@@ -16,7 +16,7 @@ import (
 )
 
 // ClusterRoleListHandler is a printFunc that prints cluster roles
-func ClusterRoleListHandler(_ context.Context, list *rbacv1.ClusterRoleList, options Options) (component.Component, error) {
+func ClusterRoleListHandler(ctx context.Context, list *rbacv1.ClusterRoleList, options Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("cluster role list is nil")
 	}
@@ -35,9 +35,15 @@ func ClusterRoleListHandler(_ context.Context, list *rbacv1.ClusterRoleList, opt
 		ts := clusterRole.CreationTimestamp.Time
 		row["Age"] = component.NewTimestamp(ts)
 
+		if err := setRowKey(row, &clusterRole); err != nil {
+			return nil, err
+		}
+
 		tbl.Add(row)
 	}
 
+	addBulkActions(ctx, tbl, false, options)
+
 	return tbl, nil
 }
 
@@ -15,7 +15,7 @@ import (
 )
 
 // ClusterRoleBindingListHandler is a printFunc that prints ClusterRoldBindings
-func ClusterRoleBindingListHandler(_ context.Context, clusterRoleBindingList *rbacv1.ClusterRoleBindingList, options Options) (component.Component, error) {
+func ClusterRoleBindingListHandler(ctx context.Context, clusterRoleBindingList *rbacv1.ClusterRoleBindingList, options Options) (component.Component, error) {
 	if clusterRoleBindingList == nil {
 		return nil, errors.New("cluster role binding list is nil")
 	}
@@ -44,9 +44,15 @@ func ClusterRoleBindingListHandler(_ context.Context, clusterRoleBindingList *rb
 
 		row["Role name"] = roleName
 
+		if err := setRowKey(row, &roleBinding); err != nil {
+			return nil, err
+		}
+
 		table.Add(row)
 	}
 
+	addBulkActions(ctx, table, false, options)
+
 	return table, nil
 }
 
@@ -17,7 +17,7 @@ import (
 )
 
 // ConfigMapListHandler is a printFunc that prints ConfigMaps
-func ConfigMapListHandler(_ context.Context, list *corev1.ConfigMapList, opts Options) (component.Component, error) {
+func ConfigMapListHandler(ctx context.Context, list *corev1.ConfigMapList, opts Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("list is nil")
 	}
@@ -44,9 +44,15 @@ func ConfigMapListHandler(_ context.Context, list *corev1.ConfigMapList, opts Op
 		ts := c.CreationTimestamp.Time
 		row["Age"] = component.NewTimestamp(ts)
 
+		if err := setRowKey(row, &c); err != nil {
+			return nil, err
+		}
+
 		tbl.Add(row)
 	}
 
+	addBulkActions(ctx, tbl, false, opts)
+
 	return tbl, nil
 }
 
@@ -59,7 +65,7 @@ func ConfigMapHandler(ctx context.Context, cm *corev1.ConfigMap, options Options
 		return nil, err
 	}
 
-	if err := ch.Config(options); err != nil {
+	if err := ch.Config(ctx, options); err != nil {
 		return nil, errors.Wrap(err, "print configmap configuration")
 	}
 
@@ -83,7 +89,7 @@ func NewConfigMapConfiguration(cm *corev1.ConfigMap) *ConfigMapConfiguration {
 }
 
 // Create a configmap configuration summary
-func (c *ConfigMapConfiguration) Create(options Options) (*component.Summary, error) {
+func (c *ConfigMapConfiguration) Create(ctx context.Context, options Options) (*component.Summary, error) {
 	if c.configmap == nil {
 		return nil, errors.New("config map is nil")
 	}
@@ -98,6 +104,11 @@ func (c *ConfigMapConfiguration) Create(options Options) (*component.Summary, er
 	})
 
 	summary := component.NewSummary("Configuration", sections...)
+
+	if err := addConfigMapEditAction(ctx, summary, configMap, options); err != nil {
+		return nil, errors.Wrap(err, "add config map edit action")
+	}
+
 	return summary, nil
 }
 
@@ -150,13 +161,13 @@ func describeConfigMapDataRows(cm *corev1.ConfigMap) ([]component.TableRow, erro
 }
 
 type configMapObject interface {
-	Config(options Options) error
+	Config(ctx context.Context, options Options) error
 	Data(option Options) error
 }
 
 type configMapHandler struct {
 	configMap  *corev1.ConfigMap
-	configFunc func(*corev1.ConfigMap, Options) (*component.Summary, error)
+	configFunc func(context.Context, *corev1.ConfigMap, Options) (*component.Summary, error)
 	dataFunc   func(*corev1.ConfigMap, Options) (*component.Table, error)
 	object     *Object
 }
@@ -182,8 +193,8 @@ func newConfigMapHandler(configMap *corev1.ConfigMap, object *Object) (*configMa
 	return ch, nil
 }
 
-func (c *configMapHandler) Config(options Options) error {
-	out, err := c.configFunc(c.configMap, options)
+func (c *configMapHandler) Config(ctx context.Context, options Options) error {
+	out, err := c.configFunc(ctx, c.configMap, options)
 	if err != nil {
 		return err
 	}
@@ -191,8 +202,8 @@ func (c *configMapHandler) Config(options Options) error {
 	return nil
 }
 
-func defaultConfigMapConfig(configMap *corev1.ConfigMap, options Options) (*component.Summary, error) {
-	return NewConfigMapConfiguration(configMap).Create(options)
+func defaultConfigMapConfig(ctx context.Context, configMap *corev1.ConfigMap, options Options) (*component.Summary, error) {
+	return NewConfigMapConfiguration(configMap).Create(ctx, options)
 }
 
 func (c *configMapHandler) Data(options Options) error {
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package printer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kubenext/kubeon/internal/configmapedit"
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/pkg/store"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+// addConfigMapEditAction adds an action which edits the data of a config map
+// to its configuration summary if the current user is allowed to patch it.
+// Workloads which use the config map are offered for a restart.
+func addConfigMapEditAction(ctx context.Context, summary *component.Summary, configMap *corev1.ConfigMap, options Options) error {
+	key, err := store.KeyFromObject(configMap)
+	if err != nil {
+		return err
+	}
+
+	if !hasAccess(ctx, key, "", "patch", options) {
+		return nil
+	}
+
+	fields := []component.FormField{
+		component.NewFormFieldHidden("resourceVersion", configMap.ResourceVersion),
+	}
+
+	data := configmapedit.Data{Data: configMap.Data, BinaryData: configMap.BinaryData}
+	textKeys, binaryKeys := data.Keys()
+
+	var keyChoices []component.InputChoice
+	for _, name := range textKeys {
+		value := configMap.Data[name]
+
+		label := name
+		if format := configmapedit.Detect(name, value); format != configmapedit.FormatText {
+			label = fmt.Sprintf("%s (%s)", name, format)
+		}
+
+		fields = append(fields, component.NewFormFieldTextarea(label, configmapedit.DataFieldPrefix+name, value))
+		keyChoices = append(keyChoices, component.InputChoice{Label: name, Value: name})
+	}
+	for _, name := range binaryKeys {
+		fields = append(fields, component.NewFormFieldTextarea(fmt.Sprintf("%s (binary, base64)", name),
+			configmapedit.BinaryDataFieldPrefix+name, configmapedit.EncodeBinary(configMap.BinaryData[name])))
+		keyChoices = append(keyChoices, component.InputChoice{Label: name, Value: name})
+	}
+
+	if len(keyChoices) > 0 {
+		fields = append(fields, component.NewFormFieldCheckBox("Remove keys", "remove", keyChoices))
+	}
+
+	fields = append(fields,
+		component.NewFormFieldText("New key", "newKey", ""),
+		component.NewFormFieldTextarea("New value", "newValue", ""),
+		component.NewFormFieldRadio("New value is", "newEncoding", []component.InputChoice{
+			{Label: "Text", Value: configmapedit.EncodingText, Checked: true},
+			{Label: "Binary (base64)", Value: configmapedit.EncodingBase64},
+		}),
+	)
+
+	// the data can still be edited if the workloads to restart can't be found
+	consumers, err := configmapedit.Consumers(ctx, options.DashConfig.ObjectStore(), configMap.Namespace, configMap.Name)
+	if err != nil {
+		log.From(ctx).With("err", err).Warnf("unable to find workloads using config map %q", configMap.Name)
+	}
+
+	if len(consumers) > 0 {
+		var restartChoices []component.InputChoice
+		for _, consumer := range consumers {
+			restartChoices = append(restartChoices, component.InputChoice{Label: consumer.String(), Value: consumer.Value()})
+		}
+		fields = append(fields, component.NewFormFieldCheckBox("Restart after saving", "restart", restartChoices))
+	}
+
+	fields = append(fields, component.NewFormFieldCheckBox("Validation", "ignoreWarnings", []component.InputChoice{
+		{Label: "Save values which aren't valid JSON, YAML or properties", Value: "true"},
+	}))
+
+	form, err := component.CreateFormForObject("overview/editConfigMap", configMap, fields...)
+	if err != nil {
+		return errors.Wrap(err, "create edit config map form")
+	}
+
+	summary.AddAction(component.Action{
+		Name:  "Edit data",
+		Title: fmt.Sprintf("Config Map %s Data", configMap.Name),
+		Form:  form,
+	})
+
+	return nil
+}
@@ -6,8 +6,10 @@
 package printer
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/kubenext/kubeon/internal/proxy"
 	"github.com/kubenext/kubeon/pkg/view/component"
 	"k8s.io/client-go/tools/portforward"
 	"path"
@@ -23,6 +25,7 @@ import (
 
 // ContainerConfiguration generates container configuration.
 type ContainerConfiguration struct {
+	context            context.Context
 	parent             runtime.Object
 	container          *corev1.Container
 	portForwardService portforward.PortForwarder
@@ -31,8 +34,9 @@ type ContainerConfiguration struct {
 }
 
 // NewContainerConfiguration creates an instance of ContainerConfiguration.
-func NewContainerConfiguration(parent runtime.Object, c *corev1.Container, pfs portforward.PortForwarder, isInit bool, options Options) *ContainerConfiguration {
+func NewContainerConfiguration(ctx context.Context, parent runtime.Object, c *corev1.Container, pfs portforward.PortForwarder, isInit bool, options Options) *ContainerConfiguration {
 	return &ContainerConfiguration{
+		context:            ctx,
 		parent:             parent,
 		container:          c,
 		isInit:             isInit,
@@ -73,6 +77,16 @@ func (cc *ContainerConfiguration) Create() (*component.Summary, error) {
 			sections.AddText("Current State", printContainerState(status.State))
 			sections.AddText("Ready", fmt.Sprintf("%t", status.Ready))
 			sections.AddText("Restart Count", fmt.Sprintf("%d", status.RestartCount))
+
+			if status.State.Running != nil && canExec(cc.context, pod, cc.options) {
+				execAction, err := execContainerAction(pod, c)
+				if err != nil {
+					return nil, errors.Wrap(err, "create container exec action")
+				}
+
+				actions = append(actions, execAction)
+				sections.Add("Files", component.NewFileBrowser(pod.Namespace, pod.Name, c.Name, "/"))
+			}
 		} else {
 			switch err.(type) {
 			case *containerNotFoundError:
@@ -82,12 +96,26 @@ func (cc *ContainerConfiguration) Create() (*component.Summary, error) {
 			}
 		}
 	} else {
-		editAction, err := editContainerAction(cc.parent, c)
+		editAction, err := editContainerAction(cc.parent, c, cc.isInit)
 		if err != nil {
 			return nil, errors.Wrap(err, "create container edit action")
 		}
 
 		actions = append(actions, editAction)
+
+		envAction, err := editContainerEnvAction(cc.context, cc.parent, c, cc.isInit, cc.options)
+		if err != nil {
+			return nil, errors.Wrap(err, "create container environment edit action")
+		}
+
+		actions = append(actions, envAction)
+
+		resourcesAction, err := editContainerResourcesAction(cc.parent, c, cc.isInit)
+		if err != nil {
+			return nil, errors.Wrap(err, "create container resources edit action")
+		}
+
+		actions = append(actions, resourcesAction)
 	}
 
 	envTbl, err := describeContainerEnv(cc.parent, c, cc.options)
@@ -233,6 +261,9 @@ func describeContainerPorts(
 					}
 				}
 				pfs.IsForwarded = true
+				pfs.Address = state.Address
+				pfs.Status = string(state.Status)
+				pfs.Message = state.Message
 			}
 		}
 
@@ -245,6 +276,9 @@ func describeContainerPorts(
 			name,
 			int(cPort.ContainerPort),
 			string(cPort.Protocol), pfs)
+		if isPod && cPort.Protocol == corev1.ProtocolTCP {
+			port.Config.PreviewPath = proxy.Path(proxy.PodTarget(namespace, name, int(cPort.ContainerPort)))
+		}
 		list = append(list, *port)
 	}
 	return list, nil
@@ -411,26 +445,19 @@ func describeVolumeMounts(c *corev1.Container) *component.Table {
 	return tbl
 }
 
-func editContainerAction(owner runtime.Object, container *corev1.Container) (component.Action, error) {
+func editContainerAction(owner runtime.Object, container *corev1.Container, isInit bool) (component.Action, error) {
 	if container == nil {
 		return component.Action{}, errors.New("container is nil")
 	}
 
-	containersPath, err := containersPathForObject(owner)
+	fields, err := containerEditorFields(owner, container, isInit)
 	if err != nil {
 		return component.Action{}, err
 	}
 
-	containersPathData, err := json.Marshal(containersPath)
-	if err != nil {
-		return component.Action{}, err
-	}
+	fields = append(fields, component.NewFormFieldText("Image", "containerImage", container.Image))
 
-	form, err := component.CreateFormForObject("overview/containerEditor", owner,
-		component.NewFormFieldText("Image", "containerImage", container.Image),
-		component.NewFormFieldHidden("containersPath", string(containersPathData)),
-		component.NewFormFieldHidden("containerName", container.Name),
-	)
+	form, err := component.CreateFormForObject("overview/containerEditor", owner, fields...)
 	if err != nil {
 		return component.Action{}, err
 	}
@@ -444,6 +471,29 @@ func editContainerAction(owner runtime.Object, container *corev1.Container) (com
 	return action, nil
 }
 
+// containerEditorFields returns the hidden fields which identify a container
+// for the container editor.
+func containerEditorFields(owner runtime.Object, container *corev1.Container, isInit bool) ([]component.FormField, error) {
+	containersPath, err := containersPathForObject(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if isInit {
+		containersPath[len(containersPath)-1] = "initContainers"
+	}
+
+	containersPathData, err := json.Marshal(containersPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return []component.FormField{
+		component.NewFormFieldHidden("containersPath", string(containersPathData)),
+		component.NewFormFieldHidden("containerName", container.Name),
+	}, nil
+}
+
 func containersPathForObject(object runtime.Object) ([]string, error) {
 	if object == nil {
 		return nil, errors.New("object is nil")
@@ -453,9 +503,9 @@ func containersPathForObject(object runtime.Object) ([]string, error) {
 
 	switch {
 	case g.Group == "batch" && g.Kind == "CronJob":
-		return []string{"spec", "jobTemplate", "spec", "template", "containers"}, nil
+		return []string{"spec", "jobTemplate", "spec", "template", "spec", "containers"}, nil
 	case g.Group == "batch" && g.Kind == "Job":
-		return []string{"spec", "template", "containers"}, nil
+		return []string{"spec", "template", "spec", "containers"}, nil
 	case g.Group == "apps" && g.Kind == "DaemonSet":
 		return []string{"spec", "template", "spec", "containers"}, nil
 	case g.Group == "apps" && g.Kind == "Deployment":
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package printer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubenext/kubeon/internal/envedit"
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+// editContainerEnvAction creates an action which edits the environment
+// variables and environment sources of a container. Config map and secret
+// keys are offered as references.
+func editContainerEnvAction(ctx context.Context, owner runtime.Object, container *corev1.Container, isInit bool, options Options) (component.Action, error) {
+	if container == nil {
+		return component.Action{}, errors.New("container is nil")
+	}
+
+	fields, err := containerEditorFields(owner, container, isInit)
+	if err != nil {
+		return component.Action{}, err
+	}
+
+	var sources, keys []envedit.Reference
+	if options.DashConfig != nil {
+		accessor, err := meta.Accessor(owner)
+		if err != nil {
+			return component.Action{}, err
+		}
+
+		// without the config maps and secrets, the form can still edit
+		// variables; it just can't offer new references
+		sources, keys, err = envedit.Available(ctx, options.DashConfig.ObjectStore(), accessor.GetNamespace())
+		if err != nil {
+			log.From(ctx).With("err", err).Warnf("unable to list environment references in %q", accessor.GetNamespace())
+			sources, keys = nil, nil
+		}
+	}
+
+	fields = append(fields,
+		component.NewFormFieldHidden("edit", "env"),
+		component.NewFormFieldTextarea("Variables (one NAME=value per line)", "env", envedit.FormatVariables(container.Env)),
+	)
+
+	var references []component.InputChoice
+	for _, envVar := range container.Env {
+		if envVar.ValueFrom != nil {
+			references = append(references, component.InputChoice{
+				Label:   fmt.Sprintf("%s from %s", envVar.Name, envedit.DescribeValueFrom(envVar.ValueFrom)),
+				Value:   envVar.Name,
+				Checked: true,
+			})
+		}
+	}
+	if len(references) > 0 {
+		fields = append(fields, component.NewFormFieldCheckBox("Keep variables from references", "keepReferences", references))
+	}
+
+	var keyChoices []component.InputChoice
+	for _, key := range keys {
+		keyChoices = append(keyChoices, component.InputChoice{Label: key.Label(), Value: key.Value()})
+	}
+	fields = append(fields,
+		component.NewFormFieldText("New variable from a key", "referenceName", ""),
+		component.NewFormFieldSelect("Key", "referenceKey", keyChoices, false),
+		component.NewFormFieldSelect("Load all keys from", "envFrom", envFromChoices(container.EnvFrom, sources), true),
+	)
+
+	form, err := component.CreateFormForObject("overview/containerEditor", owner, fields...)
+	if err != nil {
+		return component.Action{}, err
+	}
+
+	return component.Action{
+		Name:  "Edit environment",
+		Title: fmt.Sprintf("Container %s Environment", container.Name),
+		Form:  form,
+	}, nil
+}
+
+// envFromChoices returns the config maps and secrets which can be loaded
+// into the environment. Current sources are selected, and offered even if
+// they don't exist.
+func envFromChoices(envFrom []corev1.EnvFromSource, available []envedit.Reference) []component.InputChoice {
+	current := make(map[envedit.Reference]bool)
+	for _, source := range envFrom {
+		if reference, ok := envedit.SourceReference(source); ok {
+			current[reference] = true
+		}
+	}
+
+	var choices []component.InputChoice
+	for _, reference := range available {
+		choices = append(choices, component.InputChoice{
+			Label:   reference.Label(),
+			Value:   reference.Value(),
+			Checked: current[reference],
+		})
+		delete(current, reference)
+	}
+
+	for _, source := range envFrom {
+		if reference, ok := envedit.SourceReference(source); ok && current[reference] {
+			choices = append(choices, component.InputChoice{
+				Label:   reference.Label(),
+				Value:   reference.Value(),
+				Checked: true,
+			})
+			delete(current, reference)
+		}
+	}
+
+	return choices
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package printer
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubenext/kubeon/internal/resourceedit"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+// editContainerResourcesAction creates an action which edits the CPU and
+// memory requests and limits of a container.
+func editContainerResourcesAction(owner runtime.Object, container *corev1.Container, isInit bool) (component.Action, error) {
+	if container == nil {
+		return component.Action{}, errors.New("container is nil")
+	}
+
+	fields, err := containerEditorFields(owner, container, isInit)
+	if err != nil {
+		return component.Action{}, err
+	}
+
+	values := resourceedit.Format(container.Resources)
+
+	fields = append(fields, component.NewFormFieldHidden("edit", "resources"))
+	for _, name := range resourceedit.Edited {
+		fields = append(fields,
+			component.NewFormFieldText(fmt.Sprintf("%s request", name), "requests."+string(name), values.Requests[name]),
+			component.NewFormFieldText(fmt.Sprintf("%s limit", name), "limits."+string(name), values.Limits[name]),
+		)
+	}
+	fields = append(fields, component.NewFormFieldCheckBox("Warnings", "ignoreWarnings", []component.InputChoice{
+		{Label: "Apply even if a LimitRange or ResourceQuota would be violated", Value: "true"},
+	}))
+
+	form, err := component.CreateFormForObject("overview/containerEditor", owner, fields...)
+	if err != nil {
+		return component.Action{}, err
+	}
+
+	return component.Action{
+		Name:  "Edit resources",
+		Title: fmt.Sprintf("Container %s Resources", container.Name),
+		Form:  form,
+	}, nil
+}
+
+// printResourceTotals creates a table of the requests and limits of a pod
+// template, per pod and across the replicas of its workload. If its resources
+// were edited here, the totals from before the last edit are shown too.
+func printResourceTotals(parent runtime.Object, spec corev1.PodSpec) (*component.Table, error) {
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(parent)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert object to unstructured")
+	}
+	object := &unstructured.Unstructured{Object: m}
+	replicas := resourceedit.Replicas(object)
+
+	perPod := resourceedit.PodTotals(spec)
+	totals := resourceedit.Totals(spec, replicas)
+	previous, hasPrevious := resourceedit.PreviousTotals(object.GetAnnotations())
+
+	cols := component.NewTableCols("Resource", "Per Pod", "Replicas", "Total")
+	if hasPrevious {
+		cols = component.NewTableCols("Resource", "Per Pod", "Replicas", "Total", "Before Last Edit")
+	}
+	table := component.NewTable("Resource Totals", "No requests or limits are set", cols)
+
+	for _, name := range resourceedit.TotalNames() {
+		_, ok := perPod[name]
+		_, hadPrevious := previous[name]
+		if !ok && !hadPrevious {
+			continue
+		}
+
+		row := component.TableRow{
+			"Resource": component.NewText(string(name)),
+			"Per Pod":  component.NewText(quantityText(perPod, name)),
+			"Replicas": component.NewText(fmt.Sprintf("%d", replicas)),
+			"Total":    component.NewText(quantityText(totals, name)),
+		}
+		if hasPrevious {
+			row["Before Last Edit"] = component.NewText(quantityText(previous, name))
+		}
+
+		table.Add(row)
+	}
+
+	return table, nil
+}
+
+// quantityText returns a quantity from a list, or "none" if it isn't set.
+func quantityText(list corev1.ResourceList, name corev1.ResourceName) string {
+	q, ok := list[name]
+	if !ok {
+		return "none"
+	}
+	return q.String()
+}
@@ -43,9 +43,15 @@ func CronJobListHandler(ctx context.Context, list *batchv1beta1.CronJobList, opt
 		ts := c.CreationTimestamp.Time
 		row["Age"] = component.NewTimestamp(ts)
 
+		if err := setRowKey(row, &c); err != nil {
+			return nil, err
+		}
+
 		tbl.Add(row)
 	}
 
+	addBulkActions(ctx, tbl, false, opts)
+
 	return tbl, nil
 }
 
@@ -59,7 +65,7 @@ func CronJobHandler(ctx context.Context, cronJob *batchv1beta1.CronJob, options
 		return nil, err
 	}
 
-	if err := ch.Config(options); err != nil {
+	if err := ch.Config(ctx, options); err != nil {
 		return nil, errors.Wrap(err, "print cronjob configuration")
 	}
 
@@ -130,13 +136,13 @@ func (cc *CronJobConfiguration) Create() (*component.Summary, error) {
 }
 
 type cronJobObject interface {
-	Config(options Options) error
+	Config(ctx context.Context, options Options) error
 	Jobs(ctx context.Context, object runtime.Object, options Options) error
 }
 
 type cronJobHandler struct {
 	cronJob    *batchv1beta1.CronJob
-	configFunc func(*batchv1beta1.CronJob, Options) (*component.Summary, error)
+	configFunc func(context.Context, *batchv1beta1.CronJob, Options) (*component.Summary, error)
 	jobFunc    func(context.Context, runtime.Object, Options) (component.Component, error)
 	object     *Object
 }
@@ -161,8 +167,8 @@ func newCronJobHandler(cronJob *batchv1beta1.CronJob, object *Object) (*cronJobH
 	return ch, nil
 }
 
-func (c *cronJobHandler) Config(options Options) error {
-	out, err := c.configFunc(c.cronJob, options)
+func (c *cronJobHandler) Config(ctx context.Context, options Options) error {
+	out, err := c.configFunc(ctx, c.cronJob, options)
 	if err != nil {
 		return err
 	}
@@ -170,8 +176,17 @@ func (c *cronJobHandler) Config(options Options) error {
 	return nil
 }
 
-func defaultCronJobConfig(cronJob *batchv1beta1.CronJob, options Options) (*component.Summary, error) {
-	return NewCronJobConfiguration(cronJob).Create()
+func defaultCronJobConfig(ctx context.Context, cronJob *batchv1beta1.CronJob, options Options) (*component.Summary, error) {
+	summary, err := NewCronJobConfiguration(cronJob).Create()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := addCronJobActions(ctx, summary, cronJob, options); err != nil {
+		return nil, errors.Wrap(err, "add cron job actions")
+	}
+
+	return summary, nil
 }
 
 func (c *cronJobHandler) Jobs(ctx context.Context, object runtime.Object, options Options) error {
@@ -187,5 +202,5 @@ func (c *cronJobHandler) Jobs(ctx context.Context, object runtime.Object, option
 }
 
 func defaultCronJobJobs(ctx context.Context, object runtime.Object, options Options) (component.Component, error) {
-	return createJobListView(ctx, object, options)
+	return createCronJobRunsView(ctx, object, options)
 }
@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package printer
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubenext/kubeon/internal/cronjob"
+	"github.com/kubenext/kubeon/pkg/store"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+var (
+	cronJobRunCols = component.NewTableCols("Job", "Trigger", "Outcome", "Started", "Duration", "Pods")
+)
+
+// addCronJobActions adds run now and suspend or resume actions to a cron job
+// configuration summary if the current user is allowed to use them.
+func addCronJobActions(ctx context.Context, summary *component.Summary, cronJob *batchv1beta1.CronJob, options Options) error {
+	jobKey := store.Key{Namespace: cronJob.Namespace, ApiVersion: "batch/v1", Kind: "Job"}
+	if hasAccess(ctx, jobKey, "", "create", options) {
+		run, err := cronJobAction(cronJob, "Run now", "Run now", cronjob.OperationRun)
+		if err != nil {
+			return err
+		}
+		summary.AddAction(run)
+	}
+
+	key, err := store.KeyFromObject(cronJob)
+	if err != nil {
+		return err
+	}
+
+	if hasAccess(ctx, key, "", "patch", options) {
+		suspend, err := cronJobAction(cronJob, "Suspend", "Suspend cron job", cronjob.OperationSuspend)
+		if cronjob.IsSuspended(cronJob) {
+			suspend, err = cronJobAction(cronJob, "Resume", "Resume cron job", cronjob.OperationResume)
+		}
+		if err != nil {
+			return err
+		}
+		summary.AddAction(suspend)
+	}
+
+	return nil
+}
+
+func cronJobAction(cronJob *batchv1beta1.CronJob, name, title, operation string) (component.Action, error) {
+	form, err := component.CreateFormForObject("overview/cronJob", cronJob,
+		component.NewFormFieldHidden("operation", operation))
+	if err != nil {
+		return component.Action{}, errors.Wrapf(err, "create %s action", operation)
+	}
+
+	return component.Action{
+		Name:  name,
+		Title: title,
+		Form:  form,
+	}, nil
+}
+
+// createCronJobRunsView creates a table of the jobs run by a cron job with
+// their outcome, duration and pods.
+func createCronJobRunsView(ctx context.Context, object runtime.Object, options Options) (component.Component, error) {
+	cronJob, ok := object.(*batchv1beta1.CronJob)
+	if !ok {
+		return nil, errors.Errorf("expected cron job; got %T", object)
+	}
+
+	objectStore := options.DashConfig.ObjectStore()
+
+	jobKey := store.Key{Namespace: cronJob.Namespace, ApiVersion: "batch/v1", Kind: "Job"}
+	jobList, _, err := objectStore.List(ctx, jobKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list all objects for key %+v", jobKey)
+	}
+
+	var jobs []*batchv1.Job
+	for i := range jobList.Items {
+		job := &batchv1.Job{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(jobList.Items[i].Object, job); err != nil {
+			return nil, err
+		}
+		if err := copyObjectMeta(job, &jobList.Items[i]); err != nil {
+			return nil, errors.Wrap(err, "copy object metadata")
+		}
+		jobs = append(jobs, job)
+	}
+
+	podKey := store.Key{Namespace: cronJob.Namespace, ApiVersion: "v1", Kind: "Pod"}
+	podList, _, err := objectStore.List(ctx, podKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list all objects for key %+v", podKey)
+	}
+
+	var pods []*corev1.Pod
+	for i := range podList.Items {
+		pod := &corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(podList.Items[i].Object, pod); err != nil {
+			return nil, err
+		}
+		if err := copyObjectMeta(pod, &podList.Items[i]); err != nil {
+			return nil, errors.Wrap(err, "copy object metadata")
+		}
+		pods = append(pods, pod)
+	}
+
+	table := component.NewTable("Recent Runs", "This cron job hasn't run any jobs!", cronJobRunCols)
+
+	for _, run := range cronjob.Runs(cronJob, jobs, pods, time.Now()) {
+		row := component.TableRow{}
+
+		jobLink, err := options.Link.ForGVK(run.Job.Namespace, "batch/v1", "Job", run.Job.Name, run.Job.Name)
+		if err != nil {
+			return nil, err
+		}
+		row["Job"] = jobLink
+
+		trigger := "Schedule"
+		if run.Manual {
+			trigger = "Manual"
+		}
+		row["Trigger"] = component.NewText(trigger)
+		row["Outcome"] = component.NewText(run.Outcome)
+
+		if run.Started.IsZero() {
+			row["Started"] = component.NewText("-")
+			row["Duration"] = component.NewText("-")
+		} else {
+			row["Started"] = component.NewTimestamp(run.Started)
+			row["Duration"] = component.NewText(run.Duration.String())
+		}
+
+		var podLinks []component.Component
+		for _, pod := range run.Pods {
+			podLink, err := options.Link.ForGVK(pod.Namespace, "v1", "Pod", pod.Name, pod.Name)
+			if err != nil {
+				return nil, err
+			}
+			podLinks = append(podLinks, podLink)
+		}
+		row["Pods"] = component.NewList("", podLinks)
+
+		table.Add(row)
+	}
+
+	return table, nil
+}
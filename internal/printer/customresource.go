@@ -160,6 +160,10 @@ func CustomResourceHandler(
 		return nil, err
 	}
 
+	if err := addCustomResourceScaleAction(ctx, configSummary, object, crd, options); err != nil {
+		return nil, err
+	}
+
 	o.RegisterConfig(configSummary)
 	o.RegisterSummary(statusSummary)
 	o.EnableEvents()
@@ -204,6 +208,29 @@ func printCustomResourceConfig(u *unstructured.Unstructured, crd *apiextv1beta1.
 	return summary, nil
 }
 
+// addCustomResourceScaleAction adds a scale action for custom resources
+// whose definition has the scale subresource.
+func addCustomResourceScaleAction(ctx context.Context, summary *component.Summary, u *unstructured.Unstructured, crd *apiextv1beta1.CustomResourceDefinition, options Options) error {
+	subresources := crd.Spec.Subresources
+	for _, version := range crd.Spec.Versions {
+		if version.Subresources != nil && u.GroupVersionKind().Version == version.Name {
+			subresources = version.Subresources
+		}
+	}
+
+	if subresources == nil || subresources.Scale == nil {
+		return nil
+	}
+
+	path := strings.Split(strings.TrimPrefix(subresources.Scale.SpecReplicasPath, "."), ".")
+	replicas, _, err := unstructured.NestedInt64(u.Object, path...)
+	if err != nil {
+		return errors.Wrap(err, "find custom resource replicas")
+	}
+
+	return addScaleAction(ctx, summary, u, int32(replicas), options)
+}
+
 func printCustomResourceStatus(u *unstructured.Unstructured, crd *apiextv1beta1.CustomResourceDefinition) (*component.Summary, error) {
 	if crd == nil {
 		return nil, errors.New("CRD is nil")
@@ -17,7 +17,7 @@ import (
 )
 
 // DaemonSetListHandler is a printFunc that lists daemon sets
-func DaemonSetListHandler(_ context.Context, list *appsv1.DaemonSetList, opts Options) (component.Component, error) {
+func DaemonSetListHandler(ctx context.Context, list *appsv1.DaemonSetList, opts Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("daemon set list is nil")
 	}
@@ -42,9 +42,15 @@ func DaemonSetListHandler(_ context.Context, list *appsv1.DaemonSetList, opts Op
 		row["Age"] = component.NewTimestamp(daemonSet.ObjectMeta.CreationTimestamp.Time)
 		row["Node Selector"] = printSelectorMap(daemonSet.Spec.Template.Spec.NodeSelector)
 
+		if err := setRowKey(row, &daemonSet); err != nil {
+			return nil, err
+		}
+
 		table.Add(row)
 	}
 
+	addBulkActions(ctx, table, true, opts)
+
 	return table, nil
 }
 
@@ -58,7 +64,7 @@ func DaemonSetHandler(ctx context.Context, daemonSet *appsv1.DaemonSet, options
 		return nil, err
 	}
 
-	if err := dsh.Config(options); err != nil {
+	if err := dsh.Config(ctx, options); err != nil {
 		return nil, errors.Wrap(err, "print daemonset configuration")
 	}
 
@@ -70,6 +76,8 @@ func DaemonSetHandler(ctx context.Context, daemonSet *appsv1.DaemonSet, options
 		return nil, errors.Wrap(err, "print daemonset pods")
 	}
 
+	registerWorkloadLogs(o, daemonSet.Namespace, daemonSet.Spec.Selector, daemonSet.Spec.Template)
+
 	return o.ToComponent(ctx, options)
 }
 
@@ -141,14 +149,14 @@ func createDaemonSetSummaryStatus(daemonSet *appsv1.DaemonSet) (*component.Summa
 }
 
 type daemonSetObject interface {
-	Config(options Options) error
+	Config(ctx context.Context, options Options) error
 	Status(options Options) error
 	Pods(ctx context.Context, object runtime.Object, options Options) error
 }
 
 type daemonSetHandler struct {
 	daemonSet  *appsv1.DaemonSet
-	configFunc func(*appsv1.DaemonSet, Options) (*component.Summary, error)
+	configFunc func(context.Context, *appsv1.DaemonSet, Options) (*component.Summary, error)
 	statusFunc func(*appsv1.DaemonSet, Options) (*component.Summary, error)
 	podFunc    func(context.Context, runtime.Object, Options) (component.Component, error)
 	object     *Object
@@ -176,8 +184,8 @@ func newDaemonSetHandler(daemonSet *appsv1.DaemonSet, object *Object) (*daemonSe
 	return dh, nil
 }
 
-func (d *daemonSetHandler) Config(options Options) error {
-	out, err := d.configFunc(d.daemonSet, options)
+func (d *daemonSetHandler) Config(ctx context.Context, options Options) error {
+	out, err := d.configFunc(ctx, d.daemonSet, options)
 	if err != nil {
 		return err
 	}
@@ -185,8 +193,17 @@ func (d *daemonSetHandler) Config(options Options) error {
 	return nil
 }
 
-func defaultDaemonSetConfig(daemonSet *appsv1.DaemonSet, options Options) (*component.Summary, error) {
-	return NewDaemonSetConfiguration(daemonSet).Create()
+func defaultDaemonSetConfig(ctx context.Context, daemonSet *appsv1.DaemonSet, options Options) (*component.Summary, error) {
+	summary, err := NewDaemonSetConfiguration(daemonSet).Create()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := addRolloutActions(ctx, summary, daemonSet, options); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
 }
 
 func (d *daemonSetHandler) Status(options Options) error {
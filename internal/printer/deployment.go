@@ -23,7 +23,7 @@ var (
 )
 
 // DeploymentListHandler is a printFunc that lists deployments
-func DeploymentListHandler(_ context.Context, list *appsv1.DeploymentList, opts Options) (component.Component, error) {
+func DeploymentListHandler(ctx context.Context, list *appsv1.DeploymentList, opts Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("nil list")
 	}
@@ -55,8 +55,15 @@ func DeploymentListHandler(_ context.Context, list *appsv1.DeploymentList, opts
 		row["Containers"] = containers
 		row["Selector"] = printSelector(d.Spec.Selector)
 
+		if err := setRowKey(row, &d); err != nil {
+			return nil, err
+		}
+
 		tbl.Add(row)
 	}
+
+	addBulkActions(ctx, tbl, true, opts)
+
 	return tbl, nil
 }
 
@@ -70,7 +77,7 @@ func DeploymentHandler(ctx context.Context, deployment *appsv1.Deployment, optio
 		return nil, err
 	}
 
-	if err := dh.Config(); err != nil {
+	if err := dh.Config(ctx, options); err != nil {
 		return nil, errors.Wrap(err, "print deployment configuration")
 	}
 	if err := dh.Status(); err != nil {
@@ -83,6 +90,8 @@ func DeploymentHandler(ctx context.Context, deployment *appsv1.Deployment, optio
 		return nil, errors.Wrap(err, "print deployment conditions")
 	}
 
+	registerWorkloadLogs(o, deployment.Namespace, deployment.Spec.Selector, deployment.Spec.Template)
+
 	return o.ToComponent(ctx, options)
 }
 
@@ -278,7 +287,7 @@ func editDeploymentAction(deployment *appsv1.Deployment) ([]component.Action, er
 }
 
 type deploymentObject interface {
-	Config() error
+	Config(ctx context.Context, options Options) error
 	Status() error
 	Pods(ctx context.Context, object runtime.Object, options Options) error
 	Conditions() error
@@ -286,7 +295,7 @@ type deploymentObject interface {
 
 type deploymentHandler struct {
 	deployment     *appsv1.Deployment
-	configFunc     func(*appsv1.Deployment) (*component.Summary, error)
+	configFunc     func(context.Context, *appsv1.Deployment, Options) (*component.Summary, error)
 	summaryFunc    func(*appsv1.Deployment) (*component.Summary, error)
 	podFunc        func(context.Context, []runtime.Object, Options) (component.Component, error)
 	conditionsFunc func(*appsv1.Deployment) (*component.Table, error)
@@ -316,8 +325,8 @@ func newDeploymentHandler(deployment *appsv1.Deployment, object *Object) (*deplo
 	return dh, nil
 }
 
-func (d *deploymentHandler) Config() error {
-	out, err := d.configFunc(d.deployment)
+func (d *deploymentHandler) Config(ctx context.Context, options Options) error {
+	out, err := d.configFunc(ctx, d.deployment, options)
 	if err != nil {
 		return err
 	}
@@ -326,8 +335,17 @@ func (d *deploymentHandler) Config() error {
 	return nil
 }
 
-func defaultDeploymentConfig(deployment *appsv1.Deployment) (*component.Summary, error) {
-	return NewDeploymentConfiguration(deployment).Create()
+func defaultDeploymentConfig(ctx context.Context, deployment *appsv1.Deployment, options Options) (*component.Summary, error) {
+	summary, err := NewDeploymentConfiguration(deployment).Create()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := addRolloutActions(ctx, summary, deployment, options); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
 }
 
 func (d *deploymentHandler) Status() error {
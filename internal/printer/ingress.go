@@ -19,7 +19,7 @@ import (
 )
 
 // IngressListHandler is a printFunc that prints ingresses
-func IngressListHandler(_ context.Context, list *extv1beta1.IngressList, options Options) (component.Component, error) {
+func IngressListHandler(ctx context.Context, list *extv1beta1.IngressList, options Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("ingress list is nil")
 	}
@@ -46,9 +46,15 @@ func IngressListHandler(_ context.Context, list *extv1beta1.IngressList, options
 		row["Ports"] = component.NewText(ports)
 		row["Age"] = component.NewTimestamp(ingress.CreationTimestamp.Time)
 
+		if err := setRowKey(row, &ingress); err != nil {
+			return nil, err
+		}
+
 		table.Add(row)
 	}
 
+	addBulkActions(ctx, table, false, options)
+
 	return table, nil
 }
 
@@ -11,11 +11,9 @@ import (
 
 	"github.com/pkg/errors"
 	batchv1 "k8s.io/api/batch/v1"
-	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/kubenext/kubeon/internal/conversion"
-	"github.com/kubenext/kubeon/pkg/store"
 	"github.com/kubenext/kubeon/pkg/view/component"
 )
 
@@ -24,7 +22,7 @@ var (
 )
 
 // JobListHandler prints a job list.
-func JobListHandler(_ context.Context, list *batchv1.JobList, opts Options) (component.Component, error) {
+func JobListHandler(ctx context.Context, list *batchv1.JobList, opts Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("job list is nil")
 	}
@@ -46,9 +44,15 @@ func JobListHandler(_ context.Context, list *batchv1.JobList, opts Options) (com
 		row["Successful"] = component.NewText(succeeded)
 		row["Age"] = component.NewTimestamp(job.CreationTimestamp.Time)
 
+		if err := setRowKey(row, &job); err != nil {
+			return nil, err
+		}
+
 		table.Add(row)
 	}
 
+	addBulkActions(ctx, table, false, opts)
+
 	return table, nil
 }
 
@@ -78,6 +82,8 @@ func JobHandler(ctx context.Context, job *batchv1.Job, options Options) (compone
 		return nil, errors.Wrap(err, "print job conditions")
 	}
 
+	registerWorkloadLogs(o, job.Namespace, job.Spec.Selector, job.Spec.Template)
+
 	return o.ToComponent(ctx, options)
 }
 
@@ -149,68 +155,6 @@ func createJobConditions(conditions []batchv1.JobCondition) (*component.Table, e
 	return table, nil
 }
 
-func createJobListView(ctx context.Context, object runtime.Object, options Options) (component.Component, error) {
-	options.DisableLabels = true
-
-	jobList := &batchv1.JobList{}
-
-	objectStore := options.DashConfig.ObjectStore()
-	accessor := meta.NewAccessor()
-
-	namespace, err := accessor.Namespace(object)
-	if err != nil {
-		return nil, errors.Wrap(err, "get namespace for object")
-	}
-
-	apiVersion, err := accessor.APIVersion(object)
-	if err != nil {
-		return nil, errors.Wrap(err, "Get apiVersion for object")
-	}
-
-	kind, err := accessor.Kind(object)
-	if err != nil {
-		return nil, errors.Wrap(err, "get kind for object")
-	}
-
-	name, err := accessor.Name(object)
-	if err != nil {
-		return nil, errors.Wrap(err, "get name for object")
-	}
-
-	key := store.Key{
-		Namespace:  namespace,
-		APIVersion: "batch/v1beta1",
-		Kind:       "Job",
-	}
-
-	list, _, err := objectStore.List(ctx, key)
-	if err != nil {
-		return nil, errors.Wrapf(err, "list all objects for key %+v", key)
-	}
-
-	for i := range list.Items {
-		job := &batchv1.Job{}
-		err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, job)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := copyObjectMeta(job, &list.Items[i]); err != nil {
-			return nil, errors.Wrap(err, "copy object metadata")
-		}
-
-		for _, ownerReference := range job.OwnerReferences {
-			if ownerReference.APIVersion == apiVersion &&
-				ownerReference.Kind == kind &&
-				ownerReference.Name == name {
-				jobList.Items = append(jobList.Items, *job)
-			}
-		}
-	}
-
-	return JobListHandler(ctx, jobList, options)
-}
-
 type jobObject interface {
 	Config(options Options) error
 	Status(options Options) error
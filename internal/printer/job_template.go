@@ -6,6 +6,8 @@
 package printer
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -26,7 +28,7 @@ func NewJobTemplate(parent runtime.Object, jobTemplateSpec batchv1beta1.JobTempl
 	}
 }
 
-func (jt *JobTemplate) AddToFlexLayout(fl *flexlayout.FlexLayout, options Options) error {
+func (jt *JobTemplate) AddToFlexLayout(ctx context.Context, fl *flexlayout.FlexLayout, options Options) error {
 	if fl == nil {
 		return errors.New("flex layout is nil")
 	}
@@ -47,7 +49,7 @@ func (jt *JobTemplate) AddToFlexLayout(fl *flexlayout.FlexLayout, options Option
 	containerSection := fl.AddSection()
 
 	for _, container := range jt.jobTemplateSpec.Spec.Template.Spec.Containers {
-		containerConfig := NewContainerConfiguration(jt.parent, &container, portForwarder, false, options)
+		containerConfig := NewContainerConfiguration(ctx, jt.parent, &container, portForwarder, false, options)
 		summary, err := containerConfig.Create()
 		if err != nil {
 			return err
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package printer
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+// logColumnsAnnotation is set on pod templates of workloads which write
+// structured logs. Its value is a comma separated list of the fields shown
+// as columns, and the logs view starts in table mode.
+const logColumnsAnnotation = "logs.kubeon.io/columns"
+
+// registerWorkloadLogs registers a logs view which streams the logs for all pods
+// managed by a workload. Nothing is registered if the workload has no selector.
+func registerWorkloadLogs(o *Object, namespace string, selector *metav1.LabelSelector, template corev1.PodTemplateSpec) {
+	if o == nil || selector == nil {
+		return
+	}
+
+	o.RegisterItems(ItemDescriptor{
+		Width: component.WidthFull,
+		Func: func() (component.Component, error) {
+			return createWorkloadLogsView(namespace, selector, template)
+		},
+	})
+}
+
+func createWorkloadLogsView(namespace string, selector *metav1.LabelSelector, template corev1.PodTemplateSpec) (*component.Logs, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert label selector")
+	}
+
+	var containerNames []string
+	for _, c := range template.Spec.InitContainers {
+		containerNames = append(containerNames, c.Name)
+	}
+	for _, c := range template.Spec.Containers {
+		containerNames = append(containerNames, c.Name)
+	}
+
+	logs := component.NewSelectorLogs(namespace, labelSelector.String(), containerNames)
+	if columns, ok := logColumns(template.Annotations); ok {
+		logs.SetTableMode(columns...)
+	}
+
+	return logs, nil
+}
+
+// logColumns returns the log columns listed in a pod template's annotations.
+// The columns are empty if the annotation is set without any, so the
+// default columns are used.
+func logColumns(annotations map[string]string) ([]string, bool) {
+	value, ok := annotations[logColumnsAnnotation]
+	if !ok {
+		return nil, false
+	}
+
+	var columns []string
+	for _, column := range strings.Split(value, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns, true
+}
@@ -16,8 +16,9 @@ import (
 )
 
 type Metadata struct {
-	object runtime.Object
-	link   link2.Interface
+	object  runtime.Object
+	link    link2.Interface
+	actions []component.Action
 }
 
 func NewMetadata(object runtime.Object, l link2.Interface) (*Metadata, error) {
@@ -35,6 +36,11 @@ func NewMetadata(object runtime.Object, l link2.Interface) (*Metadata, error) {
 	}, nil
 }
 
+// AddAction adds an action to the metadata summary.
+func (m *Metadata) AddAction(action component.Action) {
+	m.actions = append(m.actions, action)
+}
+
 func (m *Metadata) AddToFlexLayout(fl *flexlayout.FlexLayout) error {
 	if fl == nil {
 		return errors.New("flex layout is nil")
@@ -88,5 +94,9 @@ func (m *Metadata) createSummary() (*component.Summary, error) {
 	}
 
 	summary := component.NewSummary("Metadata", sections...)
+	for _, action := range m.actions {
+		summary.AddAction(action)
+	}
+
 	return summary, nil
 }
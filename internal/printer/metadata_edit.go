@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package printer
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubenext/kubeon/internal/metadataedit"
+	"github.com/kubenext/kubeon/pkg/store"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+// addMetadataEditAction adds an action for editing labels and annotations to
+// a metadata summary if the current user can update the object.
+func addMetadataEditAction(ctx context.Context, metadata *Metadata, object runtime.Object, options Options) error {
+	key, err := store.KeyFromObject(object)
+	if err != nil {
+		return err
+	}
+
+	if !hasAccess(ctx, key, "", "patch", options) {
+		return nil
+	}
+
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return err
+	}
+
+	fields := []component.FormField{
+		component.NewFormFieldTextarea("Labels (one key=value per line)", "labels", metadataedit.Format(accessor.GetLabels())),
+		component.NewFormFieldTextarea("Annotations (one key=value per line)", "annotations", metadataedit.Format(accessor.GetAnnotations())),
+		component.NewFormFieldHidden("resourceVersion", accessor.GetResourceVersion()),
+	}
+
+	if key.Kind == "Pod" {
+		fields = append(fields, component.NewFormFieldCheckBox("Selectors", "ignoreSelectors", []component.InputChoice{
+			{Label: "Apply even if the pod leaves a Service or controller selector", Value: "true"},
+		}))
+	}
+
+	form, err := component.CreateFormForObject("overview/editMetadata", object, fields...)
+	if err != nil {
+		return errors.Wrap(err, "create metadata edit form")
+	}
+
+	metadata.AddAction(component.Action{
+		Name:  "Edit labels and annotations",
+		Title: "Edit labels and annotations",
+		Form:  form,
+	})
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	"github.com/kubenext/kubeon/internal/terminal"
 	"github.com/kubenext/kubeon/pkg/view/component"
 )
 
@@ -24,6 +25,9 @@ const (
 
 	// nodeLabelRole specifies the role of a node
 	nodeLabelRole = "kubernetes.io/role"
+
+	// defaultDebugNamespace is the namespace suggested for node debug pods
+	defaultDebugNamespace = "default"
 )
 
 var (
@@ -52,9 +56,15 @@ func NodeListHandler(ctx context.Context, list *corev1.NodeList, options Options
 		row["Age"] = component.NewTimestamp(node.CreationTimestamp.Time)
 		row["Version"] = component.NewText(node.Status.NodeInfo.KubeletVersion)
 
+		if err := setRowKey(row, &node); err != nil {
+			return nil, err
+		}
+
 		table.Add(row)
 	}
 
+	addBulkActions(ctx, table, false, options)
+
 	return table, nil
 }
 
@@ -82,6 +92,9 @@ func NodeHandler(ctx context.Context, node *corev1.Node, options Options) (compo
 	if err := nh.Images(options); err != nil {
 		return nil, errors.Wrap(err, "print node images")
 	}
+
+	registerTerminals(o, terminal.Target{Kind: "Node", Name: node.Name}, options)
+
 	return o.ToComponent(ctx, options)
 }
 
@@ -293,6 +306,18 @@ func (n *NodeConfiguration) Create(options Options) (*component.Summary, error)
 		},
 	}...)
 
+	if canDebug(context.Background(), defaultDebugNamespace, options) {
+		debugAction, err := debugNodeAction(n.node, defaultDebugNamespace)
+		if err != nil {
+			return nil, errors.Wrap(err, "create node debug action")
+		}
+		summary.AddAction(debugAction)
+	}
+
+	if err := addNodeMaintenanceActions(context.Background(), summary, n.node, options); err != nil {
+		return nil, errors.Wrap(err, "create node maintenance actions")
+	}
+
 	return summary, nil
 }
 
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package printer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kubenext/kubeon/internal/drain"
+	"github.com/kubenext/kubeon/pkg/store"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+// addNodeMaintenanceActions adds cordon or uncordon, and drain actions to a
+// node summary if the current user can update the node.
+func addNodeMaintenanceActions(ctx context.Context, summary *component.Summary, node *corev1.Node, options Options) error {
+	key, err := store.KeyFromObject(node)
+	if err != nil {
+		return err
+	}
+
+	if !hasAccess(ctx, key, "", "patch", options) {
+		return nil
+	}
+
+	cordon, err := nodeMaintenanceAction(node, "Cordon", "Cordon node", drain.OperationCordon)
+	if node.Spec.Unschedulable {
+		cordon, err = nodeMaintenanceAction(node, "Uncordon", "Uncordon node", drain.OperationUncordon)
+	}
+	if err != nil {
+		return err
+	}
+	summary.AddAction(cordon)
+
+	drainAction, err := nodeMaintenanceAction(node, "Drain", fmt.Sprintf("Drain Node %s", node.Name), drain.OperationDrain,
+		component.NewFormFieldCheckBox("Options", "drainOptions", []component.InputChoice{
+			{Label: "Ignore DaemonSet pods", Value: drain.OptionIgnoreDaemonSets, Checked: true},
+			{Label: "Delete emptyDir data", Value: drain.OptionDeleteEmptyDirData},
+		}),
+		component.NewFormFieldNumber("Grace period seconds (blank for each pod's own)", "gracePeriod", ""),
+		component.NewFormFieldText("Timeout", "timeout", drain.DefaultTimeout.String()),
+	)
+	if err != nil {
+		return err
+	}
+	summary.AddAction(drainAction)
+
+	return nil
+}
+
+func nodeMaintenanceAction(node *corev1.Node, name, title, operation string, fields ...component.FormField) (component.Action, error) {
+	fields = append([]component.FormField{component.NewFormFieldHidden("operation", operation)}, fields...)
+
+	form, err := component.CreateFormForObject("overview/nodeMaintenance", node, fields...)
+	if err != nil {
+		return component.Action{}, errors.Wrapf(err, "create %s action", operation)
+	}
+
+	return component.Action{
+		Name:  name,
+		Title: title,
+		Form:  form,
+	}, nil
+}
@@ -29,12 +29,16 @@ type ObjectInterface interface {
 	AddButton(name string, payload action.Payload, buttonOptions ...component.ButtonOption)
 }
 
-func defaultMetadataGen(object runtime.Object, fl *flexlayout.FlexLayout, options Options) error {
+func defaultMetadataGen(ctx context.Context, object runtime.Object, fl *flexlayout.FlexLayout, options Options) error {
 	metadata, err := NewMetadata(object, options.Link)
 	if err != nil {
 		return errors.Wrap(err, "create metadata generator")
 	}
 
+	if err := addMetadataEditAction(ctx, metadata, object, options); err != nil {
+		return errors.Wrap(err, "add metadata edit action")
+	}
+
 	if err := metadata.AddToFlexLayout(fl); err != nil {
 		return errors.Wrap(err, "add metadata to layout")
 	}
@@ -42,18 +46,18 @@ func defaultMetadataGen(object runtime.Object, fl *flexlayout.FlexLayout, option
 	return nil
 }
 
-func defaultPodTemplateGen(object runtime.Object, template corev1.PodTemplateSpec, fl *flexlayout.FlexLayout, options Options) error {
+func defaultPodTemplateGen(ctx context.Context, object runtime.Object, template corev1.PodTemplateSpec, fl *flexlayout.FlexLayout, options Options) error {
 	podTemplate := NewPodTemplate(object, template)
-	if err := podTemplate.AddToFlexLayout(fl, options); err != nil {
+	if err := podTemplate.AddToFlexLayout(ctx, fl, options); err != nil {
 		return errors.Wrap(err, "add pod template to layout")
 	}
 
 	return nil
 }
 
-func defaultJobTemplateGen(object runtime.Object, template batchv1beta1.JobTemplateSpec, fl *flexlayout.FlexLayout, options Options) error {
+func defaultJobTemplateGen(ctx context.Context, object runtime.Object, template batchv1beta1.JobTemplateSpec, fl *flexlayout.FlexLayout, options Options) error {
 	podTemplate := NewJobTemplate(object, template)
-	if err := podTemplate.AddToFlexLayout(fl, options); err != nil {
+	if err := podTemplate.AddToFlexLayout(ctx, fl, options); err != nil {
 		return errors.Wrap(err, "add job template to layout")
 	}
 
@@ -109,9 +113,9 @@ type Object struct {
 
 	flexLayout *flexlayout.FlexLayout
 
-	MetadataGen    func(runtime.Object, *flexlayout.FlexLayout, Options) error
-	PodTemplateGen func(runtime.Object, corev1.PodTemplateSpec, *flexlayout.FlexLayout, Options) error
-	JobTemplateGen func(runtime.Object, batchv1beta1.JobTemplateSpec, *flexlayout.FlexLayout, Options) error
+	MetadataGen    func(context.Context, runtime.Object, *flexlayout.FlexLayout, Options) error
+	PodTemplateGen func(context.Context, runtime.Object, corev1.PodTemplateSpec, *flexlayout.FlexLayout, Options) error
+	JobTemplateGen func(context.Context, runtime.Object, batchv1beta1.JobTemplateSpec, *flexlayout.FlexLayout, Options) error
 	EventsGen      func(ctx context.Context, object runtime.Object, fl *flexlayout.FlexLayout, options Options) error
 }
 
@@ -253,7 +257,7 @@ func (o *Object) ToComponent(ctx context.Context, options Options) (component.Co
 		return nil, errors.Wrap(err, "generate summary component")
 	}
 
-	if err := o.MetadataGen(o.object, o.flexLayout, options); err != nil {
+	if err := o.MetadataGen(ctx, o.object, o.flexLayout, options); err != nil {
 		return nil, errors.Wrap(err, "generate metadata")
 	}
 
@@ -283,13 +287,13 @@ func (o *Object) ToComponent(ctx context.Context, options Options) (component.Co
 	}
 
 	if o.isPodTemplateEnabled {
-		if err := o.PodTemplateGen(o.object, o.podTemplateOptions.template, o.flexLayout, options); err != nil {
+		if err := o.PodTemplateGen(ctx, o.object, o.podTemplateOptions.template, o.flexLayout, options); err != nil {
 			return nil, errors.Wrap(err, "generate pod template")
 		}
 	}
 
 	if o.isJobTemplateEnabled {
-		if err := o.JobTemplateGen(o.object, o.jobTemplateOptions.template, o.flexLayout, options); err != nil {
+		if err := o.JobTemplateGen(ctx, o.object, o.jobTemplateOptions.template, o.flexLayout, options); err != nil {
 			return nil, errors.Wrap(err, "generate job template")
 		}
 	}
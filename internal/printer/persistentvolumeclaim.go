@@ -55,8 +55,15 @@ func PersistentVolumeClaimListHandler(ctx context.Context, list *corev1.Persiste
 		ts := persistentVolumeClaim.CreationTimestamp.Time
 		row["Age"] = component.NewTimestamp(ts)
 
+		if err := setRowKey(row, &persistentVolumeClaim); err != nil {
+			return nil, err
+		}
+
 		tbl.Add(row)
 	}
+
+	addBulkActions(ctx, tbl, false, options)
+
 	return tbl, nil
 }
 
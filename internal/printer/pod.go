@@ -22,6 +22,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 
 	"github.com/kubenext/kubeon/internal/link"
+	"github.com/kubenext/kubeon/internal/terminal"
 	"github.com/kubenext/kubeon/pkg/store"
 	"github.com/kubenext/kubeon/pkg/view/component"
 )
@@ -33,7 +34,7 @@ var (
 )
 
 // PodListHandler is a printFunc that prints pods
-func PodListHandler(_ context.Context, list *corev1.PodList, opts Options) (component.Component, error) {
+func PodListHandler(ctx context.Context, list *corev1.PodList, opts Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("list is nil")
 	}
@@ -87,11 +88,17 @@ func PodListHandler(_ context.Context, list *corev1.PodList, opts Options) (comp
 		ts := list.Items[i].CreationTimestamp.Time
 		row["Age"] = component.NewTimestamp(ts)
 
+		if err := setRowKey(row, &list.Items[i]); err != nil {
+			return nil, err
+		}
+
 		table.Add(row)
 	}
 
 	table.Sort("Name", false)
 
+	addBulkActions(ctx, table, false, opts)
+
 	return table, nil
 }
 
@@ -122,16 +129,18 @@ func PodHandler(ctx context.Context, pod *corev1.Pod, options Options) (componen
 	if err := ph.Conditions(options); err != nil {
 		return nil, errors.Wrap(err, "print pod conditions")
 	}
-	if err := ph.InitContainers(options); err != nil {
+	if err := ph.InitContainers(ctx, options); err != nil {
 		return nil, errors.Wrap(err, "print pod init containers")
 	}
-	if err := ph.Containers(options); err != nil {
+	if err := ph.Containers(ctx, options); err != nil {
 		return nil, errors.Wrap(err, "print pod containers")
 	}
 	if err := ph.Additional(options); err != nil {
 		return nil, errors.Wrap(err, "print pod additional items")
 	}
 
+	registerTerminals(o, terminal.PodTarget(pod.Namespace, pod.Name), options)
+
 	return o.ToComponent(ctx, options)
 }
 
@@ -250,6 +259,15 @@ func (p *PodConfiguration) Create(options Options) (*component.Summary, error) {
 	})
 
 	summary := component.NewSummary("Configuration", sections...)
+
+	if pod.Status.Phase == corev1.PodRunning && canDebug(context.Background(), pod.Namespace, options) {
+		debugAction, err := debugPodAction(pod)
+		if err != nil {
+			return nil, errors.Wrap(err, "create pod debug action")
+		}
+		summary.AddAction(debugAction)
+	}
+
 	return summary, nil
 }
 
@@ -608,8 +626,8 @@ type podObject interface {
 	Config(options Options) error
 	Status(options Options) error
 	Conditions(options Options) error
-	InitContainers(options Options) error
-	Containers(options Options) error
+	InitContainers(ctx context.Context, options Options) error
+	Containers(ctx context.Context, options Options) error
 	Additional(options Options) error
 }
 
@@ -618,7 +636,7 @@ type podHandler struct {
 	configFunc      func(*corev1.Pod, Options) (*component.Summary, error)
 	summaryFunc     func(*corev1.Pod, Options) (*component.Summary, error)
 	conditionsFunc  func(*corev1.Pod, Options) (*component.Table, error)
-	containerFunc   func(pod *corev1.Pod, container *corev1.Container, isInit bool, options Options) (*component.Summary, error)
+	containerFunc   func(ctx context.Context, pod *corev1.Pod, container *corev1.Container, isInit bool, options Options) (*component.Summary, error)
 	additionalFuncs []func(*corev1.Pod, Options) ObjectPrinterFunc
 	object          *Object
 }
@@ -717,11 +735,11 @@ func defaultPodConditions(pod *corev1.Pod, options Options) (*component.Table, e
 	return createPodConditionsView(pod)
 }
 
-func (p *podHandler) InitContainers(options Options) error {
-	return p.containers(p.pod.Spec.InitContainers, true, options)
+func (p *podHandler) InitContainers(ctx context.Context, options Options) error {
+	return p.containers(ctx, p.pod.Spec.InitContainers, true, options)
 }
 
-func (p *podHandler) containers(containers []corev1.Container, isInit bool, options Options) error {
+func (p *podHandler) containers(ctx context.Context, containers []corev1.Container, isInit bool, options Options) error {
 	var itemDescriptors []ItemDescriptor
 
 	for i := range containers {
@@ -730,7 +748,7 @@ func (p *podHandler) containers(containers []corev1.Container, isInit bool, opti
 		itemDescriptors = append(itemDescriptors, ItemDescriptor{
 			Width: component.WidthHalf,
 			Func: func() (component.Component, error) {
-				return p.containerFunc(p.pod, &container, isInit, options)
+				return p.containerFunc(ctx, p.pod, &container, isInit, options)
 			},
 		})
 	}
@@ -740,13 +758,13 @@ func (p *podHandler) containers(containers []corev1.Container, isInit bool, opti
 	return nil
 }
 
-func (p *podHandler) Containers(options Options) error {
-	return p.containers(p.pod.Spec.Containers, false, options)
+func (p *podHandler) Containers(ctx context.Context, options Options) error {
+	return p.containers(ctx, p.pod.Spec.Containers, false, options)
 }
 
-func defaultPodContainers(pod *corev1.Pod, container *corev1.Container, isInit bool, options Options) (*component.Summary, error) {
+func defaultPodContainers(ctx context.Context, pod *corev1.Pod, container *corev1.Container, isInit bool, options Options) (*component.Summary, error) {
 	portForwarder := options.DashConfig.PortForwarder()
-	creator := NewContainerConfiguration(pod, container, portForwarder, isInit, options)
+	creator := NewContainerConfiguration(ctx, pod, container, portForwarder, isInit, options)
 	return creator.Create()
 }
 
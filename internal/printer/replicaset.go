@@ -52,8 +52,15 @@ func ReplicaSetListHandler(ctx context.Context, list *appsv1.ReplicaSetList, opt
 		row["Containers"] = containers
 		row["Selector"] = printSelector(rs.Spec.Selector)
 
+		if err := setRowKey(row, &rs); err != nil {
+			return nil, err
+		}
+
 		tbl.Add(row)
 	}
+
+	addBulkActions(ctx, tbl, false, opts)
+
 	return tbl, nil
 }
 
@@ -67,7 +74,7 @@ func ReplicaSetHandler(ctx context.Context, replicaSet *appsv1.ReplicaSet, optio
 		return nil, err
 	}
 
-	if err := rsh.Config(options); err != nil {
+	if err := rsh.Config(ctx, options); err != nil {
 		return nil, errors.Wrap(err, "print replicaset configuration")
 	}
 
@@ -95,7 +102,7 @@ func NewReplicaSetConfiguration(rs *appsv1.ReplicaSet) *ReplicaSetConfiguration
 }
 
 // Create generates a replicaset configuration summary
-func (rc *ReplicaSetConfiguration) Create(options Options) (*component.Summary, error) {
+func (rc *ReplicaSetConfiguration) Create(ctx context.Context, options Options) (*component.Summary, error) {
 	if rc == nil || rc.replicaset == nil {
 		return nil, errors.New("replicaset is nil")
 	}
@@ -128,6 +135,12 @@ func (rc *ReplicaSetConfiguration) Create(options Options) (*component.Summary,
 
 	summary := component.NewSummary("Configuration", sections...)
 
+	if desired := rs.Spec.Replicas; desired != nil {
+		if err := addScaleAction(ctx, summary, rs, *desired, options); err != nil {
+			return nil, err
+		}
+	}
+
 	return summary, nil
 }
 
@@ -185,14 +198,14 @@ func (replicaSetStatus *ReplicaSetStatus) Create() (*component.Quadrant, error)
 }
 
 type replicaSetObject interface {
-	Config(options Options) error
+	Config(ctx context.Context, options Options) error
 	Status(ctx context.Context, options Options) error
 	Pods(ctx context.Context, object runtime.Object, options Options) error
 }
 
 type replicaSetHandler struct {
 	replicaSet *appsv1.ReplicaSet
-	configFunc func(*appsv1.ReplicaSet, Options) (*component.Summary, error)
+	configFunc func(context.Context, *appsv1.ReplicaSet, Options) (*component.Summary, error)
 	statusFunc func(context.Context, *appsv1.ReplicaSet, Options) (*component.Quadrant, error)
 	podFunc    func(context.Context, runtime.Object, Options) (component.Component, error)
 	object     *Object
@@ -220,8 +233,8 @@ func newReplicaSetHandler(replicaSet *appsv1.ReplicaSet, object *Object) (*repli
 	return rh, nil
 }
 
-func (r *replicaSetHandler) Config(options Options) error {
-	out, err := r.configFunc(r.replicaSet, options)
+func (r *replicaSetHandler) Config(ctx context.Context, options Options) error {
+	out, err := r.configFunc(ctx, r.replicaSet, options)
 	if err != nil {
 		return err
 	}
@@ -229,8 +242,8 @@ func (r *replicaSetHandler) Config(options Options) error {
 	return nil
 }
 
-func defaultReplicaSetConfig(replicaSet *appsv1.ReplicaSet, options Options) (*component.Summary, error) {
-	return NewReplicaSetConfiguration(replicaSet).Create(options)
+func defaultReplicaSetConfig(ctx context.Context, replicaSet *appsv1.ReplicaSet, options Options) (*component.Summary, error) {
+	return NewReplicaSetConfiguration(replicaSet).Create(ctx, options)
 }
 
 func (r *replicaSetHandler) Status(ctx context.Context, options Options) error {
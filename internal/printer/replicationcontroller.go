@@ -54,8 +54,15 @@ func ReplicationControllerListHandler(ctx context.Context, list *corev1.Replicat
 
 		row["Selector"] = printSelectorMap(rc.Spec.Selector)
 
+		if err := setRowKey(row, &rc); err != nil {
+			return nil, err
+		}
+
 		tbl.Add(row)
 	}
+
+	addBulkActions(ctx, tbl, false, options)
+
 	return tbl, nil
 }
 
@@ -69,7 +76,7 @@ func ReplicationControllerHandler(ctx context.Context, rc *corev1.ReplicationCon
 		return nil, err
 	}
 
-	if err := rch.Config(options); err != nil {
+	if err := rch.Config(ctx, options); err != nil {
 		return nil, errors.Wrap(err, "print replicationcontroller configuration")
 	}
 
@@ -97,7 +104,7 @@ func NewReplicationControllerConfiguration(rc *corev1.ReplicationController) *Re
 }
 
 // Create generates a replicationcontroller configuration summary
-func (rcc *ReplicationControllerConfiguration) Create(options Options) (*component.Summary, error) {
+func (rcc *ReplicationControllerConfiguration) Create(ctx context.Context, options Options) (*component.Summary, error) {
 	if rcc == nil || rcc.replicationController == nil {
 		return nil, errors.New("replicationcontroller is nil")
 	}
@@ -130,6 +137,13 @@ func (rcc *ReplicationControllerConfiguration) Create(options Options) (*compone
 	sections.AddText("Replicas", replicas)
 
 	summary := component.NewSummary("Configuration", sections...)
+
+	if desired := replicationController.Spec.Replicas; desired != nil {
+		if err := addScaleAction(ctx, summary, replicationController, *desired, options); err != nil {
+			return nil, err
+		}
+	}
+
 	return summary, nil
 }
 
@@ -188,14 +202,14 @@ func (rcs *ReplicationControllerStatus) Create() (*component.Quadrant, error) {
 }
 
 type replicationControllerObject interface {
-	Config(options Options) error
+	Config(ctx context.Context, options Options) error
 	Status(ctx context.Context, options Options) error
 	Pods(ctx context.Context, object runtime.Object, options Options) error
 }
 
 type replicationControllerHandler struct {
 	replicationController *corev1.ReplicationController
-	configFunc            func(*corev1.ReplicationController, Options) (*component.Summary, error)
+	configFunc            func(context.Context, *corev1.ReplicationController, Options) (*component.Summary, error)
 	statusFunc            func(context.Context, *corev1.ReplicationController, Options) (*component.Quadrant, error)
 	podFunc               func(context.Context, runtime.Object, Options) (component.Component, error)
 	object                *Object
@@ -223,8 +237,8 @@ func newReplicationControllerHandler(replicationController *corev1.ReplicationCo
 	return rch, nil
 }
 
-func (r *replicationControllerHandler) Config(options Options) error {
-	out, err := r.configFunc(r.replicationController, options)
+func (r *replicationControllerHandler) Config(ctx context.Context, options Options) error {
+	out, err := r.configFunc(ctx, r.replicationController, options)
 	if err != nil {
 		return err
 	}
@@ -232,8 +246,8 @@ func (r *replicationControllerHandler) Config(options Options) error {
 	return nil
 }
 
-func defaultReplicationControllerConfig(replicationController *corev1.ReplicationController, options Options) (*component.Summary, error) {
-	return NewReplicationControllerConfiguration(replicationController).Create(options)
+func defaultReplicationControllerConfig(ctx context.Context, replicationController *corev1.ReplicationController, options Options) (*component.Summary, error) {
+	return NewReplicationControllerConfiguration(replicationController).Create(ctx, options)
 }
 
 func (r *replicationControllerHandler) Status(ctx context.Context, options Options) error {
@@ -16,7 +16,7 @@ import (
 )
 
 // RoleListHandler is a printFunc that prints roles
-func RoleListHandler(_ context.Context, roleList *rbacv1.RoleList, options Options) (component.Component, error) {
+func RoleListHandler(ctx context.Context, roleList *rbacv1.RoleList, options Options) (component.Component, error) {
 	if roleList == nil {
 		return nil, errors.New("role list is nil")
 	}
@@ -33,9 +33,15 @@ func RoleListHandler(_ context.Context, roleList *rbacv1.RoleList, options Optio
 
 		row["Name"] = nameLink
 		row["Age"] = component.NewTimestamp(role.CreationTimestamp.Time)
+		if err := setRowKey(row, &role); err != nil {
+			return nil, err
+		}
+
 		table.Add(row)
 	}
 
+	addBulkActions(ctx, table, false, options)
+
 	return table, nil
 }
 
@@ -40,9 +40,15 @@ func RoleBindingListHandler(ctx context.Context, roleBindingList *rbacv1.RoleBin
 		}
 		row["Role name"] = roleName
 
+		if err := setRowKey(row, &roleBinding); err != nil {
+			return nil, err
+		}
+
 		table.Add(row)
 	}
 
+	addBulkActions(ctx, table, false, opts)
+
 	return table, nil
 }
 
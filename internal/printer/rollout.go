@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package printer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubenext/kubeon/internal/queryer"
+	"github.com/kubenext/kubeon/internal/rollout"
+	"github.com/kubenext/kubeon/pkg/store"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+// addRolloutActions adds restart, pause or resume, and undo actions to a
+// configuration summary if the current user can patch the object.
+func addRolloutActions(ctx context.Context, summary *component.Summary, object runtime.Object, options Options) error {
+	if options.DashConfig == nil {
+		return nil
+	}
+
+	key, err := store.KeyFromObject(object)
+	if err != nil {
+		return err
+	}
+
+	if !hasAccess(ctx, key, "", "patch", options) {
+		return nil
+	}
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return errors.Wrap(err, "convert object to unstructured")
+	}
+	u := &unstructured.Unstructured{Object: m}
+	u.SetAPIVersion(key.ApiVersion)
+	u.SetKind(key.Kind)
+
+	var actions []component.Action
+
+	restart, err := rolloutAction(object, "Restart", "Restart rollout", rollout.OperationRestart)
+	if err != nil {
+		return err
+	}
+	actions = append(actions, restart)
+
+	if rollout.CanPause(u.GetKind()) {
+		pause, err := rolloutAction(object, "Pause", "Pause rollout", rollout.OperationPause)
+		if rollout.IsPaused(u) {
+			pause, err = rolloutAction(object, "Resume", "Resume rollout", rollout.OperationResume)
+		}
+		if err != nil {
+			return err
+		}
+		actions = append(actions, pause)
+	}
+
+	undo, ok, err := undoAction(ctx, object, u, options)
+	if err != nil {
+		return err
+	}
+	if ok {
+		actions = append(actions, undo)
+	}
+
+	for _, action := range actions {
+		summary.AddAction(action)
+	}
+
+	return nil
+}
+
+func rolloutAction(object runtime.Object, name, title, operation string, fields ...component.FormField) (component.Action, error) {
+	fields = append([]component.FormField{component.NewFormFieldHidden("operation", operation)}, fields...)
+
+	form, err := component.CreateFormForObject("overview/rollout", object, fields...)
+	if err != nil {
+		return component.Action{}, errors.Wrapf(err, "create %s action", operation)
+	}
+
+	return component.Action{
+		Name:  name,
+		Title: title,
+		Form:  form,
+	}, nil
+}
+
+// undoAction creates an action which rolls back to one of the previous
+// revisions. There is no action if there are no previous revisions.
+func undoAction(ctx context.Context, object runtime.Object, u *unstructured.Unstructured, options Options) (component.Action, bool, error) {
+	discoveryClient, err := options.DashConfig.ClusterClient().DiscoveryClient()
+	if err != nil {
+		return component.Action{}, false, errors.Wrap(err, "create discovery client")
+	}
+
+	q := queryer.New(options.DashConfig.ObjectStore(), discoveryClient)
+
+	revisions, err := rollout.Revisions(ctx, q, u)
+	if err != nil {
+		return component.Action{}, false, errors.Wrap(err, "find revisions")
+	}
+
+	var choices []component.InputChoice
+	for _, revision := range revisions {
+		if revision.Current {
+			continue
+		}
+		choices = append(choices, component.InputChoice{
+			Label:   revision.Description(),
+			Value:   fmt.Sprintf("%d", revision.Number),
+			Checked: len(choices) == 0,
+		})
+	}
+
+	if len(choices) == 0 {
+		return component.Action{}, false, nil
+	}
+
+	action, err := rolloutAction(object, "Undo", "Undo rollout", rollout.OperationUndo,
+		component.NewFormFieldRadio("Revision", "revision", choices))
+	if err != nil {
+		return component.Action{}, false, err
+	}
+
+	return action, true, nil
+}
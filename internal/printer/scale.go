@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package printer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/internal/scale"
+	"github.com/kubenext/kubeon/pkg/store"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+// scaleAction creates an action which sets the replicas of an object.
+func scaleAction(object runtime.Object, replicas int32) (component.Action, error) {
+	form, err := component.CreateFormForObject("overview/scale", object,
+		component.NewFormFieldNumber("Replicas", "replicas", fmt.Sprintf("%d", replicas)),
+	)
+	if err != nil {
+		return component.Action{}, err
+	}
+
+	return component.Action{
+		Name:  "Scale",
+		Title: "Scale",
+		Form:  form,
+	}, nil
+}
+
+// addScaleAction adds a scale action to a configuration summary if the current
+// user can scale the object. The summary warns when a horizontal pod autoscaler
+// will override the replicas, if autoscalers can be listed.
+func addScaleAction(ctx context.Context, summary *component.Summary, object runtime.Object, replicas int32, options Options) error {
+	if options.DashConfig == nil {
+		return nil
+	}
+
+	key, err := store.KeyFromObject(object)
+	if err != nil {
+		return err
+	}
+
+	// the action is still shown if autoscalers can't be listed; it just
+	// can't warn about them
+	autoscalers, err := scale.Autoscalers(ctx, options.DashConfig.ObjectStore(), key)
+	if err != nil {
+		log.From(ctx).With("err", err).Warnf("unable to find autoscalers for %s %q", key.Kind, key.Name)
+	} else if warning := scale.AutoscalerWarning(autoscalers); warning != "" {
+		summary.SetAlert(component.NewAlert(component.AlertTypeWarning, warning))
+	}
+
+	if !hasAccess(ctx, key, scale.Subresource, "update", options) {
+		return nil
+	}
+
+	action, err := scaleAction(object, replicas)
+	if err != nil {
+		return errors.Wrap(err, "create scale action")
+	}
+
+	summary.AddAction(action)
+	return nil
+}
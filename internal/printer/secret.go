@@ -17,7 +17,7 @@ import (
 
 var (
 	secretTableCols = component.NewTableCols("Name", "Labels", "Type", "Data", "Age")
-	secretDataCols  = component.NewTableCols("Key")
+	secretDataCols  = component.NewTableCols("Key", "Size", "Actions")
 )
 
 // SecretListHandler is a printFunc that lists secrets.
@@ -42,9 +42,15 @@ func SecretListHandler(ctx context.Context, list *corev1.SecretList, options Opt
 		row["Data"] = component.NewText(fmt.Sprintf("%d", len(secret.Data)))
 		row["Age"] = component.NewTimestamp(secret.ObjectMeta.CreationTimestamp.Time)
 
+		if err := setRowKey(row, &secret); err != nil {
+			return nil, err
+		}
+
 		table.Add(row)
 	}
 
+	addBulkActions(ctx, table, false, options)
+
 	return table, nil
 }
 
@@ -57,7 +63,7 @@ func SecretHandler(ctx context.Context, secret *corev1.Secret, options Options)
 		return nil, err
 	}
 
-	if err := sh.Config(options); err != nil {
+	if err := sh.Config(ctx, options); err != nil {
 		return nil, errors.Wrap(err, "print secret configuration")
 	}
 
@@ -81,7 +87,7 @@ func NewSecretConfiguration(secret *corev1.Secret) *SecretConfiguration {
 }
 
 // Create creates a secret configuration summary
-func (s *SecretConfiguration) Create(options Options) (*component.Summary, error) {
+func (s *SecretConfiguration) Create(ctx context.Context, options Options) (*component.Summary, error) {
 	if s.secret == nil {
 		return nil, errors.New("secret is nil")
 	}
@@ -95,15 +101,31 @@ func (s *SecretConfiguration) Create(options Options) (*component.Summary, error
 	})
 
 	summary := component.NewSummary("Configuration", sections...)
+
+	if err := addSecretEditActions(ctx, summary, secret, options); err != nil {
+		return nil, errors.Wrap(err, "add secret edit actions")
+	}
+
 	return summary, nil
 }
 
+// describeSecretData creates a table of the keys of a secret with their sizes.
+// Values aren't included; each one is revealed separately after confirmation.
 func describeSecretData(secret corev1.Secret) (*component.Table, error) {
 	table := component.NewTable("Data", "This secret has no data!", secretDataCols)
 
-	for key := range secret.Data {
+	for _, key := range sortedSecretKeys(secret.Data) {
 		row := component.TableRow{}
 		row["Key"] = component.NewText(key)
+		row["Size"] = component.NewText(fmt.Sprintf("%d bytes", len(secret.Data[key])))
+
+		actions := component.NewButtonGroup()
+		reveal, err := revealSecretValueButton(&secret, key)
+		if err != nil {
+			return nil, err
+		}
+		actions.AddButton(reveal)
+		row["Actions"] = actions
 
 		table.Add(row)
 	}
@@ -112,13 +134,13 @@ func describeSecretData(secret corev1.Secret) (*component.Table, error) {
 }
 
 type secretObject interface {
-	Config(options Options) error
+	Config(ctx context.Context, options Options) error
 	Data(options Options) error
 }
 
 type secretHandler struct {
 	secret     *corev1.Secret
-	configFunc func(*corev1.Secret, Options) (*component.Summary, error)
+	configFunc func(context.Context, *corev1.Secret, Options) (*component.Summary, error)
 	dataFunc   func(*corev1.Secret, Options) (*component.Table, error)
 	object     *Object
 }
@@ -142,8 +164,8 @@ func newSecretHandler(secret *corev1.Secret, object *Object) (*secretHandler, er
 	return sh, nil
 }
 
-func (s *secretHandler) Config(options Options) error {
-	out, err := s.configFunc(s.secret, options)
+func (s *secretHandler) Config(ctx context.Context, options Options) error {
+	out, err := s.configFunc(ctx, s.secret, options)
 	if err != nil {
 		return err
 	}
@@ -151,8 +173,8 @@ func (s *secretHandler) Config(options Options) error {
 	return nil
 }
 
-func defaultSecretConfig(secret *corev1.Secret, options Options) (*component.Summary, error) {
-	return NewSecretConfiguration(secret).Create(options)
+func defaultSecretConfig(ctx context.Context, secret *corev1.Secret, options Options) (*component.Summary, error) {
+	return NewSecretConfiguration(secret).Create(ctx, options)
 }
 
 func (s *secretHandler) Data(options Options) error {
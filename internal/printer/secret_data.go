@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package printer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kubenext/kubeon/internal/secretdata"
+	"github.com/kubenext/kubeon/pkg/action"
+	"github.com/kubenext/kubeon/pkg/store"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+// revealSecretValueActionName is the name of the action for revealing the
+// value of a secret key. It is handled by the secret manager rather than a
+// module, and the decoded value is sent back as a secretValue event.
+const revealSecretValueActionName = "revealSecretValue"
+
+// revealSecretValueButton creates a button which reveals the value of a
+// secret key after it is confirmed.
+func revealSecretValueButton(secret *corev1.Secret, key string) (component.Button, error) {
+	objectKey, err := store.KeyFromObject(secret)
+	if err != nil {
+		return component.Button{}, err
+	}
+
+	payload := action.CreatePayload(revealSecretValueActionName, objectKey.ToActionPayload())
+	payload["key"] = key
+
+	confirmation := component.WithButtonConfirmation(
+		fmt.Sprintf("Reveal %s", key),
+		fmt.Sprintf("Are you sure you want to reveal the value of *%s* in secret **%s**? Revealing it is recorded.", key, secret.Name),
+	)
+
+	return component.NewButton("Reveal", payload, confirmation), nil
+}
+
+// addSecretEditActions adds actions which set and delete secret keys to a
+// secret configuration summary if the current user is allowed to patch it.
+func addSecretEditActions(ctx context.Context, summary *component.Summary, secret *corev1.Secret, options Options) error {
+	key, err := store.KeyFromObject(secret)
+	if err != nil {
+		return err
+	}
+
+	if !hasAccess(ctx, key, "", "patch", options) {
+		return nil
+	}
+
+	set, err := component.CreateFormForObject("overview/editSecret", secret,
+		component.NewFormFieldHidden("operation", secretdata.OperationSet),
+		component.NewFormFieldHidden("resourceVersion", secret.ResourceVersion),
+		component.NewFormFieldText("Key", "key", ""),
+		component.NewFormFieldTextarea("Value", "value", ""),
+		component.NewFormFieldRadio("Value is", "encoding", []component.InputChoice{
+			{Label: "Text", Value: secretdata.EncodingText, Checked: true},
+			{Label: "Base64 (for binary values)", Value: secretdata.EncodingBase64},
+		}),
+	)
+	if err != nil {
+		return errors.Wrap(err, "create set secret key form")
+	}
+
+	summary.AddAction(component.Action{
+		Name:  "Set key",
+		Title: "Add or replace a secret key",
+		Form:  set,
+	})
+
+	if len(secret.Data) == 0 {
+		return nil
+	}
+
+	var choices []component.InputChoice
+	for _, name := range sortedSecretKeys(secret.Data) {
+		choices = append(choices, component.InputChoice{Label: name, Value: name})
+	}
+
+	remove, err := component.CreateFormForObject("overview/editSecret", secret,
+		component.NewFormFieldHidden("operation", secretdata.OperationDelete),
+		component.NewFormFieldHidden("resourceVersion", secret.ResourceVersion),
+		component.NewFormFieldCheckBox("Keys", "keys", choices),
+	)
+	if err != nil {
+		return errors.Wrap(err, "create delete secret keys form")
+	}
+
+	summary.AddAction(component.Action{
+		Name:  "Delete keys",
+		Title: "Delete secret keys",
+		Form:  remove,
+	})
+
+	return nil
+}
+
+func sortedSecretKeys(data map[string][]byte) []string {
+	var keys []string
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
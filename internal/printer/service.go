@@ -15,12 +15,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 
+	"github.com/kubenext/kubeon/internal/proxy"
 	"github.com/kubenext/kubeon/pkg/store"
 	"github.com/kubenext/kubeon/pkg/view/component"
 )
 
 // ServiceListHandler is a printFunc that lists services
-func ServiceListHandler(_ context.Context, list *corev1.ServiceList, options Options) (component.Component, error) {
+func ServiceListHandler(ctx context.Context, list *corev1.ServiceList, options Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("nil list")
 	}
@@ -40,15 +41,22 @@ func ServiceListHandler(_ context.Context, list *corev1.ServiceList, options Opt
 		row["Type"] = component.NewText(string(s.Spec.Type))
 		row["Cluster IP"] = component.NewText(s.Spec.ClusterIP)
 		row["External IP"] = component.NewText(describeExternalIPs(s))
-		row["Target Ports"] = printServicePorts(s.Spec.Ports)
+		row["Target Ports"] = printServicePorts(&s)
 
 		ts := s.CreationTimestamp.Time
 		row["Age"] = component.NewTimestamp(ts)
 
 		row["Selector"] = printSelectorMap(s.Spec.Selector)
 
+		if err := setRowKey(row, &s); err != nil {
+			return nil, err
+		}
+
 		tbl.Add(row)
 	}
+
+	addBulkActions(ctx, tbl, false, options)
+
 	return tbl, nil
 }
 
@@ -77,13 +85,22 @@ func ServiceHandler(ctx context.Context, service *corev1.Service, options Option
 	return o.ToComponent(ctx, options)
 }
 
-func printServicePorts(ports []corev1.ServicePort) component.Component {
+// printServicePorts prints a service's target ports. TCP ports link to a
+// preview of the port through the dashboard proxy.
+func printServicePorts(service *corev1.Service) component.Component {
+	ports := service.Spec.Ports
+	canPreview := service.Spec.Type != corev1.ServiceTypeExternalName
+
 	out := make([]string, len(ports))
 	for i, port := range ports {
 		out[i] = describeTargetPort(port)
+		if canPreview && port.Protocol == corev1.ProtocolTCP {
+			target := proxy.ServiceTarget(service.Namespace, service.Name, int(port.Port))
+			out[i] = fmt.Sprintf("[%s](%s)", out[i], proxy.Path(target))
+		}
 	}
 
-	return component.NewText(strings.Join(out, ", "))
+	return component.NewMarkdownText(strings.Join(out, ", "))
 }
 
 // ServiceConfiguration generates a service configuration
@@ -20,7 +20,7 @@ import (
 )
 
 // ServiceAccountListHandler is a printFunc that prints service accounts
-func ServiceAccountListHandler(_ context.Context, list *corev1.ServiceAccountList, options Options) (component.Component, error) {
+func ServiceAccountListHandler(ctx context.Context, list *corev1.ServiceAccountList, options Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("service account list is nil")
 	}
@@ -41,9 +41,15 @@ func ServiceAccountListHandler(_ context.Context, list *corev1.ServiceAccountLis
 		row["Secrets"] = component.NewText(fmt.Sprint(len(serviceAccount.Secrets)))
 		row["Age"] = component.NewTimestamp(serviceAccount.CreationTimestamp.Time)
 
+		if err := setRowKey(row, &serviceAccount); err != nil {
+			return nil, err
+		}
+
 		table.Add(row)
 	}
 
+	addBulkActions(ctx, table, false, options)
+
 	return table, nil
 }
 
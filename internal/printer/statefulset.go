@@ -20,7 +20,7 @@ import (
 )
 
 // StatefulSetListHandler is a printFunc that list stateful sets
-func StatefulSetListHandler(_ context.Context, list *appsv1.StatefulSetList, options Options) (component.Component, error) {
+func StatefulSetListHandler(ctx context.Context, list *appsv1.StatefulSetList, options Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("nil list")
 	}
@@ -49,9 +49,15 @@ func StatefulSetListHandler(_ context.Context, list *appsv1.StatefulSetList, opt
 
 		row["Selector"] = printSelector(statefulSet.Spec.Selector)
 
+		if err := setRowKey(row, &statefulSet); err != nil {
+			return nil, err
+		}
+
 		tbl.Add(row)
 	}
 
+	addBulkActions(ctx, tbl, true, options)
+
 	return tbl, nil
 }
 
@@ -65,7 +71,7 @@ func StatefulSetHandler(ctx context.Context, statefulSet *appsv1.StatefulSet, op
 		return nil, err
 	}
 
-	if err := sh.Config(options); err != nil {
+	if err := sh.Config(ctx, options); err != nil {
 		return nil, errors.Wrap(err, "print statefulset configuration")
 	}
 
@@ -77,6 +83,8 @@ func StatefulSetHandler(ctx context.Context, statefulSet *appsv1.StatefulSet, op
 		return nil, errors.Wrap(err, "print statefulset pods")
 	}
 
+	registerWorkloadLogs(o, statefulSet.Namespace, statefulSet.Spec.Selector, statefulSet.Spec.Template)
+
 	return o.ToComponent(ctx, options)
 }
 
@@ -93,7 +101,7 @@ func NewStatefulSetConfiguration(statefulSet *appsv1.StatefulSet) *StatefulSetCo
 }
 
 // Create generates a statefulset configuration summary
-func (sc *StatefulSetConfiguration) Create(options Options) (*component.Summary, error) {
+func (sc *StatefulSetConfiguration) Create(ctx context.Context, options Options) (*component.Summary, error) {
 	if sc == nil || sc.statefulset == nil {
 		return nil, errors.New("statefulset is nil")
 	}
@@ -139,6 +147,17 @@ func (sc *StatefulSetConfiguration) Create(options Options) (*component.Summary,
 	sections.AddText("Pod Management Policy", string(statefulSet.Spec.PodManagementPolicy))
 
 	summary := component.NewSummary("Configuration", sections...)
+
+	if desired := statefulSet.Spec.Replicas; desired != nil {
+		if err := addScaleAction(ctx, summary, statefulSet, *desired, options); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := addRolloutActions(ctx, summary, statefulSet, options); err != nil {
+		return nil, err
+	}
+
 	return summary, nil
 }
 
@@ -197,14 +216,14 @@ func (statefulSetStatus *StatefulSetStatus) Create() (*component.Quadrant, error
 }
 
 type statefulSetObject interface {
-	Config(options Options) error
+	Config(ctx context.Context, options Options) error
 	Status(ctx context.Context, options Options) error
 	Pods(ctx context.Context, object runtime.Object, options Options) error
 }
 
 type statefulSetHandler struct {
 	statefulSet *appsv1.StatefulSet
-	configFunc  func(*appsv1.StatefulSet, Options) (*component.Summary, error)
+	configFunc  func(context.Context, *appsv1.StatefulSet, Options) (*component.Summary, error)
 	statusFunc  func(context.Context, *appsv1.StatefulSet, Options) (*component.Quadrant, error)
 	podFunc     func(context.Context, runtime.Object, Options) (component.Component, error)
 	object      *Object
@@ -232,8 +251,8 @@ func newStatufulSetHandler(statefulSet *appsv1.StatefulSet, object *Object) (*st
 	return sh, nil
 }
 
-func (s *statefulSetHandler) Config(options Options) error {
-	out, err := s.configFunc(s.statefulSet, options)
+func (s *statefulSetHandler) Config(ctx context.Context, options Options) error {
+	out, err := s.configFunc(ctx, s.statefulSet, options)
 	if err != nil {
 		return err
 	}
@@ -241,8 +260,8 @@ func (s *statefulSetHandler) Config(options Options) error {
 	return nil
 }
 
-func defaultStatefulSetConfig(statefulSet *appsv1.StatefulSet, options Options) (*component.Summary, error) {
-	return NewStatefulSetConfiguration(statefulSet).Create(options)
+func defaultStatefulSetConfig(ctx context.Context, statefulSet *appsv1.StatefulSet, options Options) (*component.Summary, error) {
+	return NewStatefulSetConfiguration(statefulSet).Create(ctx, options)
 }
 
 func (s *statefulSetHandler) Status(ctx context.Context, options Options) error {
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package printer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kubenext/kubeon/internal/debugpod"
+	"github.com/kubenext/kubeon/internal/terminal"
+	"github.com/kubenext/kubeon/pkg/store"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+// canExec returns true if the current user can create pods/exec for pod.
+func canExec(ctx context.Context, pod *corev1.Pod, options Options) bool {
+	if pod == nil {
+		return false
+	}
+
+	key := store.Key{
+		Namespace:  pod.Namespace,
+		ApiVersion: "v1",
+		Kind:       "Pod",
+		Name:       pod.Name,
+	}
+
+	return hasAccess(ctx, key, "exec", "create", options)
+}
+
+func execContainerAction(pod *corev1.Pod, container *corev1.Container) (component.Action, error) {
+	form, err := component.CreateFormForObject("overview/startTerminal", pod,
+		component.NewFormFieldText("Command (blank for the default shell)", "command", ""),
+		component.NewFormFieldHidden("containerName", container.Name),
+	)
+	if err != nil {
+		return component.Action{}, err
+	}
+
+	return component.Action{
+		Name:  "Exec",
+		Title: fmt.Sprintf("Exec in Container %s", container.Name),
+		Form:  form,
+	}, nil
+}
+
+// canDebug returns true if the current user can create and exec into debug pods in namespace.
+func canDebug(ctx context.Context, namespace string, options Options) bool {
+	key := store.Key{
+		Namespace:  namespace,
+		ApiVersion: "v1",
+		Kind:       "Pod",
+	}
+
+	return hasAccess(ctx, key, "", "create", options) &&
+		hasAccess(ctx, key, "exec", "create", options)
+}
+
+func debugPodAction(pod *corev1.Pod) (component.Action, error) {
+	form, err := component.CreateFormForObject("overview/startDebugPod", pod,
+		component.NewFormFieldText("Image", "image", debugpod.DefaultImage),
+	)
+	if err != nil {
+		return component.Action{}, err
+	}
+
+	return component.Action{
+		Name:  "Debug",
+		Title: fmt.Sprintf("Debug Pod %s", pod.Name),
+		Form:  form,
+	}, nil
+}
+
+func debugNodeAction(node *corev1.Node, namespace string) (component.Action, error) {
+	form, err := component.CreateFormForObject("overview/startDebugPod", node,
+		component.NewFormFieldText("Image", "image", debugpod.DefaultImage),
+		component.NewFormFieldText("Namespace", "debugNamespace", namespace),
+	)
+	if err != nil {
+		return component.Action{}, err
+	}
+
+	return component.Action{
+		Name:  "Debug",
+		Title: fmt.Sprintf("Debug Node %s", node.Name),
+		Form:  form,
+	}, nil
+}
+
+// registerTerminals registers a terminal view for each terminal running for target.
+func registerTerminals(o *Object, target terminal.Target, options Options) {
+	if o == nil || options.DashConfig == nil {
+		return
+	}
+
+	for _, inst := range options.DashConfig.TerminalManager().List(target) {
+		view := component.NewTerminal(inst.Namespace(), inst.PodName(), inst.Container(), strings.Join(inst.Command(), " "))
+		view.Config.ID = inst.ID()
+
+		o.RegisterItems(ItemDescriptor{
+			Width: component.WidthFull,
+			Func: func() (component.Component, error) {
+				return view, nil
+			},
+		})
+	}
+}
@@ -0,0 +1,242 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// PathPrefix is the dashboard path proxied requests are served under.
+	PathPrefix = "/api/v1/proxy"
+
+	// ResourcePods is the resource for proxying to pods.
+	ResourcePods = "pods"
+	// ResourceServices is the resource for proxying to services.
+	ResourceServices = "services"
+)
+
+// Target is a pod or service port requests are proxied to.
+type Target struct {
+	// Resource is ResourcePods or ResourceServices.
+	Resource  string
+	Namespace string
+	Name      string
+	// Port is a port number or name. It can be prefixed with a scheme,
+	// e.g. https:443.
+	Port string
+}
+
+// PodTarget creates a target for a pod port.
+func PodTarget(namespace, name string, port int) Target {
+	return Target{
+		Resource:  ResourcePods,
+		Namespace: namespace,
+		Name:      name,
+		Port:      portWithScheme(port),
+	}
+}
+
+// ServiceTarget creates a target for a service port.
+func ServiceTarget(namespace, name string, port int) Target {
+	return Target{
+		Resource:  ResourceServices,
+		Namespace: namespace,
+		Name:      name,
+		Port:      portWithScheme(port),
+	}
+}
+
+// Path returns the dashboard path which proxies to a target.
+func Path(t Target) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s/", PathPrefix, t.Resource, t.Namespace, t.Name, t.Port)
+}
+
+// Validate returns an error if the target is incomplete.
+func (t Target) Validate() error {
+	switch {
+	case t.Resource != ResourcePods && t.Resource != ResourceServices:
+		return errors.Errorf("can't proxy to %q", t.Resource)
+	case t.Namespace == "":
+		return errors.New("namespace is required")
+	case t.Name == "":
+		return errors.New("name is required")
+	case t.Port == "":
+		return errors.New("port is required")
+	}
+
+	return nil
+}
+
+// Kind returns the kind of the target object.
+func (t Target) Kind() string {
+	if t.Resource == ResourceServices {
+		return "Service"
+	}
+	return "Pod"
+}
+
+// proxyName returns the name used with the proxy subresource, e.g. https:nginx:443.
+func (t Target) proxyName() string {
+	port := t.Port
+	scheme := ""
+	if i := strings.Index(port, ":"); i >= 0 {
+		scheme, port = port[:i], port[i+1:]
+	}
+
+	name := t.Name + ":" + port
+	if scheme != "" {
+		name = scheme + ":" + name
+	}
+	return name
+}
+
+func portWithScheme(port int) string {
+	if port == 443 || port == 8443 {
+		return "https:" + strconv.Itoa(port)
+	}
+	return strconv.Itoa(port)
+}
+
+// sandboxPolicy is the Content-Security-Policy of proxied responses. Proxied
+// content is served from the dashboard's host, so it is sandboxed in an
+// opaque origin which can't read the dashboard's storage or call its API.
+const sandboxPolicy = "sandbox allow-forms allow-modals allow-popups allow-scripts"
+
+// New creates a reverse proxy to a target through the API server's proxy
+// subresource. Requests are expected under the target's Path. Redirects and
+// root relative links in HTML are rewritten so they stay under the Path.
+// Responses are sandboxed with sandboxPolicy.
+func New(kubeClient kubernetes.Interface, restConfig *rest.Config, target Target) (*httputil.ReverseProxy, error) {
+	if err := target.Validate(); err != nil {
+		return nil, err
+	}
+
+	transport, err := rest.TransportFor(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "create transport")
+	}
+
+	upstream := kubeClient.CoreV1().RESTClient().Get().
+		Namespace(target.Namespace).
+		Resource(target.Resource).
+		Name(target.proxyName()).
+		SubResource("proxy").
+		URL()
+
+	prefix := Path(target)
+	rewriter := &rewriter{
+		upstreamPath: upstream.Path + "/",
+		prefix:       prefix,
+	}
+
+	director := func(r *http.Request) {
+		r.URL.Scheme = upstream.Scheme
+		r.URL.Host = upstream.Host
+		r.URL.Path = upstream.Path + "/" + strings.TrimPrefix(r.URL.Path, prefix)
+		r.URL.RawPath = ""
+		r.Host = upstream.Host
+
+		// responses are rewritten, so ask for them uncompressed
+		r.Header.Del("Accept-Encoding")
+	}
+
+	return &httputil.ReverseProxy{
+		Director:       director,
+		Transport:      transport,
+		ModifyResponse: rewriter.rewrite,
+	}, nil
+}
+
+// linkAttribute matches root relative href, src and action attributes.
+var linkAttribute = regexp.MustCompile(`(?i)\b(href|src|action)(\s*=\s*["'])(/[^"']*)`)
+
+// rewriter rewrites upstream responses so links stay under the proxy path.
+type rewriter struct {
+	// upstreamPath is the API server proxy path. The API server rewrites
+	// some links to it.
+	upstreamPath string
+	prefix       string
+}
+
+func (rw *rewriter) rewrite(resp *http.Response) error {
+	// added rather than set so the upstream's own policy still applies
+	resp.Header.Add("Content-Security-Policy", sandboxPolicy)
+
+	if location := resp.Header.Get("Location"); location != "" {
+		resp.Header.Set("Location", rw.location(location))
+	}
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		return nil
+	}
+
+	if encoding := resp.Header.Get("Content-Encoding"); encoding != "" && encoding != "identity" {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read response")
+	}
+	if err := resp.Body.Close(); err != nil {
+		return errors.Wrap(err, "close response")
+	}
+
+	body = linkAttribute.ReplaceAllFunc(body, func(match []byte) []byte {
+		parts := linkAttribute.FindSubmatch(match)
+		link := rw.path(string(parts[3]))
+		return []byte(string(parts[1]) + string(parts[2]) + link)
+	})
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return nil
+}
+
+// location rewrites a redirect. Redirects to other hosts aren't changed.
+func (rw *rewriter) location(location string) string {
+	u, err := url.Parse(location)
+	if err != nil || !strings.HasPrefix(u.Path, "/") {
+		return location
+	}
+
+	if u.Host != "" {
+		if !strings.HasPrefix(u.Path, rw.upstreamPath) {
+			return location
+		}
+		u.Scheme, u.Host = "", ""
+	}
+
+	u.Path = rw.path(u.Path)
+	return u.String()
+}
+
+// path rewrites a root relative path to be under the proxy path.
+func (rw *rewriter) path(p string) string {
+	switch {
+	case strings.HasPrefix(p, "//"), strings.HasPrefix(p, rw.prefix):
+		return p
+	case strings.HasPrefix(p, rw.upstreamPath):
+		return rw.prefix + strings.TrimPrefix(p, rw.upstreamPath)
+	default:
+		return rw.prefix + strings.TrimPrefix(p, "/")
+	}
+}
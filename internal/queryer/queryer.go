@@ -294,6 +294,8 @@ var allowed = []schema.GroupVersionKind{
 	gvk.Pod,
 	gvk.Job,
 	gvk.ExtReplicaSet,
+	gvk.AppReplicaSet,
+	gvk.ControllerRevision,
 	gvk.ReplicationController,
 	gvk.StatefulSet,
 	gvk.Ingress,
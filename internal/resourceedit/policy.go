@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package resourceedit
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// LimitRangeWarnings returns the ways requirements of a container, or the
+// totals of its pod, violate the limit ranges of its namespace. Pods which
+// violate a limit range are rejected when they are created.
+func LimitRangeWarnings(limitRanges []corev1.LimitRange, requirements corev1.ResourceRequirements, pod corev1.PodSpec) []string {
+	var warnings []string
+
+	requests := EffectiveRequests(requirements)
+	podTotals := PodTotals(pod)
+
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			for _, name := range Edited {
+				request, hasRequest := requests[name]
+				limit, hasLimit := requirements.Limits[name]
+
+				switch item.Type {
+				case corev1.LimitTypeContainer:
+				case corev1.LimitTypePod:
+					request, hasRequest = podTotals[quotaName("requests", name)]
+					limit, hasLimit = podTotals[quotaName("limits", name)]
+				default:
+					continue
+				}
+
+				prefix := fmt.Sprintf("LimitRange %s: %s %s", limitRange.Name, item.Type, name)
+
+				if minimum, ok := item.Min[name]; ok && hasRequest && request.Cmp(minimum) < 0 {
+					warnings = append(warnings, fmt.Sprintf("%s request %s is below the minimum %s", prefix, request.String(), minimum.String()))
+				}
+
+				if maximum, ok := item.Max[name]; ok && hasLimit && limit.Cmp(maximum) > 0 {
+					warnings = append(warnings, fmt.Sprintf("%s limit %s is above the maximum %s", prefix, limit.String(), maximum.String()))
+				}
+
+				if ratio, ok := item.MaxLimitRequestRatio[name]; ok && hasRequest && hasLimit && request.MilliValue() > 0 {
+					actual := float64(limit.MilliValue()) / float64(request.MilliValue())
+					if actual > float64(ratio.MilliValue())/1000 {
+						warnings = append(warnings, fmt.Sprintf("%s limit to request ratio %.2f is above the maximum %s", prefix, actual, ratio.String()))
+					}
+				}
+			}
+		}
+	}
+
+	return warnings
+}
+
+// QuotaWarnings returns the resource quotas of a namespace which don't have
+// room for a change of totals from before to after. Quota scopes aren't
+// considered, so a warning may be for a quota which doesn't apply.
+func QuotaWarnings(quotas []corev1.ResourceQuota, before, after corev1.ResourceList) []string {
+	var warnings []string
+
+	for _, quota := range quotas {
+		for _, name := range totalNames {
+			increase := after[name].DeepCopy()
+			if previous, ok := before[name]; ok {
+				increase.Sub(previous)
+			}
+			if increase.Sign() <= 0 {
+				continue
+			}
+
+			for _, quotaName := range quotaNames(name) {
+				hard, ok := quota.Status.Hard[quotaName]
+				if !ok {
+					continue
+				}
+
+				used := quota.Status.Used[quotaName]
+				headroom := hard.DeepCopy()
+				headroom.Sub(used)
+
+				if increase.Cmp(headroom) > 0 {
+					left := nonNegative(headroom)
+					warnings = append(warnings, fmt.Sprintf("ResourceQuota %s: %s would increase by %s but only %s of %s is left",
+						quota.Name, quotaName, increase.String(), left.String(), hard.String()))
+				}
+			}
+		}
+	}
+
+	return warnings
+}
+
+// quotaNames returns the quota resource names a total counts against. cpu
+// and memory are short for requests.cpu and requests.memory in quotas.
+func quotaNames(name corev1.ResourceName) []corev1.ResourceName {
+	switch name {
+	case corev1.ResourceRequestsCPU:
+		return []corev1.ResourceName{name, corev1.ResourceCPU}
+	case corev1.ResourceRequestsMemory:
+		return []corev1.ResourceName{name, corev1.ResourceMemory}
+	default:
+		return []corev1.ResourceName{name}
+	}
+}
+
+func nonNegative(q resource.Quantity) resource.Quantity {
+	if q.Sign() < 0 {
+		return resource.Quantity{Format: q.Format}
+	}
+	return q
+}
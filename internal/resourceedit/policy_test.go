@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package resourceedit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func limitRange(item corev1.LimitRangeItem) corev1.LimitRange {
+	return corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits"},
+		Spec:       corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{item}},
+	}
+}
+
+func TestLimitRangeWarnings(t *testing.T) {
+	tests := []struct {
+		name         string
+		limitRange   corev1.LimitRange
+		requirements corev1.ResourceRequirements
+		pod          corev1.PodSpec
+		expected     []string
+	}{
+		{
+			name: "within container limits",
+			limitRange: limitRange(corev1.LimitRangeItem{
+				Type: corev1.LimitTypeContainer,
+				Min:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				Max:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			}),
+			requirements: resources("250m", "1", "", ""),
+		},
+		{
+			name: "container request below the minimum",
+			limitRange: limitRange(corev1.LimitRangeItem{
+				Type: corev1.LimitTypeContainer,
+				Min:  corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+			}),
+			requirements: resources("", "", "32Mi", ""),
+			expected:     []string{"LimitRange limits: Container memory request 32Mi is below the minimum 64Mi"},
+		},
+		{
+			name: "container limit above the maximum",
+			limitRange: limitRange(corev1.LimitRangeItem{
+				Type: corev1.LimitTypeContainer,
+				Max:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}),
+			requirements: resources("", "2", "", ""),
+			expected:     []string{"LimitRange limits: Container cpu limit 2 is above the maximum 1"},
+		},
+		{
+			name: "limit to request ratio above the maximum",
+			limitRange: limitRange(corev1.LimitRangeItem{
+				Type:                 corev1.LimitTypeContainer,
+				MaxLimitRequestRatio: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			}),
+			requirements: resources("250m", "1", "", ""),
+			expected:     []string{"LimitRange limits: Container cpu limit to request ratio 4.00 is above the maximum 2"},
+		},
+		{
+			name: "pod totals above the maximum",
+			limitRange: limitRange(corev1.LimitRangeItem{
+				Type: corev1.LimitTypePod,
+				Max:  corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			}),
+			requirements: resources("", "", "", "200Mi"),
+			pod: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Resources: resources("", "", "", "200Mi")},
+					{Resources: resources("", "", "", "100Mi")},
+				},
+			},
+			expected: []string{"LimitRange limits: Pod memory limit 300Mi is above the maximum 256Mi"},
+		},
+		{
+			name: "other limit types are ignored",
+			limitRange: limitRange(corev1.LimitRangeItem{
+				Type: corev1.LimitTypePersistentVolumeClaim,
+				Max:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}),
+			requirements: resources("", "2", "", ""),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := LimitRangeWarnings([]corev1.LimitRange{test.limitRange}, test.requirements, test.pod)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestQuotaWarnings(t *testing.T) {
+	quota := func(hard, used corev1.ResourceList) corev1.ResourceQuota {
+		return corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "quota"},
+			Status:     corev1.ResourceQuotaStatus{Hard: hard, Used: used},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		quota    corev1.ResourceQuota
+		before   corev1.ResourceList
+		after    corev1.ResourceList
+		expected []string
+	}{
+		{
+			name: "increase fits",
+			quota: quota(
+				corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("4")},
+				corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("2")}),
+			before: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1")},
+			after:  corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("3")},
+		},
+		{
+			name: "increase doesn't fit",
+			quota: quota(
+				corev1.ResourceList{corev1.ResourceLimitsMemory: resource.MustParse("1Gi")},
+				corev1.ResourceList{corev1.ResourceLimitsMemory: resource.MustParse("768Mi")}),
+			after:    corev1.ResourceList{corev1.ResourceLimitsMemory: resource.MustParse("512Mi")},
+			expected: []string{"ResourceQuota quota: limits.memory would increase by 512Mi but only 256Mi of 1Gi is left"},
+		},
+		{
+			name: "short names count as requests",
+			quota: quota(
+				corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}),
+			before:   corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1")},
+			after:    corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1500m")},
+			expected: []string{"ResourceQuota quota: cpu would increase by 500m but only 0 of 2 is left"},
+		},
+		{
+			name: "decreases are allowed over quota",
+			quota: quota(
+				corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1")},
+				corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("2")}),
+			before: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("2")},
+			after:  corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := QuotaWarnings([]corev1.ResourceQuota{test.quota}, test.before, test.after)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
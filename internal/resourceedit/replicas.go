@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package resourceedit
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Replicas returns how many pods a workload runs at once. Daemon sets run
+// a pod on each node they are scheduled to, and jobs run up to their
+// parallelism.
+func Replicas(object *unstructured.Unstructured) int64 {
+	var fields []string
+
+	switch object.GetKind() {
+	case "Deployment", "ReplicaSet", "StatefulSet", "ReplicationController":
+		fields = []string{"spec", "replicas"}
+	case "Job":
+		fields = []string{"spec", "parallelism"}
+	case "CronJob":
+		fields = []string{"spec", "jobTemplate", "spec", "parallelism"}
+	case "DaemonSet":
+		replicas, _, _ := unstructured.NestedInt64(object.Object, "status", "desiredNumberScheduled")
+		return replicas
+	default:
+		return 1
+	}
+
+	replicas, found, err := unstructured.NestedInt64(object.Object, fields...)
+	if err != nil || !found {
+		return 1
+	}
+	return replicas
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package resourceedit edits the CPU and memory requests and limits of
+// containers and checks them against limit ranges and resource quotas.
+package resourceedit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Edited are the resources which are edited. Other resources, like
+// ephemeral storage or devices, are left as they are.
+var Edited = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// Values are the edited requests and limits as entered. Blank values are unset.
+type Values struct {
+	Requests map[corev1.ResourceName]string
+	Limits   map[corev1.ResourceName]string
+}
+
+// Format returns the requests and limits of a container for editing.
+func Format(requirements corev1.ResourceRequirements) Values {
+	values := Values{
+		Requests: map[corev1.ResourceName]string{},
+		Limits:   map[corev1.ResourceName]string{},
+	}
+
+	for _, name := range Edited {
+		if q, ok := requirements.Requests[name]; ok {
+			values.Requests[name] = q.String()
+		}
+		if q, ok := requirements.Limits[name]; ok {
+			values.Limits[name] = q.String()
+		}
+	}
+
+	return values
+}
+
+// Parse parses requests and limits. Limits lower than requests are rejected.
+func Parse(values Values) (corev1.ResourceRequirements, error) {
+	requirements := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+
+	for _, name := range Edited {
+		request, err := parseQuantity(values.Requests[name])
+		if err != nil {
+			return requirements, errors.Wrapf(err, "%s request", name)
+		}
+		limit, err := parseQuantity(values.Limits[name])
+		if err != nil {
+			return requirements, errors.Wrapf(err, "%s limit", name)
+		}
+
+		if request != nil {
+			requirements.Requests[name] = *request
+		}
+		if limit != nil {
+			requirements.Limits[name] = *limit
+		}
+
+		if request != nil && limit != nil && limit.Cmp(*request) < 0 {
+			return requirements, errors.Errorf("%s limit %s is lower than the request %s", name, limit, request)
+		}
+	}
+
+	return requirements, nil
+}
+
+func parseQuantity(s string) (*resource.Quantity, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return nil, errors.Errorf("invalid quantity %q", s)
+	}
+	if q.Sign() < 0 {
+		return nil, errors.Errorf("quantity %q is negative", s)
+	}
+
+	return &q, nil
+}
+
+// Apply sets the edited requests and limits of a container.
+func Apply(container *corev1.Container, requirements corev1.ResourceRequirements) {
+	container.Resources.Requests = apply(container.Resources.Requests, requirements.Requests)
+	container.Resources.Limits = apply(container.Resources.Limits, requirements.Limits)
+}
+
+func apply(current, edited corev1.ResourceList) corev1.ResourceList {
+	list := corev1.ResourceList{}
+	for name, q := range current {
+		list[name] = q
+	}
+
+	for _, name := range Edited {
+		if q, ok := edited[name]; ok {
+			list[name] = q
+		} else {
+			delete(list, name)
+		}
+	}
+
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
+
+// totalNames are the quota resource names of totals in display order.
+var totalNames = []corev1.ResourceName{
+	corev1.ResourceRequestsCPU,
+	corev1.ResourceLimitsCPU,
+	corev1.ResourceRequestsMemory,
+	corev1.ResourceLimitsMemory,
+}
+
+// PodTotals returns the requests and limits a pod is scheduled with, named
+// like quota resources. Init containers run one at a time before the other
+// containers, so a pod needs the largest of any init container and the sum
+// of the other containers.
+func PodTotals(spec corev1.PodSpec) corev1.ResourceList {
+	totals := corev1.ResourceList{}
+
+	for _, container := range spec.Containers {
+		for _, name := range Edited {
+			add(totals, quotaName("requests", name), EffectiveRequests(container.Resources), name)
+			add(totals, quotaName("limits", name), container.Resources.Limits, name)
+		}
+	}
+
+	for _, container := range spec.InitContainers {
+		for _, name := range Edited {
+			atLeast(totals, quotaName("requests", name), EffectiveRequests(container.Resources), name)
+			atLeast(totals, quotaName("limits", name), container.Resources.Limits, name)
+		}
+	}
+
+	return totals
+}
+
+// EffectiveRequests returns the requests of a container. A resource with a
+// limit and no request is requested at its limit.
+func EffectiveRequests(requirements corev1.ResourceRequirements) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for name, q := range requirements.Requests {
+		requests[name] = q
+	}
+
+	for name, q := range requirements.Limits {
+		if _, ok := requests[name]; !ok {
+			requests[name] = q
+		}
+	}
+
+	return requests
+}
+
+// Totals returns the totals of a pod across replicas.
+func Totals(spec corev1.PodSpec, replicas int64) corev1.ResourceList {
+	totals := corev1.ResourceList{}
+	for name, q := range PodTotals(spec) {
+		totals[name] = multiply(q, replicas)
+	}
+	return totals
+}
+
+// Describe describes totals, such as "requests.cpu 500m, limits.memory 1Gi".
+func Describe(totals corev1.ResourceList) string {
+	var parts []string
+	for _, name := range totalNames {
+		if q, ok := totals[name]; ok {
+			parts = append(parts, fmt.Sprintf("%s %s", name, q.String()))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// TotalNames are the names of totals in display order.
+func TotalNames() []corev1.ResourceName {
+	return append([]corev1.ResourceName(nil), totalNames...)
+}
+
+func quotaName(prefix string, name corev1.ResourceName) corev1.ResourceName {
+	return corev1.ResourceName(prefix + "." + string(name))
+}
+
+func add(totals corev1.ResourceList, total corev1.ResourceName, list corev1.ResourceList, name corev1.ResourceName) {
+	q, ok := list[name]
+	if !ok {
+		return
+	}
+
+	sum := totals[total]
+	sum.Add(q)
+	totals[total] = sum
+}
+
+func atLeast(totals corev1.ResourceList, total corev1.ResourceName, list corev1.ResourceList, name corev1.ResourceName) {
+	q, ok := list[name]
+	if !ok {
+		return
+	}
+
+	if current, ok := totals[total]; !ok || q.Cmp(current) > 0 {
+		totals[total] = q.DeepCopy()
+	}
+}
+
+func multiply(q resource.Quantity, n int64) resource.Quantity {
+	if q.MilliValue()%1000 != 0 {
+		return *resource.NewMilliQuantity(q.MilliValue()*n, q.Format)
+	}
+	return *resource.NewQuantity(q.Value()*n, q.Format)
+}
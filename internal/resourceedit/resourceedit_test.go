@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package resourceedit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// listStrings returns a resource list as strings so quantities compare by value.
+func listStrings(list corev1.ResourceList) map[corev1.ResourceName]string {
+	m := make(map[corev1.ResourceName]string)
+	for name, q := range list {
+		m[name] = q.String()
+	}
+	return m
+}
+
+func resources(cpuRequest, cpuLimit, memoryRequest, memoryLimit string) corev1.ResourceRequirements {
+	requirements := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+
+	set := func(list corev1.ResourceList, name corev1.ResourceName, value string) {
+		if value != "" {
+			list[name] = resource.MustParse(value)
+		}
+	}
+	set(requirements.Requests, corev1.ResourceCPU, cpuRequest)
+	set(requirements.Limits, corev1.ResourceCPU, cpuLimit)
+	set(requirements.Requests, corev1.ResourceMemory, memoryRequest)
+	set(requirements.Limits, corev1.ResourceMemory, memoryLimit)
+
+	return requirements
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name             string
+		values           Values
+		expectedRequests map[corev1.ResourceName]string
+		expectedLimits   map[corev1.ResourceName]string
+		isErr            bool
+	}{
+		{
+			name: "requests and limits",
+			values: Values{
+				Requests: map[corev1.ResourceName]string{corev1.ResourceCPU: "250m", corev1.ResourceMemory: " 128Mi "},
+				Limits:   map[corev1.ResourceName]string{corev1.ResourceCPU: "1", corev1.ResourceMemory: "256Mi"},
+			},
+			expectedRequests: map[corev1.ResourceName]string{corev1.ResourceCPU: "250m", corev1.ResourceMemory: "128Mi"},
+			expectedLimits:   map[corev1.ResourceName]string{corev1.ResourceCPU: "1", corev1.ResourceMemory: "256Mi"},
+		},
+		{
+			name: "blank values are unset",
+			values: Values{
+				Requests: map[corev1.ResourceName]string{corev1.ResourceCPU: "100m", corev1.ResourceMemory: ""},
+				Limits:   map[corev1.ResourceName]string{},
+			},
+			expectedRequests: map[corev1.ResourceName]string{corev1.ResourceCPU: "100m"},
+			expectedLimits:   map[corev1.ResourceName]string{},
+		},
+		{
+			name: "invalid quantity",
+			values: Values{
+				Requests: map[corev1.ResourceName]string{corev1.ResourceCPU: "lots"},
+			},
+			isErr: true,
+		},
+		{
+			name: "negative quantity",
+			values: Values{
+				Limits: map[corev1.ResourceName]string{corev1.ResourceMemory: "-1Gi"},
+			},
+			isErr: true,
+		},
+		{
+			name: "limit lower than the request",
+			values: Values{
+				Requests: map[corev1.ResourceName]string{corev1.ResourceCPU: "2"},
+				Limits:   map[corev1.ResourceName]string{corev1.ResourceCPU: "500m"},
+			},
+			isErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse(test.values)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedRequests, listStrings(got.Requests))
+			assert.Equal(t, test.expectedLimits, listStrings(got.Limits))
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	requirements := resources("250m", "", "128Mi", "256Mi")
+	requirements.Limits[corev1.ResourceEphemeralStorage] = resource.MustParse("1Gi")
+
+	got := Format(requirements)
+
+	assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceCPU: "250m", corev1.ResourceMemory: "128Mi"}, got.Requests)
+	assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceMemory: "256Mi"}, got.Limits)
+}
+
+func TestApply(t *testing.T) {
+	container := corev1.Container{Resources: resources("100m", "1", "", "")}
+	container.Resources.Limits[corev1.ResourceEphemeralStorage] = resource.MustParse("1Gi")
+
+	Apply(&container, resources("", "2", "64Mi", ""))
+
+	assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceMemory: "64Mi"}, listStrings(container.Resources.Requests))
+	assert.Equal(t, map[corev1.ResourceName]string{
+		corev1.ResourceCPU:              "2",
+		corev1.ResourceEphemeralStorage: "1Gi",
+	}, listStrings(container.Resources.Limits))
+
+	Apply(&container, resources("", "", "", ""))
+	assert.Nil(t, container.Resources.Requests)
+	assert.Equal(t, map[corev1.ResourceName]string{corev1.ResourceEphemeralStorage: "1Gi"}, listStrings(container.Resources.Limits))
+}
+
+func TestPodTotals(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     corev1.PodSpec
+		expected map[corev1.ResourceName]string
+	}{
+		{
+			name: "containers are summed",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Resources: resources("100m", "500m", "64Mi", "128Mi")},
+					{Resources: resources("200m", "", "64Mi", "")},
+				},
+			},
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourceRequestsCPU:    "300m",
+				corev1.ResourceLimitsCPU:      "500m",
+				corev1.ResourceRequestsMemory: "128Mi",
+				corev1.ResourceLimitsMemory:   "128Mi",
+			},
+		},
+		{
+			name: "limits without requests are requested",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Resources: resources("", "1", "", "")},
+				},
+			},
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourceRequestsCPU: "1",
+				corev1.ResourceLimitsCPU:   "1",
+			},
+		},
+		{
+			name: "init containers need the largest of any",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Resources: resources("2", "", "", "")},
+					{Resources: resources("", "", "32Mi", "")},
+				},
+				Containers: []corev1.Container{
+					{Resources: resources("500m", "", "64Mi", "")},
+				},
+			},
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourceRequestsCPU:    "2",
+				corev1.ResourceRequestsMemory: "64Mi",
+			},
+		},
+		{
+			name:     "no resources",
+			spec:     corev1.PodSpec{Containers: []corev1.Container{{}}},
+			expected: map[corev1.ResourceName]string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, listStrings(PodTotals(test.spec)))
+		})
+	}
+}
+
+func TestTotals(t *testing.T) {
+	spec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{Resources: resources("250m", "1", "100Mi", "")},
+		},
+	}
+
+	got := Totals(spec, 3)
+
+	assert.Equal(t, map[corev1.ResourceName]string{
+		corev1.ResourceRequestsCPU:    "750m",
+		corev1.ResourceLimitsCPU:      "3",
+		corev1.ResourceRequestsMemory: "300Mi",
+	}, listStrings(got))
+
+	assert.Equal(t, "requests.cpu 750m, limits.cpu 3, requests.memory 300Mi", Describe(got))
+	assert.Equal(t, "none", Describe(nil))
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package resourceedit
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// Review describes the effect of changing the resources of a container.
+type Review struct {
+	// Replicas is how many pods the workload runs.
+	Replicas int64
+	// Before and After are the totals across replicas.
+	Before corev1.ResourceList
+	After  corev1.ResourceList
+	// Warnings are the limit ranges and quotas the change would violate.
+	Warnings []string
+}
+
+// ReviewChange reviews changing the resources of a container in a workload.
+// containersPath is the path of the containers or init containers list
+// within the workload.
+func ReviewChange(ctx context.Context, objectStore store.Store, object *unstructured.Unstructured, containersPath []string, containerName string, requirements corev1.ResourceRequirements) (Review, error) {
+	var review Review
+
+	if len(containersPath) < 2 {
+		return review, errors.Errorf("invalid containers path %v", containersPath)
+	}
+
+	m, found, err := unstructured.NestedMap(object.Object, containersPath[:len(containersPath)-1]...)
+	if err != nil || !found {
+		return review, errors.Errorf("unable to find pod spec within %s %s", object.GetKind(), object.GetName())
+	}
+
+	var before corev1.PodSpec
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &before); err != nil {
+		return review, errors.Wrap(err, "convert pod spec")
+	}
+
+	after := before.DeepCopy()
+	containers := after.Containers
+	if containersPath[len(containersPath)-1] == "initContainers" {
+		containers = after.InitContainers
+	}
+
+	var edited *corev1.Container
+	for i := range containers {
+		if containers[i].Name == containerName {
+			edited = &containers[i]
+		}
+	}
+	if edited == nil {
+		return review, errors.Errorf("container %q was not found", containerName)
+	}
+	Apply(edited, requirements)
+
+	review.Replicas = Replicas(object)
+	review.Before = Totals(before, review.Replicas)
+	review.After = Totals(*after, review.Replicas)
+
+	namespace := object.GetNamespace()
+
+	var limitRanges []corev1.LimitRange
+	if err := list(ctx, objectStore, namespace, "LimitRange", func(u *unstructured.Unstructured) error {
+		var limitRange corev1.LimitRange
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &limitRange); err != nil {
+			return err
+		}
+		limitRanges = append(limitRanges, limitRange)
+		return nil
+	}); err != nil {
+		return review, err
+	}
+
+	var quotas []corev1.ResourceQuota
+	if err := list(ctx, objectStore, namespace, "ResourceQuota", func(u *unstructured.Unstructured) error {
+		var quota corev1.ResourceQuota
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &quota); err != nil {
+			return err
+		}
+		quotas = append(quotas, quota)
+		return nil
+	}); err != nil {
+		return review, err
+	}
+
+	review.Warnings = append(review.Warnings, LimitRangeWarnings(limitRanges, edited.Resources, *after)...)
+	review.Warnings = append(review.Warnings, QuotaWarnings(quotas, review.Before, review.After)...)
+
+	return review, nil
+}
+
+func list(ctx context.Context, objectStore store.Store, namespace, kind string, fn func(u *unstructured.Unstructured) error) error {
+	objects, _, err := objectStore.List(ctx, store.Key{Namespace: namespace, ApiVersion: "v1", Kind: kind})
+	if err != nil {
+		return errors.Wrapf(err, "list %s", kind)
+	}
+
+	for i := range objects.Items {
+		if err := fn(&objects.Items[i]); err != nil {
+			return errors.Wrapf(err, "convert %s %s", kind, objects.Items[i].GetName())
+		}
+	}
+
+	return nil
+}
+
+// PreviousTotalsAnnotation records the totals of a workload before its
+// resources were last edited, so views can show the change after the alert
+// for it is gone.
+const PreviousTotalsAnnotation = "resources.kubeon.io/previous-totals"
+
+// SetPreviousTotals records the totals of a workload before an edit.
+func SetPreviousTotals(object *unstructured.Unstructured, totals corev1.ResourceList) error {
+	values := make(map[corev1.ResourceName]string)
+	for name, q := range totals {
+		values[name] = q.String()
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return errors.Wrap(err, "marshal totals")
+	}
+
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[PreviousTotalsAnnotation] = string(data)
+	object.SetAnnotations(annotations)
+
+	return nil
+}
+
+// PreviousTotals returns the totals recorded by SetPreviousTotals. It returns
+// false if there are none, or they can't be read.
+func PreviousTotals(annotations map[string]string) (corev1.ResourceList, bool) {
+	data, ok := annotations[PreviousTotalsAnnotation]
+	if !ok {
+		return nil, false
+	}
+
+	var values map[corev1.ResourceName]string
+	if err := json.Unmarshal([]byte(data), &values); err != nil {
+		return nil, false
+	}
+
+	totals := corev1.ResourceList{}
+	for name, value := range values {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, false
+		}
+		totals[name] = q
+	}
+
+	return totals, true
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package resourceedit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPreviousTotals(t *testing.T) {
+	object := &unstructured.Unstructured{}
+	object.SetAnnotations(map[string]string{"team": "a"})
+
+	_, ok := PreviousTotals(object.GetAnnotations())
+	assert.False(t, ok)
+
+	totals := corev1.ResourceList{
+		corev1.ResourceRequestsCPU:  resource.MustParse("750m"),
+		corev1.ResourceLimitsMemory: resource.MustParse("1Gi"),
+	}
+	require.NoError(t, SetPreviousTotals(object, totals))
+
+	got, ok := PreviousTotals(object.GetAnnotations())
+	require.True(t, ok)
+	assert.Equal(t, listStrings(totals), listStrings(got))
+	assert.Equal(t, "a", object.GetAnnotations()["team"])
+
+	_, ok = PreviousTotals(map[string]string{PreviousTotalsAnnotation: "{"})
+	assert.False(t, ok)
+	_, ok = PreviousTotals(map[string]string{PreviousTotalsAnnotation: `{"requests.cpu":"lots"}`})
+	assert.False(t, ok)
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubenext/kubeon/internal/diff"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+const (
+	// controllerRevisionHashLabel links daemon set and stateful set pods to
+	// the controller revision they were created from.
+	controllerRevisionHashLabel = "controller-revision-hash"
+)
+
+// PodCounts are the pods created from a revision.
+type PodCounts struct {
+	Desired int64
+	Current int64
+	Ready   int64
+}
+
+func (c PodCounts) String() string {
+	return fmt.Sprintf("%d/%d ready, %d desired", c.Ready, c.Current, c.Desired)
+}
+
+// Pods counts the pods created from a revision of an object. Replica sets
+// track their own pods; pods from a controller revision are found by label.
+func Pods(ctx context.Context, objectStore store.Store, object *unstructured.Unstructured, revision Revision) (PodCounts, error) {
+	var counts PodCounts
+
+	if revision.object.GetKind() == "ReplicaSet" {
+		counts.Desired, _, _ = unstructured.NestedInt64(revision.object.Object, "spec", "replicas")
+		counts.Current, _, _ = unstructured.NestedInt64(revision.object.Object, "status", "replicas")
+		counts.Ready, _, _ = unstructured.NestedInt64(revision.object.Object, "status", "readyReplicas")
+		return counts, nil
+	}
+
+	// daemon set pods are labeled with the revision hash, and stateful set
+	// pods with the revision name
+	hash := revision.Name
+	if object.GetKind() == "DaemonSet" {
+		hash = revision.object.GetLabels()[controllerRevisionHashLabel]
+	}
+	if hash == "" {
+		return counts, nil
+	}
+
+	key := store.Key{
+		Namespace:  object.GetNamespace(),
+		ApiVersion: "v1",
+		Kind:       "Pod",
+		Selector:   &labels.Set{controllerRevisionHashLabel: hash},
+	}
+
+	list, _, err := objectStore.List(ctx, key)
+	if err != nil {
+		return counts, errors.Wrap(err, "list pods")
+	}
+
+	for i := range list.Items {
+		pod := &corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, pod); err != nil {
+			return counts, errors.Wrap(err, "convert pod")
+		}
+
+		if !ownedBy(pod, object) {
+			continue
+		}
+
+		counts.Current++
+		if podReady(pod) {
+			counts.Ready++
+		}
+	}
+
+	// only the current revision should have pods
+	if revision.Current {
+		counts.Desired = counts.Current
+		if replicas, ok, _ := unstructured.NestedInt64(object.Object, "spec", "replicas"); ok {
+			counts.Desired = replicas
+		}
+		if desired, ok, _ := unstructured.NestedInt64(object.Object, "status", "desiredNumberScheduled"); ok {
+			counts.Desired = desired
+		}
+	}
+
+	return counts, nil
+}
+
+func ownedBy(pod *corev1.Pod, object *unstructured.Unstructured) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.UID == object.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// Comparison is the difference between the pod templates of two revisions.
+type Comparison struct {
+	From    int64
+	To      int64
+	Changes []diff.Change
+	// Diff is a unified diff of the pod templates as YAML.
+	Diff string
+}
+
+// Compare compares the pod templates of two revisions of an object.
+func Compare(revisions []Revision, from, to int64) (Comparison, error) {
+	comparison := Comparison{From: from, To: to}
+
+	fromRevision, err := findRevision(revisions, from)
+	if err != nil {
+		return comparison, err
+	}
+
+	toRevision, err := findRevision(revisions, to)
+	if err != nil {
+		return comparison, err
+	}
+
+	fromTemplate, err := fromRevision.Template()
+	if err != nil {
+		return comparison, err
+	}
+
+	toTemplate, err := toRevision.Template()
+	if err != nil {
+		return comparison, err
+	}
+
+	comparison.Changes = diff.Fields(fromTemplate, toTemplate)
+
+	comparison.Diff, err = diff.Maps(
+		fmt.Sprintf("revision %d", from), fmt.Sprintf("revision %d", to), fromTemplate, toTemplate)
+	if err != nil {
+		return comparison, err
+	}
+
+	return comparison, nil
+}
+
+func findRevision(revisions []Revision, number int64) (*Revision, error) {
+	for i := range revisions {
+		if revisions[i].Number == number {
+			return &revisions[i], nil
+		}
+	}
+
+	return nil, errors.Errorf("revision %d not found", number)
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package rollout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubenext/kubeon/internal/diff"
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+// listStore is a store which only lists objects. Selectors aren't applied.
+type listStore struct {
+	store.Store
+	objects []runtime.Object
+}
+
+func (s *listStore) List(ctx context.Context, key store.Key) (*unstructured.UnstructuredList, bool, error) {
+	list := &unstructured.UnstructuredList{}
+	for _, object := range s.objects {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err != nil {
+			return nil, false, err
+		}
+		list.Items = append(list.Items, unstructured.Unstructured{Object: m})
+	}
+	return list, false, nil
+}
+
+func revisionsOf(t *testing.T, object *unstructured.Unstructured, children ...unstructured.Unstructured) []Revision {
+	revisions, err := Revisions(context.Background(), &childrenQueryer{children: children}, object)
+	require.NoError(t, err)
+	return revisions
+}
+
+func TestCompare(t *testing.T) {
+	revisions := revisionsOf(t, deployment(false),
+		replicaSet("web-1", "1", "web:1"),
+		replicaSet("web-2", "2", "web:2"),
+	)
+
+	got, err := Compare(revisions, 1, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), got.From)
+	assert.Equal(t, int64(2), got.To)
+	// the pod template hash label differs between replica sets but isn't a change
+	assert.Equal(t, []diff.Change{{Path: "spec.containers[web].image", From: "web:1", To: "web:2"}}, got.Changes)
+	assert.Contains(t, got.Diff, "-  - image: web:1\n+  - image: web:2\n")
+
+	_, err = Compare(revisions, 1, 3)
+	require.Error(t, err)
+}
+
+func TestPods_replicaSet(t *testing.T) {
+	rs := replicaSet("web-1", "1", "web:1")
+	require.NoError(t, unstructured.SetNestedField(rs.Object, int64(3), "spec", "replicas"))
+	require.NoError(t, unstructured.SetNestedField(rs.Object, int64(3), "status", "replicas"))
+	require.NoError(t, unstructured.SetNestedField(rs.Object, int64(2), "status", "readyReplicas"))
+
+	object := deployment(false)
+	revisions := revisionsOf(t, object, rs)
+
+	got, err := Pods(context.Background(), &listStore{}, object, revisions[0])
+	require.NoError(t, err)
+	assert.Equal(t, PodCounts{Desired: 3, Current: 3, Ready: 2}, got)
+	assert.Equal(t, "2/3 ready, 3 desired", got.String())
+}
+
+func TestPods_daemonSet(t *testing.T) {
+	daemonSet := deployment(false)
+	daemonSet.SetKind("DaemonSet")
+	require.NoError(t, unstructured.SetNestedField(daemonSet.Object, int64(3), "status", "desiredNumberScheduled"))
+
+	controllerRevision := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "ControllerRevision",
+		"metadata": map[string]interface{}{
+			"name":   "web-6d4f",
+			"labels": map[string]interface{}{controllerRevisionHashLabel: "6d4f"},
+		},
+		"revision": int64(1),
+	}}
+
+	pod := func(name string, owner types.UID, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				OwnerReferences: []metav1.OwnerReference{{UID: owner}},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	objectStore := &listStore{
+		objects: []runtime.Object{
+			pod("web-a", daemonSet.GetUID(), corev1.ConditionTrue),
+			pod("web-b", daemonSet.GetUID(), corev1.ConditionFalse),
+			pod("other", "other-uid", corev1.ConditionTrue),
+		},
+	}
+
+	revisions := revisionsOf(t, daemonSet, controllerRevision)
+	require.Len(t, revisions, 1)
+
+	got, err := Pods(context.Background(), objectStore, daemonSet, revisions[0])
+	require.NoError(t, err)
+	assert.Equal(t, PodCounts{Desired: 3, Current: 2, Ready: 1}, got)
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubenext/kubeon/internal/queryer"
+)
+
+const (
+	// deploymentRevisionAnnotation is set on replica sets by the deployment controller.
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+	// podTemplateHashLabel is added to pod templates by the deployment controller.
+	podTemplateHashLabel = "pod-template-hash"
+	// changeCauseAnnotation records the command which created a revision.
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// Revision is a revision of an object's pod template. Deployment revisions
+// are stored in replica sets, and daemon set and stateful set revisions are
+// stored in controller revisions.
+type Revision struct {
+	Number      int64
+	Name        string
+	ChangeCause string
+	Created     time.Time
+	Images      []string
+	// Current is true for the newest revision.
+	Current bool
+
+	object *unstructured.Unstructured
+}
+
+// Description describes a revision for choosing it.
+func (r Revision) Description() string {
+	description := fmt.Sprintf("Revision %d", r.Number)
+	if len(r.Images) > 0 {
+		description = fmt.Sprintf("%s (%s)", description, strings.Join(r.Images, ", "))
+	}
+	if r.Current {
+		description += " - current"
+	}
+	return description
+}
+
+// Revisions returns the revisions of an object, newest first.
+func Revisions(ctx context.Context, q queryer.Queryer, object *unstructured.Unstructured) ([]Revision, error) {
+	children, err := q.Children(ctx, object)
+	if err != nil {
+		return nil, errors.Wrap(err, "find children")
+	}
+
+	childKind := "ControllerRevision"
+	if object.GetKind() == "Deployment" {
+		childKind = "ReplicaSet"
+	}
+
+	seen := make(map[types.UID]bool)
+
+	var revisions []Revision
+	for i := range children.Items {
+		child := &children.Items[i]
+		if child.GetKind() != childKind || seen[child.GetUID()] {
+			continue
+		}
+		seen[child.GetUID()] = true
+
+		revision, ok, err := revisionFor(child)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read revision from %s %q", child.GetKind(), child.GetName())
+		}
+		if ok {
+			revisions = append(revisions, revision)
+		}
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number > revisions[j].Number
+	})
+
+	if len(revisions) > 0 {
+		revisions[0].Current = true
+	}
+
+	return revisions, nil
+}
+
+func revisionFor(child *unstructured.Unstructured) (Revision, bool, error) {
+	revision := Revision{
+		Name:        child.GetName(),
+		ChangeCause: child.GetAnnotations()[changeCauseAnnotation],
+		Created:     child.GetCreationTimestamp().Time,
+		object:      child,
+	}
+
+	switch child.GetKind() {
+	case "ReplicaSet":
+		value, ok := child.GetAnnotations()[deploymentRevisionAnnotation]
+		if !ok {
+			return revision, false, nil
+		}
+
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return revision, false, errors.Wrap(err, "parse revision annotation")
+		}
+
+		revision.Number = number
+	case "ControllerRevision":
+		number, ok, err := unstructured.NestedInt64(child.Object, "revision")
+		if err != nil || !ok {
+			return revision, false, err
+		}
+
+		revision.Number = number
+	default:
+		return revision, false, nil
+	}
+
+	template, err := revision.Template()
+	if err != nil {
+		return revision, false, err
+	}
+
+	containers, _, err := unstructured.NestedSlice(template, "spec", "containers")
+	if err != nil {
+		return revision, false, err
+	}
+
+	for _, container := range containers {
+		m, ok := container.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if image, ok := m["image"].(string); ok {
+			revision.Images = append(revision.Images, image)
+		}
+	}
+
+	return revision, true, nil
+}
+
+// Template returns the pod template of a revision. Labels added by the
+// deployment controller are left out.
+func (r Revision) Template() (map[string]interface{}, error) {
+	path := []string{"spec", "template"}
+	if r.object.GetKind() == "ControllerRevision" {
+		path = []string{"data", "spec", "template"}
+	}
+
+	template, _, err := unstructured.NestedMap(r.object.Object, path...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read pod template from %s %q", r.object.GetKind(), r.Name)
+	}
+
+	unstructured.RemoveNestedField(template, "metadata", "labels", podTemplateHashLabel)
+	// controller revisions store the template as a patch which replaces it
+	delete(template, "$patch")
+
+	return template, nil
+}
+
+// Undo rolls an object back to a revision.
+func Undo(client dynamic.ResourceInterface, object *unstructured.Unstructured, revisions []Revision, number int64) error {
+	target, err := findRevision(revisions, number)
+	if err != nil {
+		return err
+	}
+
+	if target.Current {
+		return errors.Errorf("revision %d is the current revision", number)
+	}
+
+	if object.GetKind() == "Deployment" {
+		return undoDeployment(client, object, target)
+	}
+
+	return undoControllerRevision(client, object, target)
+}
+
+// undoDeployment replaces the pod template of a deployment with the one from
+// a replica set. The patch fails if the deployment changed after it was read.
+func undoDeployment(client dynamic.ResourceInterface, object *unstructured.Unstructured, target *Revision) error {
+	if IsPaused(object) {
+		return errors.New("rollout is paused; resume it before undoing")
+	}
+
+	template, err := target.Template()
+	if err != nil {
+		return err
+	}
+	if len(template) == 0 {
+		return errors.Errorf("replica set %q has no pod template", target.Name)
+	}
+
+	patch := []map[string]interface{}{
+		{"op": "test", "path": "/metadata/resourceVersion", "value": object.GetResourceVersion()},
+		{"op": "replace", "path": "/spec/template", "value": template},
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return errors.Wrap(err, "marshal patch")
+	}
+
+	_, err = client.Patch(object.GetName(), types.JSONPatchType, data, metav1.PatchOptions{})
+	return err
+}
+
+// undoControllerRevision applies the patch stored in a controller revision,
+// which replaces the pod template of a daemon set or stateful set.
+func undoControllerRevision(client dynamic.ResourceInterface, object *unstructured.Unstructured, target *Revision) error {
+	patch, ok, err := unstructured.NestedMap(target.object.Object, "data")
+	if err != nil || !ok {
+		return errors.Errorf("controller revision %q has no data", target.Name)
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return errors.Wrap(err, "marshal patch")
+	}
+
+	_, err = client.Patch(object.GetName(), types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	return err
+}
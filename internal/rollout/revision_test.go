@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package rollout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubenext/kubeon/internal/queryer"
+)
+
+// childrenQueryer is a queryer which only finds children.
+type childrenQueryer struct {
+	queryer.Queryer
+	children []unstructured.Unstructured
+}
+
+func (q *childrenQueryer) Children(ctx context.Context, object *unstructured.Unstructured) (*unstructured.UnstructuredList, error) {
+	return &unstructured.UnstructuredList{Items: q.children}, nil
+}
+
+func replicaSet(name, revision, image string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "ReplicaSet",
+		"metadata": map[string]interface{}{
+			"name": name,
+			"uid":  name + "-uid",
+			"annotations": map[string]interface{}{
+				deploymentRevisionAnnotation: revision,
+			},
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{
+						"app":                "web",
+						podTemplateHashLabel: name,
+					},
+				},
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "web", "image": image},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func TestRevisions(t *testing.T) {
+	pod := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": "web-2-abcde"},
+	}}
+
+	noRevision := replicaSet("web-0", "", "web:0")
+	unstructured.RemoveNestedField(noRevision.Object, "metadata", "annotations")
+
+	q := &childrenQueryer{
+		children: []unstructured.Unstructured{
+			replicaSet("web-1", "1", "web:1"),
+			replicaSet("web-2", "2", "web:2"),
+			// children can be found more than once
+			replicaSet("web-2", "2", "web:2"),
+			noRevision,
+			pod,
+		},
+	}
+
+	revisions, err := Revisions(context.Background(), q, deployment(false))
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+
+	assert.Equal(t, int64(2), revisions[0].Number)
+	assert.Equal(t, "web-2", revisions[0].Name)
+	assert.Equal(t, []string{"web:2"}, revisions[0].Images)
+	assert.True(t, revisions[0].Current)
+	assert.Equal(t, "Revision 2 (web:2) - current", revisions[0].Description())
+
+	assert.Equal(t, int64(1), revisions[1].Number)
+	assert.False(t, revisions[1].Current)
+}
+
+func TestRevisions_controllerRevisions(t *testing.T) {
+	controllerRevision := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "ControllerRevision",
+		"metadata":   map[string]interface{}{"name": "agent-1", "uid": "agent-1-uid"},
+		"revision":   int64(3),
+		"data": map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"$patch": "replace",
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "agent", "image": "agent:3"},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	q := &childrenQueryer{
+		children: []unstructured.Unstructured{
+			controllerRevision,
+			// replica sets aren't revisions of daemon sets
+			replicaSet("agent-rs", "1", "agent:1"),
+		},
+	}
+
+	daemonSet := deployment(false)
+	daemonSet.SetKind("DaemonSet")
+
+	revisions, err := Revisions(context.Background(), q, daemonSet)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+
+	assert.Equal(t, int64(3), revisions[0].Number)
+	assert.Equal(t, []string{"agent:3"}, revisions[0].Images)
+}
+
+func TestUndo(t *testing.T) {
+	object := deployment(false)
+	client := deploymentClient(object)
+
+	q := &childrenQueryer{
+		children: []unstructured.Unstructured{
+			replicaSet("web-1", "1", "web:1"),
+			replicaSet("web-2", "2", "web:2"),
+		},
+	}
+
+	revisions, err := Revisions(context.Background(), q, object)
+	require.NoError(t, err)
+
+	require.Error(t, Undo(client, object, revisions, 2), "current revision")
+	require.Error(t, Undo(client, object, revisions, 5), "missing revision")
+
+	require.NoError(t, Undo(client, object, revisions, 1))
+
+	got, err := client.Get("web", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	template, _, err := unstructured.NestedMap(got.Object, "spec", "template")
+	require.NoError(t, err)
+
+	expected := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "web"},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "web", "image": "web:1"},
+			},
+		},
+	}
+	assert.Equal(t, expected, template)
+}
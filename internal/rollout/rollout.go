@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package rollout restarts, pauses, resumes and undoes rollouts of
+// deployments, daemon sets and stateful sets.
+package rollout
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// RestartedAtAnnotation is set on the pod template to restart a rollout.
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+	// OperationRestart restarts a rollout.
+	OperationRestart = "restart"
+	// OperationPause pauses a deployment rollout.
+	OperationPause = "pause"
+	// OperationResume resumes a paused deployment rollout.
+	OperationResume = "resume"
+	// OperationUndo rolls back to a previous revision.
+	OperationUndo = "undo"
+)
+
+// Supported returns true if objects of a kind can be rolled out.
+func Supported(apiVersion, kind string) bool {
+	if apiVersion != "apps/v1" {
+		return false
+	}
+
+	switch kind {
+	case "Deployment", "DaemonSet", "StatefulSet":
+		return true
+	default:
+		return false
+	}
+}
+
+// CanPause returns true if rollouts of a kind can be paused.
+func CanPause(kind string) bool {
+	return kind == "Deployment"
+}
+
+// IsPaused returns true if the rollout of an object is paused.
+func IsPaused(object *unstructured.Unstructured) bool {
+	paused, _, _ := unstructured.NestedBool(object.Object, "spec", "paused")
+	return paused
+}
+
+// Restart restarts the rollout of an object by setting an annotation on its
+// pod template.
+func Restart(client dynamic.ResourceInterface, object *unstructured.Unstructured, now time.Time) error {
+	if IsPaused(object) {
+		return errors.New("rollout is paused; resume it before restarting")
+	}
+
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						RestartedAtAnnotation: now.Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	}
+
+	return mergePatch(client, object.GetName(), patch)
+}
+
+// Pause pauses or resumes the rollout of a deployment.
+func Pause(client dynamic.ResourceInterface, object *unstructured.Unstructured, paused bool) error {
+	if !CanPause(object.GetKind()) {
+		return errors.Errorf("%s rollouts can't be paused", object.GetKind())
+	}
+
+	if IsPaused(object) == paused {
+		if paused {
+			return errors.New("rollout is already paused")
+		}
+		return errors.New("rollout is not paused")
+	}
+
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"paused": paused,
+		},
+	}
+
+	return mergePatch(client, object.GetName(), patch)
+}
+
+func mergePatch(client dynamic.ResourceInterface, name string, patch map[string]interface{}) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return errors.Wrap(err, "marshal patch")
+	}
+
+	_, err = client.Patch(name, types.MergePatchType, data, metav1.PatchOptions{})
+	return err
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package rollout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var deploymentsGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+func deployment(paused bool) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"namespace":       "default",
+			"name":            "web",
+			"uid":             "deployment-uid",
+			"resourceVersion": "1",
+		},
+		"spec": map[string]interface{}{
+			"paused": paused,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "web", "image": "web:2"},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func deploymentClient(object *unstructured.Unstructured) dynamic.ResourceInterface {
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), object)
+	return client.Resource(deploymentsGVR).Namespace(object.GetNamespace())
+}
+
+func TestSupported(t *testing.T) {
+	assert.True(t, Supported("apps/v1", "Deployment"))
+	assert.True(t, Supported("apps/v1", "DaemonSet"))
+	assert.True(t, Supported("apps/v1", "StatefulSet"))
+	assert.False(t, Supported("extensions/v1beta1", "Deployment"))
+	assert.False(t, Supported("apps/v1", "ReplicaSet"))
+
+	assert.True(t, CanPause("Deployment"))
+	assert.False(t, CanPause("StatefulSet"))
+}
+
+func TestRestart(t *testing.T) {
+	object := deployment(false)
+	client := deploymentClient(object)
+
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, Restart(client, object, now))
+
+	got, err := client.Get("web", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	restartedAt, _, err := unstructured.NestedString(got.Object, "spec", "template", "metadata", "annotations", RestartedAtAnnotation)
+	require.NoError(t, err)
+	assert.Equal(t, "2019-10-01T12:00:00Z", restartedAt)
+}
+
+func TestRestart_paused(t *testing.T) {
+	object := deployment(true)
+	require.Error(t, Restart(deploymentClient(object), object, time.Now()))
+}
+
+func TestPause(t *testing.T) {
+	object := deployment(false)
+	client := deploymentClient(object)
+
+	require.Error(t, Pause(client, object, false), "rollout is not paused")
+	require.NoError(t, Pause(client, object, true))
+
+	got, err := client.Get("web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, IsPaused(got))
+
+	require.Error(t, Pause(client, got, true), "rollout is already paused")
+
+	statefulSet := deployment(false)
+	statefulSet.SetKind("StatefulSet")
+	require.Error(t, Pause(client, statefulSet, true))
+}
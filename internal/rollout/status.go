@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// DefaultWatchTimeout is how long a rollout can go without completing
+	// before it is considered stalled.
+	DefaultWatchTimeout = 10 * time.Minute
+
+	watchInterval = 2 * time.Second
+
+	// progressDeadlineExceeded is the Progressing condition reason set when a
+	// deployment exceeds its progress deadline.
+	progressDeadlineExceeded = "ProgressDeadlineExceeded"
+)
+
+// Progress is the progress of a rollout.
+type Progress struct {
+	Done    bool
+	Stalled bool
+	Message string
+}
+
+// Status returns the rollout progress of an object.
+func Status(object *unstructured.Unstructured) (Progress, error) {
+	switch object.GetKind() {
+	case "Deployment":
+		deployment := &appsv1.Deployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, deployment); err != nil {
+			return Progress{}, errors.Wrap(err, "convert deployment")
+		}
+		return deploymentStatus(deployment), nil
+	case "DaemonSet":
+		daemonSet := &appsv1.DaemonSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, daemonSet); err != nil {
+			return Progress{}, errors.Wrap(err, "convert daemon set")
+		}
+		return daemonSetStatus(daemonSet), nil
+	case "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, statefulSet); err != nil {
+			return Progress{}, errors.Wrap(err, "convert stateful set")
+		}
+		return statefulSetStatus(statefulSet), nil
+	default:
+		return Progress{}, errors.Errorf("%s rollouts are not supported", object.GetKind())
+	}
+}
+
+func deploymentStatus(deployment *appsv1.Deployment) Progress {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return Progress{Message: "waiting for the deployment spec update to be observed"}
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == progressDeadlineExceeded {
+			return Progress{Stalled: true, Message: "exceeded its progress deadline"}
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	status := deployment.Status
+	switch {
+	case status.UpdatedReplicas < replicas:
+		return Progress{Message: fmt.Sprintf("%d of %d new replicas have been updated", status.UpdatedReplicas, replicas)}
+	case status.Replicas > status.UpdatedReplicas:
+		return Progress{Message: fmt.Sprintf("%d old replicas are pending termination", status.Replicas-status.UpdatedReplicas)}
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return Progress{Message: fmt.Sprintf("%d of %d updated replicas are available", status.AvailableReplicas, status.UpdatedReplicas)}
+	}
+
+	return Progress{Done: true, Message: "successfully rolled out"}
+}
+
+func daemonSetStatus(daemonSet *appsv1.DaemonSet) Progress {
+	if daemonSet.Spec.UpdateStrategy.Type != appsv1.RollingUpdateDaemonSetStrategyType {
+		return Progress{Done: true, Message: "uses the OnDelete update strategy; pods are updated when they are deleted"}
+	}
+
+	if daemonSet.Generation > daemonSet.Status.ObservedGeneration {
+		return Progress{Message: "waiting for the daemon set spec update to be observed"}
+	}
+
+	status := daemonSet.Status
+	switch {
+	case status.UpdatedNumberScheduled < status.DesiredNumberScheduled:
+		return Progress{Message: fmt.Sprintf("%d of %d new pods have been updated", status.UpdatedNumberScheduled, status.DesiredNumberScheduled)}
+	case status.NumberAvailable < status.DesiredNumberScheduled:
+		return Progress{Message: fmt.Sprintf("%d of %d updated pods are available", status.NumberAvailable, status.DesiredNumberScheduled)}
+	}
+
+	return Progress{Done: true, Message: "successfully rolled out"}
+}
+
+func statefulSetStatus(statefulSet *appsv1.StatefulSet) Progress {
+	if statefulSet.Spec.UpdateStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType {
+		return Progress{Done: true, Message: "uses the OnDelete update strategy; pods are updated when they are deleted"}
+	}
+
+	if statefulSet.Status.ObservedGeneration == 0 || statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		return Progress{Message: "waiting for the stateful set spec update to be observed"}
+	}
+
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+
+	status := statefulSet.Status
+	if status.ReadyReplicas < replicas {
+		return Progress{Message: fmt.Sprintf("%d of %d pods are ready", status.ReadyReplicas, replicas)}
+	}
+
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		partition := *rollingUpdate.Partition
+		if status.UpdatedReplicas < replicas-partition {
+			return Progress{Message: fmt.Sprintf("%d of %d new pods in the partition have been updated", status.UpdatedReplicas, replicas-partition)}
+		}
+		return Progress{Done: true, Message: fmt.Sprintf("partitioned rollout complete: %d new pods have been updated", status.UpdatedReplicas)}
+	}
+
+	if status.UpdateRevision != status.CurrentRevision {
+		return Progress{Message: fmt.Sprintf("%d of %d new pods have been updated", status.UpdatedReplicas, replicas)}
+	}
+
+	return Progress{Done: true, Message: "successfully rolled out"}
+}
+
+// Watch polls an object until its rollout completes or stalls, and calls
+// report each time the progress of an unfinished rollout changes. The final
+// progress is returned. A rollout which doesn't complete within timeout is
+// considered stalled.
+func Watch(ctx context.Context, client dynamic.ResourceInterface, name string, timeout time.Duration, report func(Progress)) (Progress, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var last Progress
+	err := wait.PollImmediateUntil(watchInterval, func() (bool, error) {
+		object, err := client.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		progress, err := Status(object)
+		if err != nil {
+			return false, err
+		}
+
+		if progress.Done || progress.Stalled {
+			last = progress
+			return true, nil
+		}
+
+		if progress != last {
+			last = progress
+			report(progress)
+		}
+
+		return false, nil
+	}, ctx.Done())
+
+	if err == wait.ErrWaitTimeout {
+		if ctx.Err() != context.DeadlineExceeded {
+			return last, ctx.Err()
+		}
+
+		last.Stalled = true
+		last.Message = fmt.Sprintf("not complete after %s: %s", timeout, last.Message)
+		return last, nil
+	}
+
+	return last, err
+}
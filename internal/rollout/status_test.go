@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package rollout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func toUnstructured(t *testing.T, object runtime.Object) *unstructured.Unstructured {
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	require.NoError(t, err)
+	return &unstructured.Unstructured{Object: m}
+}
+
+func TestStatus_deployment(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   appsv1.DeploymentStatus
+		expected Progress
+	}{
+		{
+			name:     "spec update not observed",
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 1},
+			expected: Progress{Message: "waiting for the deployment spec update to be observed"},
+		},
+		{
+			name: "progress deadline exceeded",
+			status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Reason: progressDeadlineExceeded},
+				},
+			},
+			expected: Progress{Stalled: true, Message: "exceeded its progress deadline"},
+		},
+		{
+			name:     "updating",
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1},
+			expected: Progress{Message: "1 of 3 new replicas have been updated"},
+		},
+		{
+			name:     "old replicas terminating",
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 3},
+			expected: Progress{Message: "1 old replicas are pending termination"},
+		},
+		{
+			name:     "waiting for availability",
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2},
+			expected: Progress{Message: "2 of 3 updated replicas are available"},
+		},
+		{
+			name:     "done",
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3},
+			expected: Progress{Done: true, Message: "successfully rolled out"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(3)},
+				Status:     test.status,
+			}
+
+			got, err := Status(toUnstructured(t, deployment))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestStatus_daemonSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy appsv1.DaemonSetUpdateStrategyType
+		status   appsv1.DaemonSetStatus
+		expected Progress
+	}{
+		{
+			name:     "on delete",
+			strategy: appsv1.OnDeleteDaemonSetStrategyType,
+			expected: Progress{Done: true, Message: "uses the OnDelete update strategy; pods are updated when they are deleted"},
+		},
+		{
+			name:     "updating",
+			strategy: appsv1.RollingUpdateDaemonSetStrategyType,
+			status:   appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 1},
+			expected: Progress{Message: "1 of 3 new pods have been updated"},
+		},
+		{
+			name:     "done",
+			strategy: appsv1.RollingUpdateDaemonSetStrategyType,
+			status:   appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3},
+			expected: Progress{Done: true, Message: "successfully rolled out"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			daemonSet := &appsv1.DaemonSet{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Spec: appsv1.DaemonSetSpec{
+					UpdateStrategy: appsv1.DaemonSetUpdateStrategy{Type: test.strategy},
+				},
+				Status: test.status,
+			}
+
+			got, err := Status(toUnstructured(t, daemonSet))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestStatus_statefulSet(t *testing.T) {
+	partition := int32(1)
+
+	tests := []struct {
+		name          string
+		rollingUpdate *appsv1.RollingUpdateStatefulSetStrategy
+		status        appsv1.StatefulSetStatus
+		expected      Progress
+	}{
+		{
+			name:     "pods not ready",
+			status:   appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 2},
+			expected: Progress{Message: "2 of 3 pods are ready"},
+		},
+		{
+			name:          "partitioned",
+			rollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+			status:        appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, UpdatedReplicas: 2},
+			expected:      Progress{Done: true, Message: "partitioned rollout complete: 2 new pods have been updated"},
+		},
+		{
+			name:     "updating",
+			status:   appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "a", UpdateRevision: "b"},
+			expected: Progress{Message: "1 of 3 new pods have been updated"},
+		},
+		{
+			name:     "done",
+			status:   appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, UpdatedReplicas: 3, CurrentRevision: "b", UpdateRevision: "b"},
+			expected: Progress{Done: true, Message: "successfully rolled out"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statefulSet := &appsv1.StatefulSet{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: int32Ptr(3),
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: test.rollingUpdate,
+					},
+				},
+				Status: test.status,
+			}
+
+			got, err := Status(toUnstructured(t, statefulSet))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestStatus_unsupported(t *testing.T) {
+	_, err := Status(&unstructured.Unstructured{Object: map[string]interface{}{"kind": "ReplicaSet"}})
+	require.Error(t, err)
+}
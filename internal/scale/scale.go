@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package scale
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	kretry "k8s.io/client-go/util/retry"
+
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+const (
+	// Subresource is the name of the scale subresource.
+	Subresource = "scale"
+)
+
+// HasSubresource returns true if a resource has the scale subresource.
+func HasSubresource(discoveryClient discovery.DiscoveryInterface, gvr schema.GroupVersionResource) (bool, error) {
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		return false, errors.Wrapf(err, "discover resources for %s", gvr.GroupVersion())
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == gvr.Resource+"/"+Subresource {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Update sets the replicas of an object through its scale subresource.
+func Update(client dynamic.ResourceInterface, name string, replicas int32) error {
+	if replicas < 0 {
+		return errors.Errorf("replicas can't be negative: %d", replicas)
+	}
+
+	return kretry.RetryOnConflict(kretry.DefaultRetry, func() error {
+		s, err := client.Get(name, metav1.GetOptions{}, Subresource)
+		if err != nil {
+			return err
+		}
+
+		if err := unstructured.SetNestedField(s.Object, int64(replicas), "spec", "replicas"); err != nil {
+			return err
+		}
+
+		_, err = client.Update(s, metav1.UpdateOptions{}, Subresource)
+		return err
+	})
+}
+
+// Autoscalers returns the horizontal pod autoscalers which scale an object.
+func Autoscalers(ctx context.Context, objectStore store.Store, key store.Key) ([]autoscalingv1.HorizontalPodAutoscaler, error) {
+	hpaKey := store.Key{
+		Namespace:  key.Namespace,
+		ApiVersion: "autoscaling/v1",
+		Kind:       "HorizontalPodAutoscaler",
+	}
+
+	list, _, err := objectStore.List(ctx, hpaKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "list horizontal pod autoscalers")
+	}
+
+	group := key.GroupVersionKind().Group
+
+	var autoscalers []autoscalingv1.HorizontalPodAutoscaler
+	for i := range list.Items {
+		var hpa autoscalingv1.HorizontalPodAutoscaler
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &hpa); err != nil {
+			return nil, errors.Wrap(err, "convert horizontal pod autoscaler")
+		}
+
+		ref := hpa.Spec.ScaleTargetRef
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			continue
+		}
+
+		if ref.Kind == key.Kind && ref.Name == key.Name && gv.Group == group {
+			autoscalers = append(autoscalers, hpa)
+		}
+	}
+
+	return autoscalers, nil
+}
+
+// AutoscalerWarning describes the autoscalers which will override replica
+// changes. It is blank if there are no autoscalers.
+func AutoscalerWarning(autoscalers []autoscalingv1.HorizontalPodAutoscaler) string {
+	if len(autoscalers) == 0 {
+		return ""
+	}
+
+	var descriptions []string
+	for _, hpa := range autoscalers {
+		minReplicas := int32(1)
+		if hpa.Spec.MinReplicas != nil {
+			minReplicas = *hpa.Spec.MinReplicas
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%q (%d to %d replicas)",
+			hpa.Name, minReplicas, hpa.Spec.MaxReplicas))
+	}
+
+	return fmt.Sprintf("Replicas are managed by HorizontalPodAutoscaler %s, which will override manual changes",
+		strings.Join(descriptions, ", "))
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package scale
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+func TestHasSubresource(t *testing.T) {
+	client := kubefake.NewSimpleClientset()
+	discoveryClient := client.Discovery().(*fakediscovery.FakeDiscovery)
+	discoveryClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments"},
+				{Name: "deployments/scale"},
+				{Name: "daemonsets"},
+			},
+		},
+	}
+
+	ok, err := HasSubresource(discoveryClient, schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = HasSubresource(discoveryClient, schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"})
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+// listStore is a store which only lists objects.
+type listStore struct {
+	store.Store
+	objects []runtime.Object
+}
+
+func (s *listStore) List(ctx context.Context, key store.Key) (*unstructured.UnstructuredList, bool, error) {
+	list := &unstructured.UnstructuredList{}
+	for _, object := range s.objects {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err != nil {
+			return nil, false, err
+		}
+		list.Items = append(list.Items, unstructured.Unstructured{Object: m})
+	}
+	return list, false, nil
+}
+
+func autoscaler(name, apiVersion, kind, target string) *autoscalingv1.HorizontalPodAutoscaler {
+	minReplicas := int32(2)
+	return &autoscalingv1.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+				APIVersion: apiVersion,
+				Kind:       kind,
+				Name:       target,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: 10,
+		},
+	}
+}
+
+func TestAutoscalers(t *testing.T) {
+	objectStore := &listStore{
+		objects: []runtime.Object{
+			autoscaler("web", "apps/v1", "Deployment", "web"),
+			autoscaler("web-old-version", "extensions/v1beta1", "Deployment", "web"),
+			autoscaler("api", "apps/v1", "Deployment", "api"),
+			autoscaler("web-statefulset", "apps/v1", "StatefulSet", "web"),
+			autoscaler("web-other-group", "example.com/v1", "Deployment", "web"),
+		},
+	}
+
+	key := store.Key{Namespace: "default", ApiVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+	got, err := Autoscalers(context.Background(), objectStore, key)
+	require.NoError(t, err)
+
+	var names []string
+	for _, hpa := range got {
+		names = append(names, hpa.Name)
+	}
+	assert.Equal(t, []string{"web"}, names)
+}
+
+func TestAutoscalerWarning(t *testing.T) {
+	assert.Empty(t, AutoscalerWarning(nil))
+
+	noMin := autoscaler("api", "apps/v1", "Deployment", "api")
+	noMin.Spec.MinReplicas = nil
+
+	got := AutoscalerWarning([]autoscalingv1.HorizontalPodAutoscaler{
+		*autoscaler("web", "apps/v1", "Deployment", "web"),
+		*noMin,
+	})
+
+	expected := `Replicas are managed by HorizontalPodAutoscaler "web" (2 to 10 replicas), "api" (1 to 10 replicas), which will override manual changes`
+	assert.Equal(t, expected, got)
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	scoreUID           = 100
+	scoreNameExact     = 90
+	scoreNamePrefix    = 70
+	scoreNameSubstring = 50
+	scoreLabel         = 40
+	scoreAnnotation    = 30
+	scoreImage         = 30
+	scoreFilter        = 0
+)
+
+// containerPaths are the locations of container lists in the workload kinds
+// that are searched by image.
+var containerPaths = [][]string{
+	{"spec", "containers"},
+	{"spec", "initContainers"},
+	{"spec", "template", "spec", "containers"},
+	{"spec", "template", "spec", "initContainers"},
+	{"spec", "jobTemplate", "spec", "template", "spec", "containers"},
+	{"spec", "jobTemplate", "spec", "template", "spec", "initContainers"},
+}
+
+// Match matches an object against a query. It returns the object's score
+// and a description of each term that matched. An object matches if all
+// terms match.
+func Match(object *unstructured.Unstructured, query Query) (int, []string, bool) {
+	if object == nil || query.IsEmpty() {
+		return 0, nil, false
+	}
+
+	total := 0
+	var reasons []string
+
+	for _, term := range query.Terms {
+		score, reason, ok := matchTerm(object, term)
+		if !ok {
+			return 0, nil, false
+		}
+
+		total += score
+		if reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+
+	return total, reasons, true
+}
+
+func matchTerm(object *unstructured.Unstructured, term Term) (int, string, bool) {
+	switch term.Field {
+	case FieldName:
+		return matchName(object.GetName(), term.Value)
+	case FieldLabel:
+		return matchMap(object.GetLabels(), term, scoreLabel)
+	case FieldAnnotation:
+		return matchMap(object.GetAnnotations(), term, scoreAnnotation)
+	case FieldUID:
+		if string(object.GetUID()) == term.Value {
+			return scoreUID, "uid", true
+		}
+	case FieldImage:
+		for _, image := range objectImages(object) {
+			if strings.Contains(strings.ToLower(image), strings.ToLower(term.Value)) {
+				return scoreImage, fmt.Sprintf("image %s", image), true
+			}
+		}
+	case FieldKind:
+		if strings.EqualFold(object.GetKind(), term.Value) {
+			return scoreFilter, "", true
+		}
+	case FieldNamespace:
+		if object.GetNamespace() == term.Value {
+			return scoreFilter, "", true
+		}
+	}
+
+	return 0, "", false
+}
+
+func matchName(name, value string) (int, string, bool) {
+	name = strings.ToLower(name)
+	value = strings.ToLower(value)
+
+	switch {
+	case name == value:
+		return scoreNameExact, "name", true
+	case strings.HasPrefix(name, value):
+		return scoreNamePrefix, "name", true
+	case strings.Contains(name, value):
+		return scoreNameSubstring, "name", true
+	default:
+		return 0, "", false
+	}
+}
+
+func matchMap(m map[string]string, term Term, score int) (int, string, bool) {
+	v, ok := m[term.Key]
+	if !ok {
+		return 0, "", false
+	}
+
+	if term.Value != "" && v != term.Value {
+		return 0, "", false
+	}
+
+	return score, fmt.Sprintf("%s %s=%s", term.Field, term.Key, v), true
+}
+
+// objectImages returns the images of all containers defined by an object.
+func objectImages(object *unstructured.Unstructured) []string {
+	var images []string
+
+	for _, path := range containerPaths {
+		containers, found, err := unstructured.NestedSlice(object.Object, path...)
+		if err != nil || !found {
+			continue
+		}
+
+		for _, container := range containers {
+			m, ok := container.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if image, ok := m["image"].(string); ok && image != "" {
+				images = append(images, image)
+			}
+		}
+	}
+
+	return images
+}
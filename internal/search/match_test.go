@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseQuery(t *testing.T) {
+	query := ParseQuery("nginx label:app=web ann:team ns:default bogus:x")
+
+	expected := []Term{
+		{Field: FieldName, Value: "nginx"},
+		{Field: FieldLabel, Key: "app", Value: "web"},
+		{Field: FieldAnnotation, Key: "team"},
+		{Field: FieldNamespace, Value: "default"},
+		{Field: FieldName, Value: "bogus:x"},
+	}
+	assert.Equal(t, expected, query.Terms)
+	assert.Equal(t, "default", query.Namespace())
+}
+
+func TestMatch(t *testing.T) {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "nginx-frontend",
+			"namespace": "default",
+			"uid":       "1234",
+			"labels":    map[string]interface{}{"app": "web"},
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "nginx", "image": "nginx:1.17"},
+					},
+				},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name     string
+		query    string
+		score    int
+		expected bool
+	}{
+		{name: "exact name", query: "nginx-frontend", score: scoreNameExact, expected: true},
+		{name: "name prefix", query: "nginx", score: scoreNamePrefix, expected: true},
+		{name: "name substring", query: "front", score: scoreNameSubstring, expected: true},
+		{name: "label", query: "label:app=web", score: scoreLabel, expected: true},
+		{name: "label mismatch", query: "label:app=db", expected: false},
+		{name: "uid", query: "uid:1234", score: scoreUID, expected: true},
+		{name: "image", query: "image:nginx:1.17", score: scoreImage, expected: true},
+		{name: "kind and name", query: "kind:deployment front", score: scoreNameSubstring, expected: true},
+		{name: "all terms must match", query: "front ns:kube-system", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			score, _, ok := Match(object, ParseQuery(test.query))
+			require.Equal(t, test.expected, ok)
+			assert.Equal(t, test.score, score)
+		})
+	}
+}
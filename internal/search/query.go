@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package search
+
+import (
+	"strings"
+)
+
+// Field is the part of an object a search term is matched against.
+type Field string
+
+const (
+	// FieldName matches object names by substring.
+	FieldName Field = "name"
+	// FieldLabel matches label keys and values.
+	FieldLabel Field = "label"
+	// FieldAnnotation matches annotation keys and values.
+	FieldAnnotation Field = "annotation"
+	// FieldUID matches object UIDs.
+	FieldUID Field = "uid"
+	// FieldImage matches container images by substring.
+	FieldImage Field = "image"
+	// FieldKind matches object kinds.
+	FieldKind Field = "kind"
+	// FieldNamespace matches object namespaces.
+	FieldNamespace Field = "namespace"
+)
+
+var fieldAliases = map[string]Field{
+	"name":       FieldName,
+	"label":      FieldLabel,
+	"annotation": FieldAnnotation,
+	"ann":        FieldAnnotation,
+	"uid":        FieldUID,
+	"image":      FieldImage,
+	"img":        FieldImage,
+	"kind":       FieldKind,
+	"namespace":  FieldNamespace,
+	"ns":         FieldNamespace,
+}
+
+// Term is a single search term. Key is only used for label and annotation terms.
+type Term struct {
+	Field Field
+	Key   string
+	Value string
+}
+
+// Query is a parsed search query. All terms must match for an object to be returned.
+type Query struct {
+	Terms []Term
+}
+
+// ParseQuery parses a search string. Terms are separated by whitespace and take the
+// form `field:value`; terms without a known field prefix search object names.
+// Label and annotation terms may be `label:key` or `label:key=value`.
+func ParseQuery(s string) Query {
+	var query Query
+
+	for _, part := range strings.Fields(s) {
+		term := Term{Field: FieldName, Value: part}
+
+		if i := strings.Index(part, ":"); i > 0 {
+			if field, ok := fieldAliases[strings.ToLower(part[:i])]; ok {
+				term.Field = field
+				term.Value = part[i+1:]
+			}
+		}
+
+		if term.Field == FieldLabel || term.Field == FieldAnnotation {
+			kv := strings.SplitN(term.Value, "=", 2)
+			term.Key = kv[0]
+			term.Value = ""
+			if len(kv) == 2 {
+				term.Value = kv[1]
+			}
+		}
+
+		if term.Key == "" && term.Value == "" {
+			continue
+		}
+
+		query.Terms = append(query.Terms, term)
+	}
+
+	return query
+}
+
+// IsEmpty returns true if the query has no terms.
+func (q Query) IsEmpty() bool {
+	return len(q.Terms) == 0
+}
+
+// Namespace returns the namespace the query is restricted to, if any.
+func (q Query) Namespace() string {
+	for _, term := range q.Terms {
+		if term.Field == FieldNamespace {
+			return term.Value
+		}
+	}
+
+	return ""
+}
+
+// String converts the query back into its textual form.
+func (q Query) String() string {
+	var parts []string
+	for _, term := range q.Terms {
+		switch term.Field {
+		case FieldName:
+			parts = append(parts, term.Value)
+		case FieldLabel, FieldAnnotation:
+			s := string(term.Field) + ":" + term.Key
+			if term.Value != "" {
+				s += "=" + term.Value
+			}
+			parts = append(parts, s)
+		default:
+			parts = append(parts, string(term.Field)+":"+term.Value)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
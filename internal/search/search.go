@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package search
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kubenext/kubeon/internal/gvk"
+	"github.com/kubenext/kubeon/internal/log"
+	"github.com/kubenext/kubeon/pkg/store"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// DefaultLimit is the maximum number of results returned by a search.
+	DefaultLimit = 100
+)
+
+// DefaultFallbackKinds are searched even if they have not been cached yet.
+var DefaultFallbackKinds = []schema.GroupVersionKind{
+	gvk.Pod,
+	gvk.Deployment,
+	gvk.StatefulSet,
+	gvk.DaemonSet,
+	gvk.AppReplicaSet,
+	gvk.Job,
+	gvk.CronJob,
+	gvk.Service,
+	gvk.Ingress,
+	gvk.ConfigMap,
+	gvk.Secret,
+	gvk.PersistentVolumeClaim,
+	gvk.ServiceAccount,
+	gvk.Node,
+}
+
+// Source is a source of objects to search. objectstore.DynamicCache is a Source;
+// use SourceFor to search other stores.
+type Source interface {
+	List(ctx context.Context, key store.Key) (*unstructured.UnstructuredList, bool, error)
+	SyncedObjects(ctx context.Context) (map[schema.GroupVersionResource][]*unstructured.Unstructured, error)
+	HasAccess(ctx context.Context, key store.Key, verb string) error
+}
+
+// ResourceMapper maps a group kind to the resource which serves it.
+// cluster.ClientInterface is a ResourceMapper.
+type ResourceMapper interface {
+	Resource(gk schema.GroupKind) (schema.GroupVersionResource, error)
+}
+
+// Result is an object matching a search.
+type Result struct {
+	Object  *unstructured.Unstructured
+	Score   int
+	Reasons []string
+}
+
+// Option is an option for configuring Searcher.
+type Option func(s *Searcher)
+
+// WithLimit sets the maximum number of results.
+func WithLimit(limit int) Option {
+	return func(s *Searcher) {
+		s.limit = limit
+	}
+}
+
+// WithFallbackKinds sets the kinds which are listed if they are not cached.
+func WithFallbackKinds(kinds ...schema.GroupVersionKind) Option {
+	return func(s *Searcher) {
+		s.fallbackKinds = kinds
+	}
+}
+
+// Searcher searches objects in the object store.
+type Searcher struct {
+	source        Source
+	mapper        ResourceMapper
+	fallbackKinds []schema.GroupVersionKind
+	limit         int
+}
+
+// NewSearcher creates an instance of Searcher.
+func NewSearcher(source Source, mapper ResourceMapper, options ...Option) *Searcher {
+	s := &Searcher{
+		source:        source,
+		mapper:        mapper,
+		fallbackKinds: DefaultFallbackKinds,
+		limit:         DefaultLimit,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// Search returns objects matching query ordered by score. Objects held by synced
+// informers are searched first. Fallback kinds which are not cached are listed
+// if the user is allowed to list them; doing so also warms the cache.
+func (s *Searcher) Search(ctx context.Context, query Query) ([]Result, error) {
+	ctx, span := trace.StartSpan(ctx, "search")
+	defer span.End()
+
+	if query.IsEmpty() {
+		return []Result{}, nil
+	}
+
+	if s.source == nil {
+		return nil, errors.New("search source is nil")
+	}
+
+	synced, err := s.source.SyncedObjects(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieve synced objects")
+	}
+
+	var results []Result
+	seen := make(map[types.UID]bool)
+
+	add := func(object *unstructured.Unstructured) {
+		if seen[object.GetUID()] {
+			return
+		}
+		seen[object.GetUID()] = true
+
+		if score, reasons, ok := Match(object, query); ok {
+			results = append(results, Result{
+				Object:  object,
+				Score:   score,
+				Reasons: reasons,
+			})
+		}
+	}
+
+	for _, objects := range synced {
+		for _, object := range objects {
+			add(object)
+		}
+	}
+
+	for _, object := range s.fallbackObjects(ctx, query, synced) {
+		add(object)
+	}
+
+	sortResults(results)
+
+	if s.limit > 0 && len(results) > s.limit {
+		results = results[:s.limit]
+	}
+
+	return results, nil
+}
+
+func (s *Searcher) fallbackObjects(ctx context.Context, query Query, synced map[schema.GroupVersionResource][]*unstructured.Unstructured) []*unstructured.Unstructured {
+	if s.mapper == nil {
+		return nil
+	}
+
+	logger := log.From(ctx)
+
+	var objects []*unstructured.Unstructured
+
+	for _, groupVersionKind := range s.fallbackKinds {
+		gvr, err := s.mapper.Resource(groupVersionKind.GroupKind())
+		if err != nil {
+			continue
+		}
+
+		if _, ok := synced[gvr]; ok {
+			continue
+		}
+
+		apiVersion, kind := groupVersionKind.ToAPIVersionAndKind()
+		key := store.Key{
+			Namespace:  query.Namespace(),
+			ApiVersion: apiVersion,
+			Kind:       kind,
+		}
+
+		if err := s.source.HasAccess(ctx, key, "list"); err != nil {
+			continue
+		}
+
+		list, _, err := s.source.List(ctx, key)
+		if err != nil {
+			logger.WithErr(err).Errorf("search list %s", groupVersionKind)
+			continue
+		}
+
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	}
+
+	return objects
+}
+
+func sortResults(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Object.GetKind() != b.Object.GetKind() {
+			return a.Object.GetKind() < b.Object.GetKind()
+		}
+		if a.Object.GetNamespace() != b.Object.GetNamespace() {
+			return a.Object.GetNamespace() < b.Object.GetNamespace()
+		}
+		return a.Object.GetName() < b.Object.GetName()
+	})
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package search
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubenext/kubeon/pkg/store"
+)
+
+type accessChecker interface {
+	HasAccess(ctx context.Context, key store.Key, verb string) error
+}
+
+// SourceFor returns the Source for an object store. Stores which implement
+// Source are searched directly. Other stores can't report the objects they
+// have synced, so only the fallback kinds are searched, by listing them from
+// the store.
+func SourceFor(objectStore store.Store) Source {
+	if source, ok := objectStore.(Source); ok {
+		return source
+	}
+
+	return &storeSource{objectStore: objectStore}
+}
+
+// storeSource is a Source for stores which don't implement it.
+type storeSource struct {
+	objectStore store.Store
+}
+
+var _ Source = (*storeSource)(nil)
+
+func (s *storeSource) List(ctx context.Context, key store.Key) (*unstructured.UnstructuredList, bool, error) {
+	return s.objectStore.List(ctx, key)
+}
+
+// SyncedObjects returns no objects, so every fallback kind is listed.
+func (s *storeSource) SyncedObjects(ctx context.Context) (map[schema.GroupVersionResource][]*unstructured.Unstructured, error) {
+	return map[schema.GroupVersionResource][]*unstructured.Unstructured{}, nil
+}
+
+// HasAccess checks access with the store if it can. Otherwise it allows the
+// list, and the store rejects it if the user isn't allowed to list key.
+func (s *storeSource) HasAccess(ctx context.Context, key store.Key, verb string) error {
+	if checker, ok := s.objectStore.(accessChecker); ok {
+		return checker.HasAccess(ctx, key, verb)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package search
+
+import (
+	"strings"
+
+	"github.com/kubenext/kubeon/internal/link"
+	"github.com/kubenext/kubeon/pkg/view/component"
+)
+
+var resultsColumns = component.NewTableCols("Name", "Kind", "Namespace", "Matched", "Age")
+
+// ResultsTable creates a table for search results. Object names link to the
+// object's content path; objects without a known path are shown as text.
+func ResultsTable(title string, results []Result, linkGenerator link.Interface) *component.Table {
+	table := component.NewTable(title, "No objects matched the search", resultsColumns)
+
+	for _, result := range results {
+		object := result.Object
+
+		var name component.Component = component.NewText(object.GetName())
+		if linkGenerator != nil {
+			if l, err := linkGenerator.ForObject(object, object.GetName()); err == nil && l.Ref() != "" {
+				name = l
+			}
+		}
+
+		table.Add(component.TableRow{
+			"Name":      name,
+			"Kind":      component.NewText(object.GetKind()),
+			"Namespace": component.NewText(object.GetNamespace()),
+			"Matched":   component.NewText(strings.Join(result.Reasons, ", ")),
+			"Age":       component.NewTimestamp(object.GetCreationTimestamp().Time),
+		})
+	}
+
+	return table
+}
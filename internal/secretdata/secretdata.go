@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package secretdata reveals and edits the values of secret keys.
+package secretdata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// OperationSet sets the value of a secret key.
+	OperationSet = "set"
+	// OperationDelete deletes secret keys.
+	OperationDelete = "delete"
+
+	// FormatText is the format of values which are printable text.
+	FormatText = "text"
+	// FormatJSON is the format of values which are a JSON object or array.
+	FormatJSON = "json"
+	// FormatPEM is the format of values with PEM blocks, like certificates and keys.
+	FormatPEM = "pem"
+	// FormatBinary is the format of values which aren't text.
+	FormatBinary = "binary"
+
+	// EncodingText is the encoding of values entered as plain text.
+	EncodingText = "text"
+	// EncodingBase64 is the encoding of values entered as base64, which is
+	// used for binary values.
+	EncodingBase64 = "base64"
+)
+
+// Detect detects the format of a value.
+func Detect(value []byte) string {
+	if !isText(value) {
+		return FormatBinary
+	}
+
+	if block, _ := pem.Decode(value); block != nil {
+		return FormatPEM
+	}
+
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+		return FormatJSON
+	}
+
+	return FormatText
+}
+
+func isText(value []byte) bool {
+	if !utf8.Valid(value) {
+		return false
+	}
+
+	for _, r := range string(value) {
+		if unicode.IsControl(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Revealed is a decoded value. Text is only set if the value isn't binary;
+// Base64 is always set so any value can be downloaded as it is.
+type Revealed struct {
+	Format string
+	Size   int
+	Text   string
+	Base64 string
+}
+
+// Reveal decodes a value for display.
+func Reveal(value []byte) Revealed {
+	revealed := Revealed{
+		Format: Detect(value),
+		Size:   len(value),
+		Base64: base64.StdEncoding.EncodeToString(value),
+	}
+
+	if revealed.Format != FormatBinary {
+		revealed.Text = string(value)
+	}
+
+	return revealed
+}
+
+// Decode decodes a value entered with an encoding. Whitespace in base64
+// values is ignored so wrapped values can be pasted.
+func Decode(value, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", EncodingText:
+		return []byte(value), nil
+	case EncodingBase64:
+		cleaned := strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, value)
+
+		data, err := base64.StdEncoding.DecodeString(cleaned)
+		if err != nil {
+			return nil, errors.Wrap(err, "value is not valid base64")
+		}
+		return data, nil
+	default:
+		return nil, errors.Errorf("unknown encoding %q", encoding)
+	}
+}
+
+// ValidateKey validates a secret key.
+func ValidateKey(key string) error {
+	if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+		return errors.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Size returns the total size of secret data.
+func Size(data map[string][]byte) int {
+	size := 0
+	for key, value := range data {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+// Edit returns the data of a secret after setting and removing keys. It is
+// rejected if the secret would be larger than secrets can be.
+func Edit(current map[string][]byte, set map[string][]byte, remove []string) (map[string][]byte, error) {
+	data := make(map[string][]byte)
+	for key, value := range current {
+		data[key] = value
+	}
+
+	for _, key := range remove {
+		if _, ok := data[key]; !ok {
+			return nil, errors.Errorf("key %q does not exist", key)
+		}
+		delete(data, key)
+	}
+
+	for key, value := range set {
+		if err := ValidateKey(key); err != nil {
+			return nil, err
+		}
+		data[key] = value
+	}
+
+	if size := Size(data); size > corev1.MaxSecretSize {
+		return nil, errors.Errorf("secret would be %d bytes, more than the limit of %d", size, corev1.MaxSecretSize)
+	}
+
+	return data, nil
+}
+
+// Patch creates a merge patch which sets and removes keys. The patch only
+// applies to the resource version the edit was made at.
+func Patch(resourceVersion string, set map[string][]byte, remove []string) ([]byte, error) {
+	data := map[string]interface{}{}
+	for key, value := range set {
+		// byte slices are encoded as base64
+		data[key] = value
+	}
+	for _, key := range remove {
+		data[key] = nil
+	}
+
+	patch := map[string]interface{}{
+		"data": data,
+	}
+	if resourceVersion != "" {
+		patch["metadata"] = map[string]interface{}{
+			"resourceVersion": resourceVersion,
+		}
+	}
+
+	encoded, err := json.Marshal(patch)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal patch")
+	}
+
+	return encoded, nil
+}
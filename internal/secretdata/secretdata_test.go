@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package secretdata
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const certificate = `-----BEGIN CERTIFICATE-----
+MIIBszCCAVmgAwIBAgIUe0LdXWQkCj0zvEMwDQYJKoZIhvcNAQELBQAwEjEQMA4G
+-----END CERTIFICATE-----
+`
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    []byte
+		expected string
+	}{
+		{name: "text", value: []byte("hunter2"), expected: FormatText},
+		{name: "multiline text", value: []byte("user=admin\npassword=hunter2\n"), expected: FormatText},
+		{name: "empty", value: []byte{}, expected: FormatText},
+		{name: "json object", value: []byte(` {"user":"admin"}` + "\n"), expected: FormatJSON},
+		{name: "json array", value: []byte(`["a","b"]`), expected: FormatJSON},
+		{name: "invalid json", value: []byte(`{"user":`), expected: FormatText},
+		{name: "json scalar", value: []byte(`"quoted"`), expected: FormatText},
+		{name: "pem", value: []byte(certificate), expected: FormatPEM},
+		{name: "binary", value: []byte{0x00, 0x01, 0xff, 0xfe}, expected: FormatBinary},
+		{name: "control characters", value: []byte("text\x00more"), expected: FormatBinary},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Detect(test.value))
+		})
+	}
+}
+
+func TestReveal(t *testing.T) {
+	revealed := Reveal([]byte("hunter2"))
+	assert.Equal(t, Revealed{Format: FormatText, Size: 7, Text: "hunter2", Base64: "aHVudGVyMg=="}, revealed)
+
+	revealed = Reveal([]byte{0x00, 0xff})
+	assert.Equal(t, Revealed{Format: FormatBinary, Size: 2, Base64: "AP8="}, revealed)
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		encoding string
+		expected []byte
+		isErr    bool
+	}{
+		{name: "text", value: "hunter2", encoding: EncodingText, expected: []byte("hunter2")},
+		{name: "default encoding is text", value: " spaced ", expected: []byte(" spaced ")},
+		{name: "base64", value: "aHVudGVyMg==", encoding: EncodingBase64, expected: []byte("hunter2")},
+		{name: "wrapped base64", value: "aHVu\ndGVy\r\n  Mg==\n", encoding: EncodingBase64, expected: []byte("hunter2")},
+		{name: "invalid base64", value: "not base64!", encoding: EncodingBase64, isErr: true},
+		{name: "unknown encoding", value: "hunter2", encoding: "hex", isErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Decode(test.value, test.encoding)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestEdit(t *testing.T) {
+	current := map[string][]byte{
+		"user":     []byte("admin"),
+		"password": []byte("hunter2"),
+	}
+
+	tests := []struct {
+		name     string
+		set      map[string][]byte
+		remove   []string
+		expected map[string][]byte
+		isErr    bool
+	}{
+		{
+			name:   "set and remove",
+			set:    map[string][]byte{"password": []byte("correct horse"), "token": []byte("abc")},
+			remove: []string{"user"},
+			expected: map[string][]byte{
+				"password": []byte("correct horse"),
+				"token":    []byte("abc"),
+			},
+		},
+		{
+			name:   "missing key",
+			remove: []string{"missing"},
+			isErr:  true,
+		},
+		{
+			name:  "invalid key",
+			set:   map[string][]byte{"not/valid": []byte("x")},
+			isErr: true,
+		},
+		{
+			name:  "size limit",
+			set:   map[string][]byte{"big": bytes.Repeat([]byte("x"), corev1.MaxSecretSize)},
+			isErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Edit(current, test.set, test.remove)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, got)
+
+			// the current data isn't changed
+			assert.Len(t, current, 2)
+		})
+	}
+}
+
+func TestPatch(t *testing.T) {
+	got, err := Patch("42", map[string][]byte{"password": []byte("hunter2")}, []string{"user"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"data": {"password": "aHVudGVyMg==", "user": null},
+		"metadata": {"resourceVersion": "42"}
+	}`, string(got))
+
+	got, err = Patch("", nil, []string{"user"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data": {"user": null}}`, string(got))
+}
@@ -0,0 +1,260 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package terminal
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/kubenext/kubeon/internal/log"
+)
+
+const (
+	// maxScrollback is the amount of output kept so clients can attach to
+	// a running terminal.
+	maxScrollback = 64 * 1024
+
+	// subscriberBuffer is the number of writes buffered for a subscriber
+	// before it is disconnected.
+	subscriberBuffer = 256
+)
+
+// ErrSlowSubscriber is the reason a subscriber is disconnected when it
+// doesn't keep up with a terminal's output.
+var ErrSlowSubscriber = errors.New("terminal output was not read fast enough; attach again to continue")
+
+// Instance is a running terminal session.
+type Instance interface {
+	ID() string
+	Namespace() string
+	PodName() string
+	Target() Target
+	Container() string
+	Command() []string
+	CreatedAt() time.Time
+
+	// Write sends input to the terminal.
+	Write(p []byte) (int, error)
+	// Resize resizes the terminal.
+	Resize(cols, rows uint16)
+	// Subscribe returns a channel which receives output, and a function to
+	// unsubscribe. The scrollback is sent first. The channel is closed when
+	// the terminal exits, or if the subscriber doesn't keep up with output;
+	// Done is closed first if the terminal exited.
+	Subscribe() (<-chan []byte, func())
+	// Stop stops the terminal.
+	Stop()
+	// Done is closed when the terminal exits.
+	Done() <-chan struct{}
+	// Err is the error which ended the terminal, if any.
+	Err() error
+}
+
+type instance struct {
+	id        string
+	namespace string
+	podName   string
+	target    Target
+	container string
+	command   []string
+	createdAt time.Time
+	logger    log.Logger
+
+	stdin    *io.PipeWriter
+	sizeCh   chan remotecommand.TerminalSize
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+
+	mu          sync.Mutex
+	scrollback  []byte
+	subscribers map[chan []byte]bool
+	exited      bool
+	err         error
+}
+
+var _ Instance = (*instance)(nil)
+var _ remotecommand.TerminalSizeQueue = (*instance)(nil)
+
+func newInstance(ctx context.Context, namespace, podName, container string, command []string, target Target, logger log.Logger) (*instance, context.Context, *io.PipeReader) {
+	ctx, cancel := context.WithCancel(ctx)
+	stdinReader, stdinWriter := io.Pipe()
+
+	i := &instance{
+		id:          uuid.New().String(),
+		namespace:   namespace,
+		podName:     podName,
+		target:      target,
+		container:   container,
+		command:     command,
+		createdAt:   time.Now(),
+		logger:      logger,
+		stdin:       stdinWriter,
+		sizeCh:      make(chan remotecommand.TerminalSize, 1),
+		cancel:      cancel,
+		done:        make(chan struct{}),
+		subscribers: make(map[chan []byte]bool),
+	}
+
+	return i, ctx, stdinReader
+}
+
+func (i *instance) ID() string {
+	return i.id
+}
+
+func (i *instance) Namespace() string {
+	return i.namespace
+}
+
+func (i *instance) PodName() string {
+	return i.podName
+}
+
+func (i *instance) Target() Target {
+	return i.target
+}
+
+func (i *instance) Container() string {
+	return i.container
+}
+
+func (i *instance) Command() []string {
+	return i.command
+}
+
+func (i *instance) CreatedAt() time.Time {
+	return i.createdAt
+}
+
+func (i *instance) Write(p []byte) (int, error) {
+	return i.stdin.Write(p)
+}
+
+func (i *instance) Resize(cols, rows uint16) {
+	size := remotecommand.TerminalSize{Width: cols, Height: rows}
+
+	// only the latest size matters
+	select {
+	case <-i.sizeCh:
+	default:
+	}
+
+	select {
+	case i.sizeCh <- size:
+	case <-i.done:
+	}
+}
+
+// Next implements remotecommand.TerminalSizeQueue.
+func (i *instance) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-i.sizeCh:
+		return &size
+	case <-i.done:
+		return nil
+	}
+}
+
+func (i *instance) Subscribe() (<-chan []byte, func()) {
+	ch := make(chan []byte, subscriberBuffer)
+
+	i.mu.Lock()
+	if len(i.scrollback) > 0 {
+		ch <- append([]byte(nil), i.scrollback...)
+	}
+	if i.exited {
+		// the terminal has exited, so only its scrollback is replayed
+		close(ch)
+		i.mu.Unlock()
+		return ch, func() {}
+	}
+	i.subscribers[ch] = true
+	i.mu.Unlock()
+
+	unsubscribe := func() {
+		i.mu.Lock()
+		defer i.mu.Unlock()
+		if i.subscribers[ch] {
+			delete(i.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+// output implements io.Writer for the terminal's stdout.
+type output struct {
+	*instance
+}
+
+func (o output) Write(p []byte) (int, error) {
+	i := o.instance
+	data := append([]byte(nil), p...)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.scrollback = append(i.scrollback, data...)
+	if len(i.scrollback) > maxScrollback {
+		i.scrollback = i.scrollback[len(i.scrollback)-maxScrollback:]
+	}
+
+	for ch := range i.subscribers {
+		select {
+		case ch <- data:
+		default:
+			// dropping output would corrupt the subscriber's screen, so it is
+			// disconnected instead. It can subscribe again to get the scrollback.
+			i.logger.Infof("disconnecting slow terminal subscriber")
+			delete(i.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return len(p), nil
+}
+
+func (i *instance) Stop() {
+	i.stopOnce.Do(func() {
+		// closing stdin ends the shell; cancelling stops the stream if it doesn't
+		_ = i.stdin.Close()
+		i.cancel()
+	})
+}
+
+func (i *instance) exit(err error) {
+	i.mu.Lock()
+	i.err = err
+	i.exited = true
+	// done is closed before subscribers so they can tell an exit from
+	// being disconnected
+	close(i.done)
+	for ch := range i.subscribers {
+		delete(i.subscribers, ch)
+		close(ch)
+	}
+	i.mu.Unlock()
+
+	i.Stop()
+}
+
+func (i *instance) Done() <-chan struct{} {
+	return i.done
+}
+
+func (i *instance) Err() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.err
+}
@@ -0,0 +1,258 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package terminal
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/kubenext/kubeon/internal/cluster"
+	"github.com/kubenext/kubeon/internal/log"
+)
+
+// DefaultCommand is the command run when no command is given. It prefers bash.
+var DefaultCommand = []string{"/bin/sh", "-c", "command -v bash >/dev/null 2>&1 && exec bash || exec sh"}
+
+// NewExecutor creates an executor which runs command in a pod's container.
+func NewExecutor(kubeClient kubernetes.Interface, restConfig *rest.Config, namespace, podName, container string, command []string, tty bool) (remotecommand.Executor, error) {
+	req := kubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    !tty,
+			TTY:       tty,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "create exec executor")
+	}
+
+	return executor, nil
+}
+
+// Target is the object a terminal is for. It is the terminal's pod unless the
+// terminal runs in a debug pod for another object.
+type Target struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// PodTarget returns the target for a pod.
+func PodTarget(namespace, name string) Target {
+	return Target{Kind: "Pod", Namespace: namespace, Name: name}
+}
+
+// CreateOption is an option for creating a terminal.
+type CreateOption func(o *createOptions)
+
+type createOptions struct {
+	target *Target
+	onExit func()
+}
+
+// WithTarget sets the object the terminal is for.
+func WithTarget(target Target) CreateOption {
+	return func(o *createOptions) {
+		o.target = &target
+	}
+}
+
+// WithOnExit sets a function which is called after the terminal exits. It
+// isn't called if the terminal can't be created.
+func WithOnExit(fn func()) CreateOption {
+	return func(o *createOptions) {
+		o.onExit = fn
+	}
+}
+
+// Manager manages terminals.
+type Manager interface {
+	// Create execs command in a pod's container and returns the terminal.
+	Create(ctx context.Context, namespace, podName, container string, command []string, options ...CreateOption) (Instance, error)
+	// Get returns a terminal by id.
+	Get(id string) (Instance, bool)
+	// List lists terminals for a target. All terminals are listed if the target's name is empty.
+	List(target Target) []Instance
+	// Delete stops and removes a terminal.
+	Delete(id string)
+	// StopAll stops all terminals.
+	StopAll()
+	// UpdateClusterClient stops all terminals and uses client for new terminals.
+	UpdateClusterClient(client cluster.ClientInterface)
+}
+
+type manager struct {
+	client cluster.ClientInterface
+	ctx    context.Context
+
+	mu        sync.Mutex
+	instances map[string]Instance
+}
+
+var _ Manager = (*manager)(nil)
+
+// NewTerminalManager creates an instance of Manager. Terminals are stopped when ctx is cancelled.
+func NewTerminalManager(ctx context.Context, client cluster.ClientInterface) (Manager, error) {
+	if client == nil {
+		return nil, errors.New("cluster client is nil")
+	}
+
+	return &manager{
+		client:    client,
+		ctx:       ctx,
+		instances: make(map[string]Instance),
+	}, nil
+}
+
+func (m *manager) Create(ctx context.Context, namespace, podName, container string, command []string, options ...CreateOption) (Instance, error) {
+	if len(command) == 0 {
+		command = DefaultCommand
+	}
+
+	opts := createOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	target := PodTarget(namespace, podName)
+	if opts.target != nil {
+		target = *opts.target
+	}
+
+	m.mu.Lock()
+	client := m.client
+	m.mu.Unlock()
+
+	kubeClient, err := client.KubernetesClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "create kubernetes client")
+	}
+
+	executor, err := NewExecutor(kubeClient, client.RestConfig(), namespace, podName, container, command, true)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := log.From(ctx).With("pod", podName, "container", container)
+
+	// terminals outlive the request which created them
+	inst, streamCtx, stdin := newInstance(m.ctx, namespace, podName, container, command, target, logger)
+
+	m.mu.Lock()
+	m.instances[inst.ID()] = inst
+	m.mu.Unlock()
+
+	go func() {
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- executor.Stream(remotecommand.StreamOptions{
+				Stdin:             stdin,
+				Stdout:            output{inst},
+				Tty:               true,
+				TerminalSizeQueue: inst,
+			})
+		}()
+
+		select {
+		case err := <-errCh:
+			if err != nil {
+				logger.WithErr(err).Errorf("terminal exited")
+			}
+			inst.exit(err)
+		case <-streamCtx.Done():
+			inst.exit(nil)
+		}
+
+		m.remove(inst.ID())
+
+		if opts.onExit != nil {
+			opts.onExit()
+		}
+	}()
+
+	return inst, nil
+}
+
+func (m *manager) Get(id string) (Instance, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inst, ok := m.instances[id]
+	return inst, ok
+}
+
+func (m *manager) List(target Target) []Instance {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var list []Instance
+	for _, inst := range m.instances {
+		if target.Name != "" && inst.Target() != target {
+			continue
+		}
+		list = append(list, inst)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt().Before(list[j].CreatedAt())
+	})
+
+	return list
+}
+
+// remove removes an exited terminal.
+func (m *manager) remove(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.instances, id)
+}
+
+func (m *manager) Delete(id string) {
+	m.mu.Lock()
+	inst, ok := m.instances[id]
+	delete(m.instances, id)
+	m.mu.Unlock()
+
+	if ok {
+		inst.Stop()
+	}
+}
+
+func (m *manager) StopAll() {
+	m.mu.Lock()
+	instances := m.instances
+	m.instances = make(map[string]Instance)
+	m.mu.Unlock()
+
+	for _, inst := range instances {
+		inst.Stop()
+	}
+}
+
+func (m *manager) UpdateClusterClient(client cluster.ClientInterface) {
+	m.StopAll()
+
+	m.mu.Lock()
+	m.client = client
+	m.mu.Unlock()
+}
@@ -71,6 +71,15 @@ func (p Payload) Uint16(key string) (uint16, error) {
 	return uint16(i), nil
 }
 
+// Returns a uint16 from the payload. If the value does not exist, it returns zero.
+func (p Payload) OptionalUint16(key string) (uint16, error) {
+	if _, ok := p[key]; !ok {
+		return 0, nil
+	}
+
+	return p.Uint16(key)
+}
+
 // Returns a string from the payload.
 func (p Payload) String(key string) (string, error) {
 	s, ok := p[key].(string)
@@ -110,6 +119,23 @@ func (p Payload) StringSlice(key string) ([]string, error) {
 	return list, nil
 }
 
+// OptionalStringSlice returns a string slice from the payload. A missing key
+// is an empty slice, and a single string is a slice with one item, as sent by
+// unchecked check boxes and single selects.
+func (p Payload) OptionalStringSlice(key string) ([]string, error) {
+	switch v := p[key].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		return []string{v}, nil
+	default:
+		return p.StringSlice(key)
+	}
+}
+
 // Returns a float64 from the payload.
 func (p Payload) Float64(key string) (float64, error) {
 	switch v := p[key].(type) {
@@ -136,3 +136,99 @@ func TestPayload_Uint16(t *testing.T) {
 		})
 	}
 }
+
+func TestPayload_OptionalUint16(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  Payload
+		key      string
+		isErr    bool
+		expected uint16
+	}{
+		{
+			name:     "source is int",
+			payload:  Payload{"uint16": float64(7)},
+			key:      "uint16",
+			expected: uint16(7),
+		},
+		{
+			name:     "key does not exist",
+			payload:  Payload{},
+			key:      "invalid",
+			expected: uint16(0),
+		},
+		{
+			name:    "value is not int",
+			payload: Payload{"uint16": true},
+			key:     "uint16",
+			isErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.payload.OptionalUint16(test.key)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestPayload_OptionalStringSlice(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  Payload
+		key      string
+		isErr    bool
+		expected []string
+	}{
+		{
+			name:     "source is slice",
+			payload:  Payload{"slice": []interface{}{"a", "b"}},
+			key:      "slice",
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "source is string",
+			payload:  Payload{"slice": "a"},
+			key:      "slice",
+			expected: []string{"a"},
+		},
+		{
+			name:     "source is empty string",
+			payload:  Payload{"slice": ""},
+			key:      "slice",
+			expected: nil,
+		},
+		{
+			name:     "key does not exist",
+			payload:  Payload{},
+			key:      "invalid",
+			expected: nil,
+		},
+		{
+			name:    "value is not a string",
+			payload: Payload{"slice": []interface{}{true}},
+			key:     "slice",
+			isErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.payload.OptionalStringSlice(test.key)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
@@ -147,6 +147,8 @@ func (c *Client) PortForward(ctx context.Context, req PortForwardRequest) (PortF
 		Namespace:  req.Namespace,
 		PodName:    req.PodName,
 		PortNumber: uint32(req.Port),
+		LocalPort:  uint32(req.LocalPort),
+		Address:    req.Address,
 	}
 	resp, err := client.PortForward(ctx, pfRequest)
 	if err != nil {
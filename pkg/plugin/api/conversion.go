@@ -113,9 +113,16 @@ func convertToPortForwardRequest(in *proto.PortForwardRequest) (*PortForwardRequ
 		return nil, errors.Errorf("port number must be a uint32; it was: %d", port)
 	}
 
+	localPort := in.LocalPort
+	if localPort > 0xFFFF {
+		return nil, errors.Errorf("local port number must be a uint16; it was: %d", localPort)
+	}
+
 	return &PortForwardRequest{
 		Namespace: in.Namespace,
 		PodName:   in.PodName,
 		Port:      uint16(port),
+		LocalPort: uint16(localPort),
+		Address:   in.Address,
 	}, nil
 }
@@ -280,6 +280,8 @@ type PortForwardRequest struct {
 	PodName              string   `protobuf:"bytes,2,opt,name=podName,proto3" json:"podName,omitempty"`
 	ContainerName        string   `protobuf:"bytes,3,opt,name=containerName,proto3" json:"containerName,omitempty"`
 	PortNumber           uint32   `protobuf:"varint,4,opt,name=portNumber,proto3" json:"portNumber,omitempty"`
+	LocalPort            uint32   `protobuf:"varint,5,opt,name=localPort,proto3" json:"localPort,omitempty"`
+	Address              string   `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -338,6 +340,20 @@ func (m *PortForwardRequest) GetPortNumber() uint32 {
 	return 0
 }
 
+func (m *PortForwardRequest) GetLocalPort() uint32 {
+	if m != nil {
+		return m.LocalPort
+	}
+	return 0
+}
+
+func (m *PortForwardRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
 type PortForwardResponse struct {
 	PortForwardID        string   `protobuf:"bytes,1,opt,name=portForwardID,proto3" json:"portForwardID,omitempty"`
 	PortNumber           uint32   `protobuf:"varint,2,opt,name=portNumber,proto3" json:"portNumber,omitempty"`
@@ -439,37 +455,38 @@ func init() {
 func init() { proto.RegisterFile("dashboard.proto", fileDescriptor_9b97678da3a35dfb) }
 
 var fileDescriptor_9b97678da3a35dfb = []byte{
-	// 476 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xdd, 0x6e, 0xd3, 0x30,
-	0x14, 0x56, 0xfa, 0xab, 0x9e, 0xb6, 0xc0, 0x5c, 0x40, 0x26, 0xa0, 0x51, 0x45, 0x43, 0xf4, 0x02,
-	0x65, 0x62, 0x88, 0x7b, 0x18, 0xa5, 0x13, 0x02, 0x4d, 0x28, 0x88, 0xdd, 0x70, 0xe5, 0x24, 0x87,
-	0x11, 0x48, 0x6d, 0x63, 0xbb, 0x9a, 0xfa, 0x1a, 0xbc, 0x0b, 0x8f, 0xc3, 0xbb, 0xa0, 0xd8, 0x8e,
-	0xda, 0xac, 0x9d, 0xb4, 0xab, 0xf8, 0x7c, 0xe7, 0x3b, 0x27, 0xe7, 0x7c, 0x9f, 0x0d, 0x77, 0x73,
-	0xa6, 0x7f, 0xa4, 0x82, 0xa9, 0x3c, 0x96, 0x4a, 0x18, 0x41, 0xba, 0xf6, 0x13, 0x1e, 0x5e, 0x0a,
-	0x71, 0x59, 0xe2, 0xb1, 0x8d, 0xd2, 0xd5, 0xf7, 0xe3, 0x2b, 0xc5, 0xa4, 0x44, 0xa5, 0x1d, 0x2d,
-	0xea, 0x43, 0xf7, 0xfd, 0x52, 0x9a, 0x75, 0xf4, 0x37, 0x00, 0xf8, 0x88, 0xeb, 0x04, 0x7f, 0xaf,
-	0x50, 0x1b, 0xf2, 0x04, 0x06, 0x9c, 0x2d, 0x51, 0x4b, 0x96, 0x21, 0x0d, 0xa6, 0xc1, 0x6c, 0x90,
-	0x6c, 0x00, 0x72, 0x08, 0xc0, 0x64, 0x71, 0x81, 0x4a, 0x17, 0x82, 0xd3, 0x96, 0x4d, 0x6f, 0x21,
-	0x84, 0x40, 0xe7, 0x57, 0xc1, 0x73, 0xda, 0xb6, 0x19, 0x7b, 0xae, 0xb0, 0xaa, 0x01, 0xed, 0x38,
-	0xac, 0x3a, 0x93, 0xb7, 0x30, 0x2e, 0x59, 0x8a, 0xe5, 0x17, 0x2c, 0x31, 0x33, 0x42, 0xd1, 0xee,
-	0x34, 0x98, 0x0d, 0x4f, 0x1e, 0xc7, 0x6e, 0xea, 0xb8, 0x9e, 0x3a, 0x3e, 0x5d, 0x1b, 0xd4, 0x17,
-	0xac, 0x5c, 0x61, 0xd2, 0xac, 0x88, 0x66, 0x30, 0xfa, 0x54, 0x68, 0x93, 0xa0, 0x96, 0x82, 0x6b,
-	0x24, 0x14, 0xfa, 0x22, 0xfd, 0x89, 0x99, 0xd1, 0x34, 0x98, 0xb6, 0x67, 0xa3, 0xa4, 0x0e, 0xa3,
-	0x67, 0x30, 0x3c, 0xc3, 0x0d, 0xf1, 0x21, 0xf4, 0x5c, 0xc6, 0xae, 0x37, 0x4a, 0x7c, 0x14, 0x3d,
-	0x87, 0xf1, 0x57, 0x99, 0x33, 0x83, 0xb5, 0x14, 0x37, 0x11, 0xef, 0xc1, 0x9d, 0x9a, 0xe8, 0x5a,
-	0x46, 0x7f, 0x02, 0x20, 0x9f, 0x85, 0x32, 0x0b, 0xa1, 0xae, 0x98, 0xca, 0x6f, 0xa7, 0x25, 0x85,
-	0xbe, 0x14, 0xf9, 0x79, 0x25, 0x8d, 0x13, 0xb2, 0x0e, 0xc9, 0x11, 0x8c, 0x33, 0xc1, 0x0d, 0x2b,
-	0x38, 0x2a, 0x9b, 0x77, 0x72, 0x36, 0xc1, 0xca, 0x0b, 0x29, 0x94, 0x39, 0x5f, 0x2d, 0x53, 0x54,
-	0x56, 0xdd, 0x71, 0xb2, 0x85, 0x44, 0xdf, 0x60, 0xd2, 0x98, 0xc9, 0xaf, 0x7f, 0x04, 0x63, 0xb9,
-	0x81, 0x3f, 0xcc, 0xfd, 0x60, 0x4d, 0xf0, 0x5a, 0xf3, 0xd6, 0x4e, 0xf3, 0x37, 0x40, 0xdf, 0x31,
-	0x9e, 0x61, 0xb9, 0x67, 0xed, 0x5b, 0xfd, 0xe1, 0xe4, 0x5f, 0x0b, 0x06, 0xf3, 0xfa, 0xee, 0x92,
-	0x18, 0x3a, 0x95, 0x9b, 0xe4, 0xc0, 0x59, 0x1f, 0x6f, 0x6e, 0x64, 0x38, 0xf1, 0x50, 0xc3, 0xed,
-	0x17, 0xd0, 0x3e, 0xc3, 0xbd, 0x74, 0xe2, 0xa1, 0x6d, 0xcb, 0x5f, 0x43, 0xcf, 0x39, 0x46, 0xee,
-	0xfb, 0x6c, 0xc3, 0xe9, 0xf0, 0xc1, 0x35, 0xd4, 0x97, 0xcd, 0x61, 0xb8, 0xb5, 0x1e, 0x79, 0xe4,
-	0x59, 0xbb, 0x2b, 0x87, 0xe1, 0xbe, 0x94, 0xef, 0x72, 0x0a, 0x07, 0x3b, 0x52, 0x91, 0xa7, 0xbe,
-	0xe0, 0x26, 0x11, 0xc3, 0x91, 0x27, 0xd8, 0x47, 0x4a, 0x5e, 0xc2, 0x64, 0x21, 0x54, 0x86, 0x0b,
-	0x25, 0xb8, 0x41, 0x9e, 0xfb, 0x6d, 0x1a, 0xa4, 0x66, 0x49, 0xda, 0xb3, 0xc1, 0xab, 0xff, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0x1a, 0x8c, 0xe2, 0x3b, 0x21, 0x04, 0x00, 0x00,
+	// 496 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8d, 0x53, 0x5d, 0x6f, 0xd3, 0x30,
+	0x14, 0x55, 0x3f, 0xa7, 0xde, 0x36, 0xc0, 0x5c, 0x86, 0x42, 0x40, 0x03, 0x45, 0x20, 0xfa, 0x80,
+	0x32, 0x31, 0xc4, 0x3b, 0x1b, 0x65, 0x08, 0x81, 0x26, 0x14, 0xc4, 0x5e, 0x78, 0x72, 0x92, 0xbb,
+	0x11, 0x48, 0x63, 0x63, 0xbb, 0x9a, 0xfa, 0xc3, 0xf8, 0x13, 0xfb, 0x0f, 0xfc, 0x97, 0x39, 0xb6,
+	0xa3, 0x34, 0x5d, 0x27, 0xed, 0xa9, 0xb9, 0xe7, 0x5c, 0xfb, 0x9e, 0x7b, 0x7c, 0x0a, 0xf7, 0x33,
+	0x2a, 0x7f, 0x25, 0x8c, 0x8a, 0x2c, 0xe2, 0x82, 0x29, 0x46, 0x06, 0xe6, 0x27, 0xd8, 0xbf, 0x60,
+	0xec, 0xa2, 0xc0, 0x03, 0x53, 0x25, 0xcb, 0xf3, 0x83, 0x4b, 0x41, 0x39, 0x47, 0x21, 0x6d, 0x5b,
+	0xb8, 0x03, 0x83, 0x8f, 0x0b, 0xae, 0x56, 0xe1, 0xbf, 0x0e, 0xc0, 0x17, 0x5c, 0xc5, 0xf8, 0x77,
+	0x89, 0x52, 0x91, 0xa7, 0x30, 0x2a, 0xe9, 0x02, 0x25, 0xa7, 0x29, 0xfa, 0x9d, 0xe7, 0x9d, 0xd9,
+	0x28, 0x6e, 0x00, 0xb2, 0x0f, 0x40, 0x79, 0x7e, 0xa6, 0xaf, 0xc9, 0x59, 0xe9, 0x77, 0x0d, 0xbd,
+	0x86, 0x10, 0x02, 0xfd, 0x3f, 0x79, 0x99, 0xf9, 0x3d, 0xc3, 0x98, 0xef, 0x0a, 0xab, 0x2e, 0xf0,
+	0xfb, 0x16, 0xab, 0xbe, 0xc9, 0x11, 0x78, 0x05, 0x4d, 0xb0, 0xf8, 0x8e, 0x05, 0xa6, 0x8a, 0x09,
+	0x7f, 0xa0, 0xc9, 0xf1, 0xe1, 0x93, 0xc8, 0xaa, 0x8e, 0x6a, 0xd5, 0xd1, 0xf1, 0x4a, 0xa1, 0x3c,
+	0xa3, 0xc5, 0x12, 0xe3, 0xf6, 0x89, 0x70, 0x06, 0x93, 0xaf, 0xb9, 0x54, 0xb1, 0x56, 0xc6, 0x4a,
+	0x89, 0xc4, 0x87, 0x1d, 0x96, 0xfc, 0xd6, 0x9c, 0xd4, 0xb2, 0x7b, 0xb3, 0x49, 0x5c, 0x97, 0xe1,
+	0x4b, 0x18, 0x7f, 0xc2, 0xa6, 0xf1, 0x11, 0x0c, 0x2d, 0x63, 0xd6, 0x9b, 0xc4, 0xae, 0x0a, 0x5f,
+	0x81, 0xf7, 0x83, 0x67, 0x54, 0x61, 0x6d, 0xc5, 0x6d, 0x8d, 0x0f, 0xe0, 0x5e, 0xdd, 0x68, 0xaf,
+	0x0c, 0xaf, 0x3a, 0x40, 0xbe, 0x31, 0xa1, 0x4e, 0x98, 0xb8, 0xd4, 0x2f, 0x71, 0x37, 0x2f, 0xb5,
+	0x60, 0xce, 0xb2, 0xd3, 0xca, 0x1a, 0x6b, 0x64, 0x5d, 0x92, 0x17, 0xe0, 0xa5, 0xac, 0x54, 0x34,
+	0x2f, 0x51, 0x18, 0xde, 0xda, 0xd9, 0x06, 0xab, 0xb7, 0xe0, 0x7a, 0xe6, 0xe9, 0x72, 0x91, 0xa0,
+	0x30, 0xee, 0x7a, 0xf1, 0x1a, 0x52, 0x4d, 0x2f, 0x58, 0x4a, 0x8b, 0x4a, 0x98, 0xf1, 0xd7, 0x8b,
+	0x1b, 0xa0, 0x9a, 0x4e, 0xb3, 0x4c, 0xa0, 0x94, 0xfe, 0xd0, 0x4e, 0x77, 0x65, 0xf8, 0x13, 0xa6,
+	0xad, 0x5d, 0x9c, 0x6d, 0x5a, 0x14, 0x6f, 0xe0, 0xcf, 0x73, 0xb7, 0x50, 0x1b, 0xdc, 0x10, 0xd5,
+	0xdd, 0x14, 0x15, 0xbe, 0x07, 0xff, 0x03, 0x2d, 0x53, 0x2c, 0xb6, 0xd8, 0x75, 0xa7, 0x09, 0x87,
+	0xff, 0xbb, 0x30, 0x9a, 0xd7, 0x99, 0x27, 0x11, 0xf4, 0xab, 0x14, 0x90, 0x5d, 0x1b, 0x99, 0xa8,
+	0x49, 0x72, 0x30, 0x75, 0x50, 0x2b, 0x25, 0xaf, 0xa1, 0xa7, 0xb3, 0xb0, 0xad, 0x9d, 0x38, 0x68,
+	0x3d, 0x2a, 0xef, 0x60, 0x68, 0x5f, 0x9a, 0x3c, 0x74, 0x6c, 0x2b, 0x21, 0xc1, 0xde, 0x06, 0xea,
+	0x8e, 0xcd, 0x61, 0xbc, 0xb6, 0x1e, 0x79, 0xec, 0xba, 0x6e, 0xae, 0x1c, 0x04, 0xdb, 0x28, 0x77,
+	0xcb, 0x31, 0xec, 0xde, 0xb0, 0x8a, 0x3c, 0x73, 0x07, 0x6e, 0x33, 0x31, 0x98, 0xb8, 0x06, 0xf3,
+	0xe7, 0x26, 0x6f, 0x60, 0xaa, 0xf9, 0x14, 0x4f, 0x84, 0x8e, 0x0e, 0x96, 0x99, 0xdb, 0xa6, 0xd5,
+	0xd4, 0x3e, 0x92, 0x0c, 0x4d, 0xf1, 0xf6, 0x1a, 0x3d, 0xfc, 0x84, 0xb4, 0x59, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
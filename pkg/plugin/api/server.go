@@ -23,6 +23,10 @@ type PortForwardRequest struct {
 	PodName       string
 	ContainerName string
 	Port          uint16
+	// LocalPort is the local port to listen on. A random port is used if it is zero.
+	LocalPort uint16
+	// Address is the address to listen on. Localhost is used if it is blank.
+	Address string
 }
 
 // PortForwardResponse is the response from a port forward request.
@@ -100,7 +104,9 @@ func (s *GRPCService) PortForward(ctx context.Context, req PortForwardRequest) (
 		gvk.Pod,
 		req.PodName,
 		req.Namespace,
-		req.Port)
+		req.Port,
+		portforward.WithLocalPort(req.LocalPort),
+		portforward.WithAddress(req.Address))
 	if err != nil {
 		return PortForwardResponse{}, err
 	}
@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package store
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// SubresourceAccess is implemented by stores which can check access to subresources.
+type SubresourceAccess interface {
+	HasSubresourceAccess(ctx context.Context, key Key, subresource, verb string) error
+}
+
+// HasSubresourceAccess returns an error if verb is not allowed on a subresource of
+// the object described by key. A blank subresource checks the object itself. Access
+// is denied if objectStore can't check it.
+func HasSubresourceAccess(ctx context.Context, objectStore interface{}, key Key, subresource, verb string) error {
+	access, ok := objectStore.(SubresourceAccess)
+	if !ok {
+		return errors.Errorf("unable to check access with object store %T", objectStore)
+	}
+
+	return access.HasSubresourceAccess(ctx, key, subresource, verb)
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAccess struct {
+	allowed map[string]bool
+}
+
+func (a *fakeAccess) HasSubresourceAccess(ctx context.Context, key Key, subresource, verb string) error {
+	if !a.allowed[subresource+"/"+verb] {
+		return errors.Errorf("%s %s is forbidden", verb, subresource)
+	}
+	return nil
+}
+
+func TestHasSubresourceAccess(t *testing.T) {
+	key := Key{Namespace: "default", ApiVersion: "v1", Kind: "Pod", Name: "pod"}
+	access := &fakeAccess{allowed: map[string]bool{"exec/create": true}}
+
+	tests := []struct {
+		name        string
+		objectStore interface{}
+		subresource string
+		isErr       bool
+	}{
+		{
+			name:        "allowed",
+			objectStore: access,
+			subresource: "exec",
+		},
+		{
+			name:        "forbidden",
+			objectStore: access,
+			subresource: "attach",
+			isErr:       true,
+		},
+		{
+			name:        "store can't check access",
+			objectStore: struct{}{},
+			subresource: "exec",
+			isErr:       true,
+		},
+		{
+			name:        "no store",
+			subresource: "exec",
+			isErr:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := HasSubresourceAccess(context.Background(), test.objectStore, key, test.subresource, "create")
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -6,6 +6,7 @@
 package store
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/kubenext/kubeon/pkg/action"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -93,6 +94,24 @@ func KeyFromPayload(payload action.Payload) (Key, error) {
 	}, nil
 }
 
+// RowKey returns a string which identifies the object of a key in a table
+// row. It can be converted back with KeyFromRowKey.
+func (k Key) RowKey() string {
+	// a payload of strings can't fail to marshal
+	data, _ := json.Marshal(k.ToActionPayload())
+	return string(data)
+}
+
+// KeyFromRowKey converts a table row key into a Key.
+func KeyFromRowKey(rowKey string) (Key, error) {
+	var payload action.Payload
+	if err := json.Unmarshal([]byte(rowKey), &payload); err != nil {
+		return Key{}, fmt.Errorf("invalid row key %q: %v", rowKey, err)
+	}
+
+	return KeyFromPayload(payload)
+}
+
 // KeyFromObject creates a key from a runtime object.
 func KeyFromObject(object runtime.Object) (Key, error) {
 	accessor := meta.NewAccessor()
@@ -13,6 +13,7 @@ const (
 	typeContainers         = "containers"
 	typeError              = "error"
 	typeExpressionSelector = "expressionSelector"
+	typeFileBrowser        = "fileBrowser"
 	typeFlexLayout         = "flexlayout"
 	typeGraphviz           = "graphviz"
 	typeLabels             = "labels"
@@ -30,6 +31,7 @@ const (
 	typeSelectors          = "selectors"
 	typeSummary            = "summary"
 	typeTable              = "table"
+	typeTerminal           = "terminal"
 	typeText               = "text"
 	typeTimestamp          = "timestamp"
 	typeYAML               = "yaml"
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package component
+
+import (
+	"encoding/json"
+)
+
+// FileBrowserConfig is the contents of FileBrowser.
+type FileBrowserConfig struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Container string `json:"container,omitempty"`
+	// Path is the directory shown first.
+	Path string `json:"path,omitempty"`
+}
+
+// FileBrowser is a component for browsing, downloading and uploading files
+// in a container.
+type FileBrowser struct {
+	base
+	Config FileBrowserConfig `json:"config"`
+}
+
+// NewFileBrowser creates a file browser component.
+func NewFileBrowser(namespace, name, container, path string) *FileBrowser {
+	return &FileBrowser{
+		base: newBase(typeFileBrowser, TitleFromString("Files")),
+		Config: FileBrowserConfig{
+			Namespace: namespace,
+			Name:      name,
+			Container: container,
+			Path:      path,
+		},
+	}
+}
+
+// GetMetadata accesses the components metadata. Implements Component.
+func (fb *FileBrowser) GetMetadata() Metadata {
+	return fb.Metadata
+}
+
+type fileBrowserMarshal FileBrowser
+
+// MarshalJSON implements json.Marshaler
+func (fb *FileBrowser) MarshalJSON() ([]byte, error) {
+	m := fileBrowserMarshal(*fb)
+	m.Metadata.Type = typeFileBrowser
+	return json.Marshal(&m)
+}
@@ -22,6 +22,7 @@ const (
 	FieldTypeSelect   = "select"
 	FieldTypeTextarea = "textarea"
 	FieldTypeHidden   = "hidden"
+	FieldTypeFile     = "file"
 )
 
 type InputChoice struct {
@@ -497,6 +498,64 @@ func (ff *FormFieldHidden) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// FormFieldFile is a field for uploading files. The contents of the chosen
+// files are submitted as a list of strings.
+type FormFieldFile struct {
+	*baseFormField
+
+	accept   string
+	multiple bool
+}
+
+// NewFormFieldFile creates a file field. Accept lists the file types which
+// can be chosen, such as ".yaml,.json".
+func NewFormFieldFile(label, name, accept string, multiple bool) *FormFieldFile {
+	return &FormFieldFile{
+		baseFormField: newBaseFormField(label, name, FieldTypeFile),
+		accept:        accept,
+		multiple:      multiple,
+	}
+}
+
+var _ FormField = (*FormFieldFile)(nil)
+
+func (ff *FormFieldFile) Configuration() map[string]interface{} {
+	return map[string]interface{}{
+		"accept":   ff.accept,
+		"multiple": ff.multiple,
+	}
+}
+
+func (ff *FormFieldFile) Value() interface{} {
+	return []string{}
+}
+
+func (ff *FormFieldFile) MarshalJSON() ([]byte, error) {
+	return marshalFormField(ff)
+}
+
+func (ff *FormFieldFile) UnmarshalJSON(data []byte) error {
+	x := struct {
+		Label         string `json:"label"`
+		Name          string `json:"name"`
+		Type          string `json:"type"`
+		Configuration struct {
+			Accept   string `json:"accept"`
+			Multiple bool   `json:"multiple"`
+		} `json:"configuration"`
+	}{}
+
+	if err := json.Unmarshal(data, &x); err != nil {
+		return err
+	}
+
+	ff.baseFormField = newBaseFormField(x.Label, x.Name, x.Type)
+	ff.accept = x.Configuration.Accept
+	ff.multiple = x.Configuration.Multiple
+
+	return nil
+}
+
 type Form struct {
 	Fields []FormField `json:"fields"`
 }
@@ -563,6 +622,8 @@ func (f *Form) UnmarshalJSON(data []byte) error {
 			ff = &FormFieldTextarea{}
 		case FieldTypeHidden:
 			ff = &FormFieldHidden{}
+		case FieldTypeFile:
+			ff = &FormFieldFile{}
 		default:
 			return errors.Errorf("unknown form field type %q", field)
 		}
@@ -599,3 +660,10 @@ func CreateFormForObject(actionName string, object runtime.Object, fields ...For
 
 	return Form{Fields: fields}, nil
 }
+
+// CreateBulkForm creates a form for an action which runs for the selected
+// rows of a table.
+func CreateBulkForm(actionName string, fields ...FormField) Form {
+	fields = append(fields, NewFormFieldHidden("action", actionName))
+	return Form{Fields: fields}
+}
@@ -9,10 +9,27 @@ import (
 	"encoding/json"
 )
 
+const (
+	// LogsModeRaw shows log lines as they were written.
+	LogsModeRaw = "raw"
+	// LogsModeTable shows the fields of structured log lines as table columns.
+	LogsModeTable = "table"
+)
+
+// DefaultLogsColumns are the columns shown for structured logs in table mode.
+var DefaultLogsColumns = []string{"level", "msg"}
+
 type LogsConfig struct {
 	Namespace  string   `json:"namespace,omitempty"`
 	Name       string   `json:"name,omitempty"`
 	Containers []string `json:"containers,omitempty"`
+	// Selector is a label selector. If it is set, logs for all matching
+	// pods are streamed together instead of the logs for a single pod.
+	Selector string `json:"selector,omitempty"`
+	// Mode is the initial view mode. Users can switch between modes.
+	Mode string `json:"mode,omitempty"`
+	// Columns are the initial structured log fields shown in table mode.
+	Columns []string `json:"columns,omitempty"`
 }
 
 type Logs struct {
@@ -26,11 +43,29 @@ func NewLogs(namespace, name string, containers []string) *Logs {
 			Namespace:  namespace,
 			Name:       name,
 			Containers: containers,
+			Mode:       LogsModeRaw,
+			Columns:    DefaultLogsColumns,
 		},
 		base: newBase(typeLogs, TitleFromString("Logs")),
 	}
 }
 
+// NewSelectorLogs creates a logs component which streams the logs for all pods
+// in a namespace matching a label selector.
+func NewSelectorLogs(namespace, selector string, containers []string) *Logs {
+	l := NewLogs(namespace, "", containers)
+	l.Config.Selector = selector
+	return l
+}
+
+// SetTableMode shows structured logs as a table with columns.
+func (l *Logs) SetTableMode(columns ...string) {
+	l.Config.Mode = LogsModeTable
+	if len(columns) > 0 {
+		l.Config.Columns = columns
+	}
+}
+
 // GetMetadata accesses the components metadata. Implements Component.
 func (l *Logs) GetMetadata() Metadata {
 	return l.Metadata
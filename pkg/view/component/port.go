@@ -12,6 +12,12 @@ type PortForwardState struct {
 	IsForwarded   bool   `json:"isForwarded,omitempty"`
 	Port          int    `json:"port,omitempty"`
 	ID            string `json:"id,omitempty"`
+	// Address is the address the forwarded port listens on.
+	Address string `json:"address,omitempty"`
+	// Status is the status of a forwarded port, e.g. active or reconnecting.
+	Status string `json:"status,omitempty"`
+	// Message describes why a forwarded port is reconnecting.
+	Message string `json:"message,omitempty"`
 }
 
 // Port is a component for a port
@@ -29,6 +35,8 @@ type PortConfig struct {
 	Port       int              `json:"port,omitempty"`
 	Protocol   string           `json:"protocol,omitempty"`
 	State      PortForwardState `json:"state,omitempty"`
+	// PreviewPath is the dashboard path which proxies HTTP requests to the port.
+	PreviewPath string `json:"previewPath,omitempty"`
 }
 
 // NewPort creates a port component
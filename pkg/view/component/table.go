@@ -14,6 +14,12 @@ import (
 	"github.com/davecgh/go-spew/spew"
 )
 
+const (
+	// TableRowKeyAccessor is the accessor of the hidden cell which identifies
+	// the object in a row. Only rows with a key can be selected.
+	TableRowKeyAccessor = "_key"
+)
+
 // TableFilter describer a text filter for a table.
 type TableFilter struct {
 	Values   []string `json:"values"`
@@ -27,6 +33,11 @@ type TableConfig struct {
 	EmptyContent string                 `json:"emptyContent"`
 	Loading      bool                   `json:"loading"`
 	Filters      map[string]TableFilter `json:"filters"`
+	// Selectable is true if rows can be selected for bulk actions.
+	Selectable bool `json:"selectable,omitempty"`
+	// BulkActions are run for the selected rows. The keys of the selected
+	// rows are sent in the action payload as "selected".
+	BulkActions []Action `json:"bulkActions,omitempty"`
 }
 
 // TableCol describes a column from a table. Accessor is the key this
@@ -39,6 +50,21 @@ type TableCol struct {
 // TableRow is a row in table. Each key->value represents a particular column in the row.
 type TableRow map[string]Component
 
+// SetKey sets the key which identifies the object in a row.
+func (t TableRow) SetKey(key string) {
+	t[TableRowKeyAccessor] = NewText(key)
+}
+
+// Key returns the key which identifies the object in a row.
+func (t TableRow) Key() (string, bool) {
+	text, ok := t[TableRowKeyAccessor].(*Text)
+	if !ok {
+		return "", false
+	}
+
+	return text.Config.Text, true
+}
+
 func (t *TableRow) UnmarshalJSON(data []byte) error {
 	*t = make(TableRow)
 
@@ -166,6 +192,16 @@ func (t *Table) AddFilter(columnName string, filter TableFilter) {
 	t.Config.Filters[columnName] = filter
 }
 
+// AddBulkAction adds an action for selected rows and makes the table
+// selectable.
+func (t *Table) AddBulkAction(action Action) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.Config.Selectable = true
+	t.Config.BulkActions = append(t.Config.BulkActions, action)
+}
+
 // Columns returns the table columns.
 func (t *Table) Columns() []TableCol {
 	return t.Config.Columns
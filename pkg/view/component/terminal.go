@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2019 Kubenext, Inc. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package component
+
+import (
+	"encoding/json"
+)
+
+// TerminalConfig is the contents of Terminal.
+type TerminalConfig struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Container string `json:"container,omitempty"`
+	// Command is run when a terminal is started. The default shell is used if it is blank.
+	Command string `json:"command,omitempty"`
+	// ID is the ID of a running terminal. If it is set, the terminal is attached
+	// to instead of starting a new one.
+	ID string `json:"id,omitempty"`
+}
+
+// Terminal is a component for an interactive terminal in a container.
+type Terminal struct {
+	base
+	Config TerminalConfig `json:"config"`
+}
+
+// NewTerminal creates a terminal component.
+func NewTerminal(namespace, name, container, command string) *Terminal {
+	return &Terminal{
+		base: newBase(typeTerminal, TitleFromString(container)),
+		Config: TerminalConfig{
+			Namespace: namespace,
+			Name:      name,
+			Container: container,
+			Command:   command,
+		},
+	}
+}
+
+// GetMetadata accesses the components metadata. Implements Component.
+func (t *Terminal) GetMetadata() Metadata {
+	return t.Metadata
+}
+
+type terminalMarshal Terminal
+
+// MarshalJSON implements json.Marshaler
+func (t *Terminal) MarshalJSON() ([]byte, error) {
+	m := terminalMarshal(*t)
+	m.Metadata.Type = typeTerminal
+	return json.Marshal(&m)
+}
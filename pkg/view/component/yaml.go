@@ -17,7 +17,8 @@ import (
 )
 
 type YAMLConfig struct {
-	Data string `json:"data,omitempty"`
+	Data    string   `json:"data,omitempty"`
+	Actions []Action `json:"actions,omitempty"`
 }
 
 type YAML struct {
@@ -50,6 +51,11 @@ func (y *YAML) Data(object runtime.Object) error {
 	return nil
 }
 
+// AddAction adds an action to the YAML.
+func (y *YAML) AddAction(action Action) {
+	y.Config.Actions = append(y.Config.Actions, action)
+}
+
 // GetMetadata returns the component's metadata.
 func (y *YAML) GetMetadata() Metadata {
 	return y.Metadata